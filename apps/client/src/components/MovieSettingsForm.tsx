@@ -25,6 +25,7 @@ import { Separator } from "@/components/ui/separator";
 import Loading from "@/components/Loading";
 import { RadarrQualityProfile, RadarrRootFolder } from "@/types/radarr";
 import { MovieSettings } from "@/types/movies";
+import { apiFetch } from "@/lib/api";
 
 // Validation schema
 const movieFormSchema = z.object({
@@ -69,13 +70,13 @@ export default function MovieSettingsForm({
 
   const fetchRadarrData = async () => {
     try {
-      const rootFoldersResponse = await fetch(
+      const rootFoldersResponse = await apiFetch(
         `${import.meta.env.VITE_API_URL}/radarr/rootfolders`
       );
       const rootFolderData = await rootFoldersResponse.json();
       setRadarrRootFolder(rootFolderData);
 
-      const qualityProfilesResponse = await fetch(
+      const qualityProfilesResponse = await apiFetch(
         `${import.meta.env.VITE_API_URL}/radarr/profiles`
       );
       const qualityProfilesData = await qualityProfilesResponse.json();
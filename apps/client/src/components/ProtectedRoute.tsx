@@ -4,17 +4,19 @@ import { useEffect } from "react";
 import { useNavigate } from "react-router-dom";
 
 function ProtectedRoute({ element }: { element: JSX.Element }) {
-  const { setupComplete } = useSetupStatus();  // Use setupComplete from the context
+  const { setupComplete, authenticated } = useSetupStatus(); // Use setupComplete and authenticated from the context
   const navigate = useNavigate();
 
   useEffect(() => {
     if (setupComplete === false) {
       navigate("/setup");
+    } else if (authenticated === false) {
+      navigate("/login");
     }
-  }, [setupComplete, navigate]);
+  }, [setupComplete, authenticated, navigate]);
 
-  // Block rendering until setup status is confirmed
-  if (setupComplete === null) {
+  // Block rendering until setup status and session are confirmed
+  if (setupComplete === null || !authenticated) {
     return null; // Return nothing while setup status is being checked
   }
 
@@ -23,6 +23,7 @@ import { Separator } from "@/components/ui/separator";
 import { APIErrorBody } from "@/types/api_error";
 import { useNavigate } from "react-router-dom";
 import { useSetupStatus } from "@/context/SetupContext";
+import { apiFetch } from "@/lib/api";
 
 type Step = {
   title: string;
@@ -46,6 +47,10 @@ type QualityProfile = {
 };
 
 const steps: Step[] = [
+  {
+    title: "Admin Account",
+    description: "Create the account used to sign in to blockbusterr",
+  },
   {
     title: "Trakt Details",
     description: "Enter your Trakt client ID and client secret",
@@ -68,10 +73,12 @@ const modes: Mode[] = [
 
 export default function SettingsStepper() {
   const navigate = useNavigate();
-  const { checkSetupStatus } = useSetupStatus();
+  const { authenticated, checkSetupStatus } = useSetupStatus();
 
   const [currentStep, setCurrentStep] = useState(0);
   const [formData, setFormData] = useState({
+    adminUsername: "",
+    adminPassword: "",
     traktClientId: "",
     traktClientSecret: "",
     omdbApiKey: "",
@@ -88,9 +95,12 @@ export default function SettingsStepper() {
   >([]);
   const [sonarrError, setSonarrError] = useState<string | null>(null);
   const [radarrError, setRadarrError] = useState<string | null>(null);
+  const [setupError, setSetupError] = useState<string | null>(null);
 
   const isFormValid = () => {
     const {
+      adminUsername,
+      adminPassword,
       traktClientId,
       traktClientSecret,
       omdbApiKey,
@@ -98,6 +108,11 @@ export default function SettingsStepper() {
       settings,
     } = formData;
 
+    // The admin account is only created on the first run, later runs are already signed in
+    if (!authenticated && (!adminUsername || adminPassword.length < 8)) {
+      return false;
+    }
+
     if (selectedMode === "ombi") {
       return (
         traktClientId &&
@@ -148,6 +163,8 @@ export default function SettingsStepper() {
       setCurrentStep(currentStep + 1);
     } else if (isFormValid()) {
       const allSettings = {
+        adminUsername: formData.adminUsername,
+        adminPassword: formData.adminPassword,
         traktClientId: formData.traktClientId,
         traktClientSecret: formData.traktClientSecret,
         omdbApiKey: formData.omdbApiKey,
@@ -155,11 +172,9 @@ export default function SettingsStepper() {
         ...formData.settings,
       };
 
-      console.log("Submitting settings", allSettings);
-
       try {
         // First POST request to /settings/setup
-        const response = await fetch(
+        const response = await apiFetch(
           `${import.meta.env.VITE_API_URL}/settings/setup`,
           {
             method: "POST",
@@ -179,7 +194,7 @@ export default function SettingsStepper() {
         console.log("Settings saved successfully");
 
         // Second POST request to /settings?key=SETUP_COMPLETE
-        const setupCompleteResponse = await fetch(
+        const setupCompleteResponse = await apiFetch(
           `${import.meta.env.VITE_API_URL}/settings?key=SETUP_COMPLETE`,
           {
             method: "POST",
@@ -206,7 +221,7 @@ export default function SettingsStepper() {
         // Redirect the user to the dashboard
         navigate("/");
       } catch (error) {
-        console.error(
+        setSetupError(
           error instanceof Error ? error.message : "An error occurred"
         );
       }
@@ -248,7 +263,7 @@ export default function SettingsStepper() {
     }
 
     try {
-      const rootFoldersResponse = await fetch(
+      const rootFoldersResponse = await apiFetch(
         `${import.meta.env.VITE_API_URL}/sonarr/rootfolders?url=${baseUrl}`,
         {
           headers: {
@@ -263,7 +278,7 @@ export default function SettingsStepper() {
       const rootFolders = await rootFoldersResponse.json();
       setSonarrRootFolders(rootFolders);
 
-      const qualityProfilesResponse = await fetch(
+      const qualityProfilesResponse = await apiFetch(
         `${import.meta.env.VITE_API_URL}/sonarr/profiles?url=${baseUrl}`,
         {
           headers: {
@@ -299,7 +314,7 @@ export default function SettingsStepper() {
     }
 
     try {
-      const rootFoldersResponse = await fetch(
+      const rootFoldersResponse = await apiFetch(
         `${import.meta.env.VITE_API_URL}/radarr/rootfolders?url=${baseUrl}`,
         {
           headers: {
@@ -314,7 +329,7 @@ export default function SettingsStepper() {
       const rootFolders = await rootFoldersResponse.json();
       setRadarrRootFolders(rootFolders);
 
-      const qualityProfilesResponse = await fetch(
+      const qualityProfilesResponse = await apiFetch(
         `${import.meta.env.VITE_API_URL}/radarr/profiles?url=${baseUrl}`,
         {
           headers: {
@@ -341,6 +356,45 @@ export default function SettingsStepper() {
   const renderStepContent = () => {
     switch (currentStep) {
       case 0:
+        if (authenticated) {
+          return (
+            <p className="text-sm text-muted-foreground">
+              You're already signed in, so the admin account exists.
+            </p>
+          );
+        }
+
+        return (
+          <div className="space-y-4">
+            <div className="space-y-2">
+              <Label htmlFor="admin-username">Username</Label>
+              <Input
+                id="admin-username"
+                autoComplete="username"
+                value={formData.adminUsername}
+                onChange={(e) =>
+                  handleInputChange("adminUsername", e.target.value)
+                }
+                placeholder="Enter a username"
+              />
+            </div>
+            <div className="space-y-2">
+              <Label htmlFor="admin-password">Password</Label>
+              <Input
+                id="admin-password"
+                type="password"
+                autoComplete="new-password"
+                value={formData.adminPassword}
+                onChange={(e) =>
+                  handleInputChange("adminPassword", e.target.value)
+                }
+                placeholder="At least 8 characters"
+              />
+            </div>
+          </div>
+        );
+
+      case 1:
         return (
           <div className="space-y-4">
             <div className="space-y-2">
@@ -369,7 +423,7 @@ export default function SettingsStepper() {
           </div>
         );
 
-      case 1:
+      case 2:
         return (
           <div className="space-y-4">
             <div className="space-y-2">
@@ -386,7 +440,7 @@ export default function SettingsStepper() {
           </div>
         );
 
-      case 2:
+      case 3:
         return (
           <RadioGroup
             value={formData.selectedMode}
@@ -400,7 +454,7 @@ export default function SettingsStepper() {
             ))}
           </RadioGroup>
         );
-      case 3:
+      case 4:
         return renderModeSettings();
       default:
         return null;
@@ -661,7 +715,10 @@ export default function SettingsStepper() {
         <CardTitle>{steps[currentStep].title}</CardTitle>
         <CardDescription>{steps[currentStep].description}</CardDescription>
       </CardHeader>
-      <CardContent>{renderStepContent()}</CardContent>
+      <CardContent className="space-y-4">
+        {setupError && <div className="text-red-500">Error: {setupError}</div>}
+        {renderStepContent()}
+      </CardContent>
       <CardFooter className="flex justify-between">
         <Button onClick={handlePrevious} disabled={currentStep === 0}>
           Previous
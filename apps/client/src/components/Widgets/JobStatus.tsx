@@ -4,6 +4,7 @@ import { useState, useEffect } from "react";
 import { Clock, Film, Tv } from "lucide-react";
 import { Separator } from "@/components/ui/separator";
 import { JobStatus } from "@/types/job";
+import { apiFetch } from "@/lib/api";
 
 type GroupedJobs = {
   [key: string]: {
@@ -17,7 +18,7 @@ export default function VerticalJobStatusWidget() {
   useEffect(() => {
     const fetchJobStatus = async () => {
       try {
-        const response = await fetch(
+        const response = await apiFetch(
           `${import.meta.env.VITE_API_URL}/jobs/status`
         );
         if (!response.ok) throw new Error("Failed to fetch job status");
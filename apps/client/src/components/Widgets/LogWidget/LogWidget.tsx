@@ -11,6 +11,7 @@ import {
   SelectTrigger,
   SelectValue,
 } from "@/components/ui/select";
+import { apiFetch } from "@/lib/api";
 
 export default function LogWidget() {
   const [logs, setLogs] = React.useState<Log[]>([]);
@@ -33,7 +34,7 @@ export default function LogWidget() {
         params.append("search", searchTerm);
       }
 
-      const response = await fetch(
+      const response = await apiFetch(
         `${import.meta.env.VITE_API_URL}/logs?${params.toString()}`
       );
       const responseData = await response.json();
@@ -8,6 +8,7 @@ import {
   CarouselPrevious,
 } from "@/components/ui/carousel";
 import { RecentlyAddedMedia } from "@/types/recently_added";
+import { apiFetch } from "@/lib/api";
 
 export default function MoviePosterCarousel() {
   const [items, setItems] = React.useState<RecentlyAddedMedia[]>([]);
@@ -22,7 +23,7 @@ export default function MoviePosterCarousel() {
   const fetchRecentlyAddedMedia = async (page: number) => {
     setLoading(true);
     try {
-      const response = await fetch(
+      const response = await apiFetch(
         `${
           import.meta.env.VITE_API_URL
         }/media/recentlyadded?page=${page}&pageSize=${pageSize}`
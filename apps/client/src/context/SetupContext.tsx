@@ -1,32 +1,42 @@
 import React, { createContext, useContext, useEffect, useState } from "react";
+import { apiFetch } from "@/lib/api";
 
-// Define the expected types for mode and setupComplete
+// Define the expected types for mode, setupComplete and authenticated
 interface SetupContextType {
   setupComplete: boolean | null;
+  authenticated: boolean | null;
   mode: "ombi" | "radarr-sonarr" | null;
-  checkSetupStatus: () => void;
-  checkMode: () => void;
+  checkSetupStatus: () => Promise<void>;
+  checkMode: () => Promise<void>;
 }
 
 // Default values for the context
 const SetupContext = createContext<SetupContextType>({
   setupComplete: null,
+  authenticated: null,
   mode: null,
-  checkSetupStatus: () => {},
-  checkMode: () => {},
+  checkSetupStatus: async () => {},
+  checkMode: async () => {},
 });
 
 // Provider to manage setup status and mode
 export function SetupProvider({ children }: { children: React.ReactNode }) {
   const [setupComplete, setSetupComplete] = useState<boolean | null>(null);
+  const [authenticated, setAuthenticated] = useState<boolean | null>(null);
   const [mode, setMode] = useState<"ombi" | "radarr-sonarr" | null>(null);
 
   const checkSetupStatus = async () => {
     try {
-      const res = await fetch(
+      const res = await apiFetch(
         `${import.meta.env.VITE_API_URL}/settings?key=SETUP_COMPLETE`
       );
 
+      // The settings are only open until the admin user exists, after that a session is needed
+      if (res.status === 401) {
+        setAuthenticated(false);
+        return;
+      }
+
       // Check if the response is valid and in JSON format
       const contentType = res.headers.get("content-type");
       if (contentType && contentType.includes("application/json")) {
@@ -35,6 +45,9 @@ export function SetupProvider({ children }: { children: React.ReactNode }) {
       } else {
         throw new Error(`Unexpected content-type: ${contentType}`);
       }
+
+      const meRes = await apiFetch(`${import.meta.env.VITE_API_URL}/auth/me`);
+      setAuthenticated(meRes.ok);
     } catch (error) {
       console.error("Error checking setup status", error);
     }
@@ -42,10 +55,15 @@ export function SetupProvider({ children }: { children: React.ReactNode }) {
 
   const checkMode = async () => {
     try {
-      const res = await fetch(
+      const res = await apiFetch(
         `${import.meta.env.VITE_API_URL}/settings?key=MODE`
       );
 
+      // Signed out, the mode is fetched again after signing in
+      if (res.status === 401) {
+        return;
+      }
+
       // Check if the response is valid and in JSON format
       const contentType = res.headers.get("content-type");
       if (contentType && contentType.includes("application/json")) {
@@ -69,6 +87,7 @@ export function SetupProvider({ children }: { children: React.ReactNode }) {
     <SetupContext.Provider
       value={{
         setupComplete,
+        authenticated,
         mode,
         checkSetupStatus,
         checkMode,
@@ -0,0 +1,4 @@
+// apiFetch calls the API with the session cookie, which every route needs once the admin user exists
+export function apiFetch(input: string, init: RequestInit = {}) {
+  return fetch(input, { ...init, credentials: "include" });
+}
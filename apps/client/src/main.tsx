@@ -2,6 +2,7 @@ import { createRoot } from "react-dom/client";
 import { createBrowserRouter, RouterProvider } from "react-router-dom";
 import Root from "@/routes/root.tsx";
 import Setup from "@/routes/setup";
+import Login from "@/routes/login";
 import ProtectedRoute from "@/components/ProtectedRoute";
 import Radarr from "@/routes/radarr";
 import Sonarr from "@/routes/sonarr";
@@ -19,6 +20,10 @@ const router = createBrowserRouter([
     path: "/setup",
     element: <Setup />,
   },
+  {
+    path: "/login",
+    element: <Login />,
+  },
   {
     path: "/settings",
     element: <ProtectedRoute element={<Settings />} />,
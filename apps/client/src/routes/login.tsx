@@ -0,0 +1,165 @@
+import { FormEvent, useEffect, useState } from "react";
+import { useNavigate } from "react-router-dom";
+import { Button } from "@/components/ui/button";
+import {
+  Card,
+  CardContent,
+  CardDescription,
+  CardFooter,
+  CardHeader,
+  CardTitle,
+} from "@/components/ui/card";
+import { Input } from "@/components/ui/input";
+import { Label } from "@/components/ui/label";
+import { useSetupStatus } from "@/context/SetupContext";
+import { APIErrorBody } from "@/types/api_error";
+import { AuthStatus } from "@/types/auth";
+import { apiFetch } from "@/lib/api";
+
+export default function Login() {
+  const navigate = useNavigate();
+  const { authenticated, checkSetupStatus, checkMode } = useSetupStatus();
+
+  const [username, setUsername] = useState("");
+  const [password, setPassword] = useState("");
+  const [bootstrapToken, setBootstrapToken] = useState("");
+  const [register, setRegister] = useState(false);
+  const [error, setError] = useState<string | null>(null);
+  const [submitting, setSubmitting] = useState(false);
+
+  useEffect(() => {
+    if (authenticated) {
+      navigate("/");
+      return;
+    }
+
+    // Installs that finished the setup before sign in existed create their admin user here,
+    // with the bootstrap token the server logs at startup
+    const checkUsers = async () => {
+      try {
+        const res = await apiFetch(`${import.meta.env.VITE_API_URL}/auth/status`);
+        if (!res.ok) {
+          return;
+        }
+
+        const status: AuthStatus = await res.json();
+        if (status.bootstrap_token_required) {
+          setRegister(true);
+        } else if (!status.setup_complete && !status.has_users) {
+          navigate("/setup");
+        }
+      } catch (error) {
+        console.error("Error checking users", error);
+      }
+    };
+
+    checkUsers();
+  }, [authenticated, navigate]);
+
+  const handleSubmit = async (e: FormEvent) => {
+    e.preventDefault();
+    setSubmitting(true);
+
+    try {
+      const res = await apiFetch(
+        `${import.meta.env.VITE_API_URL}/auth/${register ? "register" : "login"}`,
+        {
+          method: "POST",
+          headers: {
+            "Content-Type": "application/json",
+          },
+          body: JSON.stringify(
+            register
+              ? { username, password, bootstrap_token: bootstrapToken }
+              : { username, password }
+          ),
+        }
+      );
+
+      if (!res.ok) {
+        const contentType = res.headers.get("content-type");
+        if (contentType && contentType.includes("application/json")) {
+          const errorBody: APIErrorBody = await res.json();
+          throw new Error(errorBody.error.error);
+        }
+        throw new Error(`Error ${res.status}: ${res.statusText}`);
+      }
+
+      setError(null);
+      await checkSetupStatus();
+      await checkMode();
+      navigate("/");
+    } catch (error) {
+      setError(error instanceof Error ? error.message : "An error occurred");
+    } finally {
+      setSubmitting(false);
+    }
+  };
+
+  return (
+    <div className="flex items-center justify-center min-h-screen bg-background">
+      <Card className="w-full max-w-[400px] mx-auto my-8">
+        <form onSubmit={handleSubmit}>
+          <CardHeader>
+            <CardTitle>{register ? "Create Admin Account" : "Sign In"}</CardTitle>
+            <CardDescription>
+              {register
+                ? "Create the account used to sign in to blockbusterr"
+                : "Sign in to manage blockbusterr"}
+            </CardDescription>
+          </CardHeader>
+          <CardContent className="space-y-4">
+            {error && <div className="text-red-500">Error: {error}</div>}
+            {register && (
+              <div className="space-y-2">
+                <Label htmlFor="bootstrap-token">Bootstrap Token</Label>
+                <Input
+                  id="bootstrap-token"
+                  autoComplete="off"
+                  value={bootstrapToken}
+                  onChange={(e) => setBootstrapToken(e.target.value)}
+                />
+                <p className="text-sm text-muted-foreground">
+                  Printed in the server log on startup, or set with BLOCKBUSTERR_BOOTSTRAP_TOKEN
+                </p>
+              </div>
+            )}
+            <div className="space-y-2">
+              <Label htmlFor="username">Username</Label>
+              <Input
+                id="username"
+                autoComplete="username"
+                value={username}
+                onChange={(e) => setUsername(e.target.value)}
+              />
+            </div>
+            <div className="space-y-2">
+              <Label htmlFor="password">Password</Label>
+              <Input
+                id="password"
+                type="password"
+                autoComplete={register ? "new-password" : "current-password"}
+                value={password}
+                onChange={(e) => setPassword(e.target.value)}
+              />
+            </div>
+          </CardContent>
+          <CardFooter>
+            <Button
+              type="submit"
+              className="w-full"
+              disabled={
+                submitting ||
+                !username ||
+                !password ||
+                (register && !bootstrapToken)
+              }
+            >
+              {register ? "Create Account" : "Sign In"}
+            </Button>
+          </CardFooter>
+        </form>
+      </Card>
+    </div>
+  );
+}
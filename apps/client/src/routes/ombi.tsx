@@ -3,6 +3,7 @@ import { Tabs, TabsContent, TabsList, TabsTrigger } from "@/components/ui/tabs";
 import MovieSettingsForm from "@/components/MovieSettingsForm";
 import ShowSettingsForm from "@/components/ShowSettingsForm";
 import Loading from "@/components/Loading";
+import { apiFetch } from "@/lib/api";
 
 export default function Ombi() {
   const [movieSettings, setMovieSettings] = React.useState(null);
@@ -11,13 +12,13 @@ export default function Ombi() {
 
   const fetchSettings = async () => {
     try {
-      const movieResponse = await fetch(
+      const movieResponse = await apiFetch(
         `${import.meta.env.VITE_API_URL}/movie/settings`
       );
       const movieData = await movieResponse.json();
       setMovieSettings(movieData);
 
-      const showResponse = await fetch(
+      const showResponse = await apiFetch(
         `${import.meta.env.VITE_API_URL}/show/settings`
       );
       const showData = await showResponse.json();
@@ -30,6 +30,7 @@ import cronValidator from "cron-validate";
 
 import { MovieSettings } from "@/types/movies";
 import FormCronJobField from "@/components/FormCronJobField";
+import { apiFetch } from "@/lib/api";
 
 const cronExpressionSchema = z.string().refine(
   (value) => {
@@ -89,25 +90,25 @@ export default function Radarr() {
 
   const fetchSettings = async () => {
     try {
-      const movieResponse = await fetch(
+      const movieResponse = await apiFetch(
         `${import.meta.env.VITE_API_URL}/movie/settings`
       );
       const movieData = await movieResponse.json();
       setMovieSettings(movieData);
 
-      const radarrResponse = await fetch(
+      const radarrResponse = await apiFetch(
         `${import.meta.env.VITE_API_URL}/radarr/settings`
       );
       const radarrData = await radarrResponse.json();
       setRadarrSettings(radarrData);
 
-      const rootFoldersResponse = await fetch(
+      const rootFoldersResponse = await apiFetch(
         `${import.meta.env.VITE_API_URL}/radarr/rootfolders`
       );
       const rootFolderData = await rootFoldersResponse.json();
       setRadarrRootFolder(rootFolderData);
 
-      const qualityProfilesResponse = await fetch(
+      const qualityProfilesResponse = await apiFetch(
         `${import.meta.env.VITE_API_URL}/radarr/profiles`
       );
       const qualityProfilesData = await qualityProfilesResponse.json();
@@ -166,7 +167,7 @@ export default function Radarr() {
 
   const onSubmitMovie = async (values: z.infer<typeof movieFormSchema>) => {
     try {
-      await fetch(`${import.meta.env.VITE_API_URL}/movie/settings`, {
+      await apiFetch(`${import.meta.env.VITE_API_URL}/movie/settings`, {
         method: "PUT",
         headers: {
           "Content-Type": "application/json",
@@ -192,7 +193,7 @@ export default function Radarr() {
         }),
       });
 
-      await fetch(`${import.meta.env.VITE_API_URL}/radarr/settings`, {
+      await apiFetch(`${import.meta.env.VITE_API_URL}/radarr/settings`, {
         method: "PUT",
         headers: {
           "Content-Type": "application/json",
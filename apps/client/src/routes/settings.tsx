@@ -24,6 +24,7 @@ import {
 import Loading from "@/components/Loading";
 import FormInputField from "@/components/FormInputField";
 import { Separator } from "@/components/ui/separator";
+import { apiFetch } from "@/lib/api";
 
 // Zod schema definition, adaptable to add more modes
 const settingsFormSchema = z.object({
@@ -73,13 +74,13 @@ export default function Settings() {
   // Function to fetch Trakt settings from API
   const fetchSettings = async () => {
     try {
-      const response = await fetch(
+      const response = await apiFetch(
         `${import.meta.env.VITE_API_URL}/trakt/settings`
       );
       const data = await response.json();
       setTraktSettings(data);
 
-      const omdbResponse = await fetch(
+      const omdbResponse = await apiFetch(
         `${import.meta.env.VITE_API_URL}/omdb/settings`
       );
       const omdbData = await omdbResponse.json();
@@ -94,7 +95,7 @@ export default function Settings() {
   // Function to save Trakt settings via API
   const saveSettings = async (values: z.infer<typeof settingsFormSchema>) => {
     try {
-      await fetch(`${import.meta.env.VITE_API_URL}/settings`, {
+      await apiFetch(`${import.meta.env.VITE_API_URL}/settings`, {
         method: "PUT",
         headers: {
           "Content-Type": "application/json",
@@ -105,7 +106,7 @@ export default function Settings() {
         }),
       });
 
-      await fetch(`${import.meta.env.VITE_API_URL}/trakt/settings`, {
+      await apiFetch(`${import.meta.env.VITE_API_URL}/trakt/settings`, {
         method: "PUT",
         headers: {
           "Content-Type": "application/json",
@@ -116,7 +117,7 @@ export default function Settings() {
         }),
       });
 
-      await fetch(`${import.meta.env.VITE_API_URL}/omdb/settings`, {
+      await apiFetch(`${import.meta.env.VITE_API_URL}/omdb/settings`, {
         method: "PUT",
         headers: {
           "Content-Type": "application/json",
@@ -24,6 +24,7 @@ import {
 } from "@/components/ui/select";
 import { ShowSettings } from "@/types/shows";
 import { SonarrSettings, SonarrRootFolder, SonarrQualityProfile } from "@/types/sonarr";
+import { apiFetch } from "@/lib/api";
 
 const showFormSchema = z.object({
   interval: z.number(),
@@ -65,25 +66,25 @@ export default function Sonarr() {
 
   const fetchSettings = async () => {
     try {
-      const showResponse = await fetch(
+      const showResponse = await apiFetch(
         `${import.meta.env.VITE_API_URL}/show/settings`
       );
       const showData = await showResponse.json();
       setShowSettings(showData);
 
-      const sonarrResponse = await fetch(
+      const sonarrResponse = await apiFetch(
         `${import.meta.env.VITE_API_URL}/sonarr/settings`
       );
       const sonarrData = await sonarrResponse.json();
       setSonarrSettings(sonarrData);
 
-      const rootFoldersResponse = await fetch(
+      const rootFoldersResponse = await apiFetch(
         `${import.meta.env.VITE_API_URL}/sonarr/rootfolders`
       );
       const rootFolderData = await rootFoldersResponse.json();
       setSonarrRootFolder(rootFolderData);
 
-      const qualityProfilesResponse = await fetch(
+      const qualityProfilesResponse = await apiFetch(
         `${import.meta.env.VITE_API_URL}/sonarr/profiles`
       );
       const qualityProfilesData = await qualityProfilesResponse.json();
@@ -0,0 +1,5 @@
+export interface AuthStatus {
+    setup_complete: boolean;
+    has_users: boolean;
+    bootstrap_token_required: boolean;
+}
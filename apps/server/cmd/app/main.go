@@ -2,8 +2,10 @@ package main
 
 import (
 	"context"
+	"flag"
 	"os"
 	"os/signal"
+	"strconv"
 	"sync"
 	"syscall"
 	"time"
@@ -11,11 +13,14 @@ import (
 	_ "github.com/mattn/go-sqlite3"
 
 	"github.com/charmbracelet/log"
+	"github.com/mahcks/blockbusterr/internal/auth"
+	"github.com/mahcks/blockbusterr/internal/config"
 	"github.com/mahcks/blockbusterr/internal/global"
 	"github.com/mahcks/blockbusterr/internal/helpers"
 	"github.com/mahcks/blockbusterr/internal/notifications"
 	"github.com/mahcks/blockbusterr/internal/rest"
 	"github.com/mahcks/blockbusterr/internal/scheduler"
+	"github.com/mahcks/blockbusterr/internal/secrets"
 	"github.com/mahcks/blockbusterr/internal/services/sqlite"
 	"github.com/mahcks/blockbusterr/internal/websocket"
 )
@@ -35,6 +40,12 @@ func main() {
 		Version = version
 	}
 
+	configOverwrite, _ := strconv.ParseBool(os.Getenv(config.EnvPrefix + "CONFIG_OVERWRITE"))
+	configPath := flag.String("config", os.Getenv(config.EnvPrefix+"CONFIG"), "Path to a YAML or TOML config file used to seed the settings")
+	flag.BoolVar(&configOverwrite, "config-overwrite", configOverwrite, "Let config file and environment values replace settings already stored in the database")
+	serverOpts := config.RegisterServerFlags(flag.CommandLine, version)
+	flag.Parse()
+
 	// Intialize the logger depending on the version of the app
 	var logger *log.Logger
 	if version == "dev" {
@@ -59,6 +70,10 @@ func main() {
 
 	log.Info("Starting the application", "version", version, "timestamp", Timestamp)
 
+	if err := serverOpts.Validate(); err != nil {
+		log.Fatal("Invalid server options", "error", err)
+	}
+
 	gctx, cancel := global.WithCancel(global.New(context.Background()))
 	var err error
 
@@ -68,13 +83,93 @@ func main() {
 
 	{
 		log.Info("Setting up SQLite database")
-		gctx.Crate().SQL, err = sqlite.Setup(gctx, Version)
+		gctx.Crate().SQL, err = sqlite.Setup(gctx, serverOpts.DBPath)
 		if err != nil {
 			log.Error("Error setting up SQLite database", "error", err)
 			cancel()
 			return
 		}
 		log.Info("SQLite database setup complete")
+
+		// Publish every new log entry to the websocket clients
+		gctx.Crate().SQL.Queries().OnLogCreated(hub.PublishLog)
+	}
+
+	{
+		// Without a configured key one is generated on the first start, so secrets are never stored in plaintext
+		secretKey := serverOpts.SecretKey
+		if secretKey == "" {
+			var created bool
+			secretKey, created, err = secrets.LoadOrCreateKey(serverOpts.SecretFile)
+			if err != nil {
+				log.Error("Error loading secret key", "error", err)
+				cancel()
+				return
+			}
+
+			if created {
+				log.Warn("No secret key is set, generated one. Back it up separately from the database, the stored secrets can't be read without it and anyone with both can read them. Set BLOCKBUSTERR_SECRET_KEY to manage the key yourself.", "path", serverOpts.SecretFile)
+			}
+		}
+
+		cipher, err := secrets.NewCipher(secretKey)
+		if err != nil {
+			log.Error("Error setting up secret encryption", "error", err)
+			cancel()
+			return
+		}
+
+		gctx.Crate().Secrets = cipher
+		gctx.Crate().SQL.Queries().UseSecrets(cipher)
+
+		// Secrets stored before encryption was enabled are encrypted once
+		encrypted, err := gctx.Crate().SQL.Queries().EncryptExistingSecrets(gctx)
+		if err != nil {
+			log.Error("Error encrypting stored secrets", "error", err)
+			cancel()
+			return
+		}
+
+		if encrypted > 0 {
+			log.Info("Encrypted secrets that were stored in plaintext", "count", encrypted)
+		}
+	}
+
+	{
+		cfg, err := config.Load(*configPath)
+		if err != nil {
+			log.Error("Error loading config", "error", err)
+			cancel()
+			return
+		}
+
+		if err := config.Apply(gctx, gctx.Crate().SQL.Queries(), cfg, configOverwrite); err != nil {
+			log.Error("Error applying config", "error", err)
+			cancel()
+			return
+		}
+	}
+
+	{
+		// Installs that finished the setup before authentication existed have no admin user yet.
+		// Creating one needs a bootstrap token, so the first visitor can't claim the install.
+		status, err := auth.GetSetupStatus(gctx, gctx.Crate().SQL.Queries())
+		if err != nil {
+			log.Error("Error checking setup status", "error", err)
+			cancel()
+			return
+		}
+
+		if status.NeedsBootstrapToken() && serverOpts.BootstrapToken == "" {
+			serverOpts.BootstrapToken, err = auth.NewToken()
+			if err != nil {
+				log.Error("Error generating bootstrap token", "error", err)
+				cancel()
+				return
+			}
+
+			log.Warn("No admin user exists yet. Enter this bootstrap token in the UI to create one, it's generated again on every start until then.", "token", serverOpts.BootstrapToken)
+		}
 	}
 
 	// Initialize helpers
@@ -90,7 +185,7 @@ func main() {
 	}
 
 	// Setup the scheduler
-	schedulerInstance := scheduler.Setup(gctx, *helpersInstance, notificationManager)
+	schedulerInstance := scheduler.Setup(gctx, *helpersInstance, notificationManager, hub)
 
 	interrupt := make(chan os.Signal, 1)
 	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
@@ -125,7 +220,7 @@ func main() {
 		defer wg.Done()
 
 		log.Info("Starting API server")
-		if err := rest.New(gctx, hub, helpersInstance, schedulerInstance); err != nil {
+		if err := rest.New(gctx, hub, helpersInstance, schedulerInstance, notificationManager, *serverOpts); err != nil {
 			log.Error("Error starting API server", "error", err)
 			cancel()
 			return
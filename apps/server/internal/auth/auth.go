@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mahcks/blockbusterr/internal/db"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// SessionCookieName is the cookie the UI session token is stored in
+	SessionCookieName = "blockbusterr_session"
+	// SessionDuration is how long a session stays valid after signing in
+	SessionDuration = 30 * 24 * time.Hour
+	// MinPasswordLength is the shortest password a user can be created with
+	MinPasswordLength = 8
+)
+
+// HashPassword returns the bcrypt hash of a password
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("error hashing password: %w", err)
+	}
+
+	return string(hash), nil
+}
+
+// CheckPassword reports whether password matches the bcrypt hash
+func CheckPassword(hash, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+// NewToken returns a random token for a session or API key
+func NewToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("error generating token: %w", err)
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// HashToken returns the SHA-256 hash a token is stored as. Tokens are random, so
+// unlike passwords they don't need a slow hash.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// ValidateCredentials checks the username and password a new user is created with
+func ValidateCredentials(username, password string) error {
+	if strings.TrimSpace(username) == "" {
+		return errors.New("username is required")
+	}
+
+	if len(password) < MinPasswordLength {
+		return fmt.Errorf("password must be at least %d characters long", MinPasswordLength)
+	}
+
+	return nil
+}
+
+// CreateSession stores a new session for the user and returns its token
+func CreateSession(ctx context.Context, queries *db.Queries, userID int) (string, time.Time, error) {
+	token, err := NewToken()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	expiresAt := time.Now().Add(SessionDuration)
+	if err := queries.CreateSession(ctx, HashToken(token), userID, expiresAt); err != nil {
+		return "", time.Time{}, err
+	}
+
+	return token, expiresAt, nil
+}
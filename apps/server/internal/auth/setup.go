@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+
+	"github.com/mahcks/blockbusterr/internal/db"
+	"github.com/mahcks/blockbusterr/pkg/structures"
+)
+
+// SetupStatus describes whether the setup wizard ran and the admin user exists
+type SetupStatus struct {
+	SetupComplete bool
+	HasUsers      bool
+}
+
+// Open reports whether the setup wizard can still run without signing in. That's only
+// the case for new installs, which have neither finished the setup nor created a user.
+func (s SetupStatus) Open() bool {
+	return !s.SetupComplete && !s.HasUsers
+}
+
+// NeedsBootstrapToken reports whether the install finished the setup before authentication
+// existed, so its admin user can only be created with the bootstrap token
+func (s SetupStatus) NeedsBootstrapToken() bool {
+	return s.SetupComplete && !s.HasUsers
+}
+
+// GetSetupStatus reads the setup status from the database
+func GetSetupStatus(ctx context.Context, queries *db.Queries) (SetupStatus, error) {
+	setting, err := queries.GetSettingByKey(ctx, structures.SettingSetupComplete.String())
+	if err != nil {
+		return SetupStatus{}, err
+	}
+
+	count, err := queries.CountUsers(ctx)
+	if err != nil {
+		return SetupStatus{}, err
+	}
+
+	return SetupStatus{
+		SetupComplete: setting.Value.String == "true",
+		HasUsers:      count > 0,
+	}, nil
+}
+
+// CheckBootstrapToken reports whether token matches the expected bootstrap token. An empty
+// expected token never matches. The hashes are compared, so the time taken doesn't leak the length.
+func CheckBootstrapToken(expected, token string) bool {
+	if expected == "" {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(HashToken(expected)), []byte(HashToken(token))) == 1
+}
@@ -0,0 +1,386 @@
+package config
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/charmbracelet/log"
+	"github.com/mahcks/blockbusterr/internal/db"
+	"github.com/mahcks/blockbusterr/pkg/structures"
+	"github.com/mahcks/blockbusterr/pkg/utils"
+)
+
+// Apply writes the config values to the database. With overwrite set, every value in the
+// config replaces what's stored. Otherwise a value is only written if the stored one is
+// still empty, so changes made through the UI survive a restart.
+func Apply(ctx context.Context, queries *db.Queries, cfg *Config, overwrite bool) error {
+	steps := []struct {
+		name  string
+		apply func(context.Context, *db.Queries, *Config, bool) error
+	}{
+		{"mode", applyMode},
+		{"Trakt", applyTrakt},
+		{"Radarr", applyRadarr},
+		{"Sonarr", applySonarr},
+		{"Ombi", applyOmbi},
+		{"OMDb", applyOMDb},
+		{"movie", applyMovieSettings},
+		{"show", applyShowSettings},
+	}
+
+	for _, step := range steps {
+		if err := step.apply(ctx, queries, cfg, overwrite); err != nil {
+			return fmt.Errorf("error applying %s settings from config: %w", step.name, err)
+		}
+	}
+
+	// Nothing can run without a Trakt client ID, so having one means the setup wizard isn't needed
+	if cfg.Trakt.ClientID != nil && *cfg.Trakt.ClientID != "" {
+		if err := queries.InsertOrUpdateSetting(ctx, structures.SettingSetupComplete.String(), "true", "boolean"); err != nil {
+			return fmt.Errorf("error marking setup as complete: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func applyMode(ctx context.Context, queries *db.Queries, cfg *Config, overwrite bool) error {
+	if cfg.Mode == nil {
+		return nil
+	}
+
+	// The initial migration always seeds a mode, so without overwrite it's only replaced before the setup is complete
+	if !overwrite {
+		complete, err := setupComplete(ctx, queries)
+		if err != nil {
+			return err
+		}
+
+		if complete {
+			return nil
+		}
+	}
+
+	log.Info("[Config] Setting mode from config.", "mode", *cfg.Mode)
+	return queries.InsertOrUpdateSetting(ctx, structures.SettingMode.String(), *cfg.Mode, "text")
+}
+
+func applyTrakt(ctx context.Context, queries *db.Queries, cfg *Config, overwrite bool) error {
+	settings, err := queries.GetTraktSettings(ctx)
+	exists := err == nil
+	if err != nil && !errors.Is(err, db.ErrNoTraktSettings) {
+		return err
+	}
+
+	changed := mergePlainString(&settings.ClientID, cfg.Trakt.ClientID, overwrite)
+	changed = mergePlainString(&settings.ClientSecret, cfg.Trakt.ClientSecret, overwrite) || changed
+	if !changed {
+		return nil
+	}
+
+	log.Info("[Config] Writing Trakt settings from config.")
+	if !exists {
+		return queries.CreateTraktSettings(ctx, settings.ClientID, settings.ClientSecret)
+	}
+
+	return queries.UpdateTraktSettings(ctx, settings.ClientID, settings.ClientSecret)
+}
+
+func applyRadarr(ctx context.Context, queries *db.Queries, cfg *Config, overwrite bool) error {
+	// The initial migration always creates the Radarr row
+	settings, err := queries.GetRadarrSettings(ctx)
+	if err != nil {
+		return err
+	}
+
+	changed := mergeString(&settings.URL, cfg.Radarr.URL, overwrite)
+	changed = mergeString(&settings.APIKey, cfg.Radarr.APIKey, overwrite) || changed
+	changed = mergeString(&settings.MinimumAvailability, cfg.Radarr.MinimumAvailability, overwrite) || changed
+	changed = mergeInt(&settings.Quality, cfg.Radarr.Quality, overwrite) || changed
+	changed = mergeInt(&settings.RootFolder, cfg.Radarr.RootFolder, overwrite) || changed
+	if !changed {
+		return nil
+	}
+
+	log.Info("[Config] Writing Radarr settings from config.")
+	return queries.UpdateRadarrSettings(ctx, settings.APIKey, settings.URL, settings.MinimumAvailability, settings.Quality, settings.RootFolder)
+}
+
+func applySonarr(ctx context.Context, queries *db.Queries, cfg *Config, overwrite bool) error {
+	settings, err := queries.GetSonarrSettings(ctx)
+	exists := err == nil
+	if err != nil && !errors.Is(err, db.ErrNoSonarrSettings) {
+		return err
+	}
+
+	changed := mergeString(&settings.URL, cfg.Sonarr.URL, overwrite)
+	changed = mergeString(&settings.APIKey, cfg.Sonarr.APIKey, overwrite) || changed
+	changed = mergeString(&settings.Language, cfg.Sonarr.Language, overwrite) || changed
+	changed = mergeInt(&settings.Quality, cfg.Sonarr.Quality, overwrite) || changed
+	changed = mergeInt(&settings.RootFolder, cfg.Sonarr.RootFolder, overwrite) || changed
+	changed = mergeBool(&settings.SeasonFolder, cfg.Sonarr.SeasonFolder, overwrite) || changed
+	if !changed {
+		return nil
+	}
+
+	// Season folders are on by default, matching the setup wizard
+	seasonFolder := !settings.SeasonFolder.Valid || settings.SeasonFolder.Bool
+
+	log.Info("[Config] Writing Sonarr settings from config.")
+	if !exists {
+		return queries.CreateSonarrSettings(ctx, settings.APIKey.String, settings.URL.String, settings.Language.String, settings.Quality.Int32, settings.RootFolder.Int32, seasonFolder)
+	}
+
+	return queries.UpdateSonarrSettings(ctx, settings.APIKey.String, settings.URL.String, settings.Language.String, settings.Quality.Int32, settings.RootFolder.Int32, seasonFolder)
+}
+
+func applyOmbi(ctx context.Context, queries *db.Queries, cfg *Config, overwrite bool) error {
+	settings, err := queries.GetOmbiSettings(ctx)
+	exists := err == nil
+	if err != nil && !errors.Is(err, db.ErrNoOmbiSettings) {
+		return err
+	}
+
+	changed := mergeString(&settings.URL, cfg.Ombi.URL, overwrite)
+	changed = mergeString(&settings.APIKey, cfg.Ombi.APIKey, overwrite) || changed
+	changed = mergeString(&settings.UserID, cfg.Ombi.UserID, overwrite) || changed
+	changed = mergeString(&settings.Language, cfg.Ombi.Language, overwrite) || changed
+	changed = mergeInt(&settings.MovieQuality, cfg.Ombi.MovieQuality, overwrite) || changed
+	changed = mergeInt(&settings.MovieRootFolder, cfg.Ombi.MovieRootFolder, overwrite) || changed
+	changed = mergeInt(&settings.ShowQuality, cfg.Ombi.ShowQuality, overwrite) || changed
+	changed = mergeInt(&settings.ShowRootFolder, cfg.Ombi.ShowRootFolder, overwrite) || changed
+	if !changed {
+		return nil
+	}
+
+	log.Info("[Config] Writing Ombi settings from config.")
+	if !exists {
+		return queries.CreateOmbiSettings(ctx, settings.APIKey, settings.URL, settings.UserID, settings.Language, settings.MovieQuality, settings.MovieRootFolder, settings.ShowQuality, settings.ShowRootFolder)
+	}
+
+	return queries.UpdateOmbiSettings(ctx, settings.APIKey, settings.URL, settings.UserID, settings.Language, settings.MovieQuality, settings.MovieRootFolder, settings.ShowQuality, settings.ShowRootFolder)
+}
+
+func applyOMDb(ctx context.Context, queries *db.Queries, cfg *Config, overwrite bool) error {
+	settings, err := queries.GetOMDbSettings(ctx)
+	exists := err == nil
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	if !mergeString(&settings.APIKey, cfg.OMDb.APIKey, overwrite) {
+		return nil
+	}
+
+	log.Info("[Config] Writing OMDb settings from config.")
+	if !exists {
+		return queries.CreateOMDbSettings(ctx, settings.APIKey.String)
+	}
+
+	return queries.UpdateOMDbSettings(ctx, settings.APIKey.String)
+}
+
+func applyMovieSettings(ctx context.Context, queries *db.Queries, cfg *Config, overwrite bool) error {
+	// The initial migration always creates the movie settings row
+	settings, err := queries.GetMovieSettings(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The periods always have a value, so without overwrite they're only replaced before the setup is complete
+	replaceDefaults, err := replaceDefaults(ctx, queries, overwrite)
+	if err != nil {
+		return err
+	}
+
+	movies := cfg.Movies
+	changed := mergeInt(&settings.Anticipated, movies.Anticipated, overwrite)
+	changed = mergeInt(&settings.BoxOffice, movies.BoxOffice, overwrite) || changed
+	changed = mergeInt(&settings.Popular, movies.Popular, overwrite) || changed
+	changed = mergeInt(&settings.Trending, movies.Trending, overwrite) || changed
+	changed = mergeInt(&settings.Watched, movies.Watched, overwrite) || changed
+	changed = mergePlainString(&settings.WatchedPeriod, movies.WatchedPeriod, replaceDefaults) || changed
+	changed = mergeInt(&settings.Played, movies.Played, overwrite) || changed
+	changed = mergePlainString(&settings.PlayedPeriod, movies.PlayedPeriod, replaceDefaults) || changed
+	changed = mergeInt(&settings.Collected, movies.Collected, overwrite) || changed
+	changed = mergePlainString(&settings.CollectedPeriod, movies.CollectedPeriod, replaceDefaults) || changed
+	changed = mergeInt(&settings.MaxRuntime, movies.MaxRuntime, overwrite) || changed
+	changed = mergeInt(&settings.MinRuntime, movies.MinRuntime, overwrite) || changed
+	changed = mergeInt(&settings.MinYear, movies.MinYear, overwrite) || changed
+	changed = mergeInt(&settings.MaxYear, movies.MaxYear, overwrite) || changed
+	changed = mergeFloat(&settings.MinRating, movies.MinRating, overwrite) || changed
+	changed = mergeInt(&settings.MinVotes, movies.MinVotes, overwrite) || changed
+	changed = mergeFloat(&settings.MinIMDbRating, movies.MinIMDbRating, overwrite) || changed
+	changed = mergeString(&settings.RottenTomatoes, movies.RottenTomatoes, overwrite) || changed
+	changed = mergeInt(&settings.MinMetacritic, movies.MinMetacritic, overwrite) || changed
+	changed = mergeString(&settings.AllowedCertifications, movies.AllowedCertifications, overwrite) || changed
+	changed = mergeString(&settings.BlockedCertifications, movies.BlockedCertifications, overwrite) || changed
+	changed = mergeString(&settings.CronAnticipated, movies.CronAnticipated, overwrite) || changed
+	changed = mergeString(&settings.CronBoxOffice, movies.CronBoxOffice, overwrite) || changed
+	changed = mergeString(&settings.CronPopular, movies.CronPopular, overwrite) || changed
+	changed = mergeString(&settings.CronTrending, movies.CronTrending, overwrite) || changed
+	changed = mergeString(&settings.CronWatched, movies.CronWatched, overwrite) || changed
+	changed = mergeString(&settings.CronPlayed, movies.CronPlayed, overwrite) || changed
+	changed = mergeString(&settings.CronCollected, movies.CronCollected, overwrite) || changed
+	if !changed {
+		return nil
+	}
+
+	log.Info("[Config] Writing movie settings from config.")
+	return queries.UpdateMovieSettings(ctx, settings)
+}
+
+func applyShowSettings(ctx context.Context, queries *db.Queries, cfg *Config, overwrite bool) error {
+	// The initial migration always creates the show settings row
+	settings, err := queries.GetShowSettings(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The periods and Sonarr add options always have a value, so without overwrite they're only replaced before the setup is complete
+	replaceDefaults, err := replaceDefaults(ctx, queries, overwrite)
+	if err != nil {
+		return err
+	}
+
+	shows := cfg.Shows
+	changed := mergeInt(&settings.Anticipated, shows.Anticipated, overwrite)
+	changed = mergeInt(&settings.Popular, shows.Popular, overwrite) || changed
+	changed = mergeInt(&settings.Trending, shows.Trending, overwrite) || changed
+	changed = mergeInt(&settings.Watched, shows.Watched, overwrite) || changed
+	changed = mergePlainString(&settings.WatchedPeriod, shows.WatchedPeriod, replaceDefaults) || changed
+	changed = mergeInt(&settings.Played, shows.Played, overwrite) || changed
+	changed = mergePlainString(&settings.PlayedPeriod, shows.PlayedPeriod, replaceDefaults) || changed
+	changed = mergeInt(&settings.Collected, shows.Collected, overwrite) || changed
+	changed = mergePlainString(&settings.CollectedPeriod, shows.CollectedPeriod, replaceDefaults) || changed
+	changed = mergeInt(&settings.MaxRuntime, shows.MaxRuntime, overwrite) || changed
+	changed = mergeInt(&settings.MinRuntime, shows.MinRuntime, overwrite) || changed
+	changed = mergeInt(&settings.MinYear, shows.MinYear, overwrite) || changed
+	changed = mergeInt(&settings.MaxYear, shows.MaxYear, overwrite) || changed
+	changed = mergeFloat(&settings.MinRating, shows.MinRating, overwrite) || changed
+	changed = mergeInt(&settings.MinVotes, shows.MinVotes, overwrite) || changed
+	changed = mergeFloat(&settings.MinIMDbRating, shows.MinIMDbRating, overwrite) || changed
+	changed = mergeString(&settings.RottenTomatoes, shows.RottenTomatoes, overwrite) || changed
+	changed = mergeInt(&settings.MinMetacritic, shows.MinMetacritic, overwrite) || changed
+	changed = mergeString(&settings.AllowedCertifications, shows.AllowedCertifications, overwrite) || changed
+	changed = mergeString(&settings.BlockedCertifications, shows.BlockedCertifications, overwrite) || changed
+	changed = mergePlainString(&settings.SeriesType, shows.SeriesType, replaceDefaults) || changed
+	changed = mergePlainBool(&settings.SeasonFolder, shows.SeasonFolder, replaceDefaults) || changed
+	changed = mergePlainString(&settings.Monitor, shows.Monitor, replaceDefaults) || changed
+	changed = mergePlainBool(&settings.SearchOnAdd, shows.SearchOnAdd, replaceDefaults) || changed
+	changed = mergeString(&settings.CronJobAnticipated, shows.CronAnticipated, overwrite) || changed
+	changed = mergeString(&settings.CronJobPopular, shows.CronPopular, overwrite) || changed
+	changed = mergeString(&settings.CronJobTrending, shows.CronTrending, overwrite) || changed
+	changed = mergeString(&settings.CronJobWatched, shows.CronWatched, overwrite) || changed
+	changed = mergeString(&settings.CronJobPlayed, shows.CronPlayed, overwrite) || changed
+	changed = mergeString(&settings.CronJobCollected, shows.CronCollected, overwrite) || changed
+	if !changed {
+		return nil
+	}
+
+	log.Info("[Config] Writing show settings from config.")
+	return queries.UpdateShowSettings(ctx, settings)
+}
+
+// setupComplete reports whether the setup wizard has been finished
+func setupComplete(ctx context.Context, queries *db.Queries) (bool, error) {
+	setting, err := queries.GetSettingByKey(ctx, structures.SettingSetupComplete.String())
+	if err != nil {
+		return false, err
+	}
+
+	return setting.Value.String == "true", nil
+}
+
+// replaceDefaults reports whether columns that always have a value can be written. Without overwrite
+// that's only the case before the setup is complete, since a stored value can't be told apart from the default.
+func replaceDefaults(ctx context.Context, queries *db.Queries, overwrite bool) (bool, error) {
+	if overwrite {
+		return true, nil
+	}
+
+	complete, err := setupComplete(ctx, queries)
+	return !complete, err
+}
+
+// mergeString copies value into dst if it's set and dst is empty or overwrite is set, and reports whether dst changed
+func mergeString(dst *sql.NullString, value *string, overwrite bool) bool {
+	if value == nil || (!overwrite && dst.Valid && dst.String != "") {
+		return false
+	}
+
+	next := utils.StringToNullString(*value)
+	if next == *dst {
+		return false
+	}
+
+	*dst = next
+	return true
+}
+
+// mergePlainString is mergeString for columns that can't be null
+func mergePlainString(dst *string, value *string, overwrite bool) bool {
+	if value == nil || (!overwrite && *dst != "") || *dst == *value {
+		return false
+	}
+
+	*dst = *value
+	return true
+}
+
+// mergeInt copies value into dst if it's set and dst is empty or overwrite is set, and reports whether dst changed
+func mergeInt(dst *sql.NullInt32, value *int, overwrite bool) bool {
+	if value == nil || (!overwrite && dst.Valid) {
+		return false
+	}
+
+	next := utils.PointerToNullInt32(value)
+	if next == *dst {
+		return false
+	}
+
+	*dst = next
+	return true
+}
+
+// mergeFloat copies value into dst if it's set and dst is empty or overwrite is set, and reports whether dst changed
+func mergeFloat(dst *sql.NullFloat64, value *float64, overwrite bool) bool {
+	if value == nil || (!overwrite && dst.Valid) {
+		return false
+	}
+
+	next := utils.PointerToNullFloat64(value)
+	if next == *dst {
+		return false
+	}
+
+	*dst = next
+	return true
+}
+
+// mergeBool copies value into dst if it's set and dst is empty or overwrite is set, and reports whether dst changed
+func mergeBool(dst *sql.NullBool, value *bool, overwrite bool) bool {
+	if value == nil || (!overwrite && dst.Valid) {
+		return false
+	}
+
+	next := utils.PointerToNullBool(value)
+	if next == *dst {
+		return false
+	}
+
+	*dst = next
+	return true
+}
+
+// mergePlainBool is mergeBool for columns that can't be null, so it only writes with overwrite set
+func mergePlainBool(dst *bool, value *bool, overwrite bool) bool {
+	if value == nil || !overwrite || *dst == *value {
+		return false
+	}
+
+	*dst = *value
+	return true
+}
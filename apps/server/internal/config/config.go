@@ -0,0 +1,402 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/mahcks/blockbusterr/pkg/structures"
+	"github.com/mahcks/blockbusterr/pkg/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// EnvPrefix is the prefix every environment variable read by the config has
+const EnvPrefix = "BLOCKBUSTERR_"
+
+// Config holds the settings a headless install can provide up front. Every value is
+// a pointer so a value that wasn't set can be told apart from a zero value.
+type Config struct {
+	Mode   *string      `yaml:"mode" toml:"mode"` // "ombi" or "radarr-sonarr"
+	Trakt  TraktConfig  `yaml:"trakt" toml:"trakt"`
+	Radarr RadarrConfig `yaml:"radarr" toml:"radarr"`
+	Sonarr SonarrConfig `yaml:"sonarr" toml:"sonarr"`
+	Ombi   OmbiConfig   `yaml:"ombi" toml:"ombi"`
+	OMDb   OMDbConfig   `yaml:"omdb" toml:"omdb"`
+	Movies MovieConfig  `yaml:"movies" toml:"movies"`
+	Shows  ShowConfig   `yaml:"shows" toml:"shows"`
+}
+
+type TraktConfig struct {
+	ClientID     *string `yaml:"client_id" toml:"client_id"`
+	ClientSecret *string `yaml:"client_secret" toml:"client_secret"`
+}
+
+type RadarrConfig struct {
+	URL                 *string `yaml:"url" toml:"url"`
+	APIKey              *string `yaml:"api_key" toml:"api_key"`
+	MinimumAvailability *string `yaml:"minimum_availability" toml:"minimum_availability"`
+	Quality             *int    `yaml:"quality" toml:"quality"`
+	RootFolder          *int    `yaml:"root_folder" toml:"root_folder"`
+}
+
+type SonarrConfig struct {
+	URL          *string `yaml:"url" toml:"url"`
+	APIKey       *string `yaml:"api_key" toml:"api_key"`
+	Language     *string `yaml:"language" toml:"language"`
+	Quality      *int    `yaml:"quality" toml:"quality"`
+	RootFolder   *int    `yaml:"root_folder" toml:"root_folder"`
+	SeasonFolder *bool   `yaml:"season_folder" toml:"season_folder"`
+}
+
+type OmbiConfig struct {
+	URL             *string `yaml:"url" toml:"url"`
+	APIKey          *string `yaml:"api_key" toml:"api_key"`
+	UserID          *string `yaml:"user_id" toml:"user_id"`
+	Language        *string `yaml:"language" toml:"language"`
+	MovieQuality    *int    `yaml:"movie_quality" toml:"movie_quality"`
+	MovieRootFolder *int    `yaml:"movie_root_folder" toml:"movie_root_folder"`
+	ShowQuality     *int    `yaml:"show_quality" toml:"show_quality"`
+	ShowRootFolder  *int    `yaml:"show_root_folder" toml:"show_root_folder"`
+}
+
+type OMDbConfig struct {
+	APIKey *string `yaml:"api_key" toml:"api_key"`
+}
+
+type MovieConfig struct {
+	Anticipated           *int     `yaml:"anticipated" toml:"anticipated"`
+	BoxOffice             *int     `yaml:"box_office" toml:"box_office"`
+	Popular               *int     `yaml:"popular" toml:"popular"`
+	Trending              *int     `yaml:"trending" toml:"trending"`
+	Watched               *int     `yaml:"watched" toml:"watched"`
+	WatchedPeriod         *string  `yaml:"watched_period" toml:"watched_period"`
+	Played                *int     `yaml:"played" toml:"played"`
+	PlayedPeriod          *string  `yaml:"played_period" toml:"played_period"`
+	Collected             *int     `yaml:"collected" toml:"collected"`
+	CollectedPeriod       *string  `yaml:"collected_period" toml:"collected_period"`
+	MaxRuntime            *int     `yaml:"max_runtime" toml:"max_runtime"`
+	MinRuntime            *int     `yaml:"min_runtime" toml:"min_runtime"`
+	MinYear               *int     `yaml:"min_year" toml:"min_year"`
+	MaxYear               *int     `yaml:"max_year" toml:"max_year"`
+	MinRating             *float64 `yaml:"min_rating" toml:"min_rating"`
+	MinVotes              *int     `yaml:"min_votes" toml:"min_votes"`
+	MinIMDbRating         *float64 `yaml:"min_imdb_rating" toml:"min_imdb_rating"`
+	RottenTomatoes        *string  `yaml:"rotten_tomatoes" toml:"rotten_tomatoes"`
+	MinMetacritic         *int     `yaml:"min_metacritic" toml:"min_metacritic"`
+	AllowedCertifications *string  `yaml:"allowed_certifications" toml:"allowed_certifications"` // Comma-separated, e.g. "PG,PG-13"
+	BlockedCertifications *string  `yaml:"blocked_certifications" toml:"blocked_certifications"` // Comma-separated, e.g. "NC-17"
+	CronAnticipated       *string  `yaml:"cron_anticipated" toml:"cron_anticipated"`
+	CronBoxOffice         *string  `yaml:"cron_box_office" toml:"cron_box_office"`
+	CronPopular           *string  `yaml:"cron_popular" toml:"cron_popular"`
+	CronTrending          *string  `yaml:"cron_trending" toml:"cron_trending"`
+	CronWatched           *string  `yaml:"cron_watched" toml:"cron_watched"`
+	CronPlayed            *string  `yaml:"cron_played" toml:"cron_played"`
+	CronCollected         *string  `yaml:"cron_collected" toml:"cron_collected"`
+}
+
+type ShowConfig struct {
+	Anticipated           *int     `yaml:"anticipated" toml:"anticipated"`
+	Popular               *int     `yaml:"popular" toml:"popular"`
+	Trending              *int     `yaml:"trending" toml:"trending"`
+	Watched               *int     `yaml:"watched" toml:"watched"`
+	WatchedPeriod         *string  `yaml:"watched_period" toml:"watched_period"`
+	Played                *int     `yaml:"played" toml:"played"`
+	PlayedPeriod          *string  `yaml:"played_period" toml:"played_period"`
+	Collected             *int     `yaml:"collected" toml:"collected"`
+	CollectedPeriod       *string  `yaml:"collected_period" toml:"collected_period"`
+	MaxRuntime            *int     `yaml:"max_runtime" toml:"max_runtime"`
+	MinRuntime            *int     `yaml:"min_runtime" toml:"min_runtime"`
+	MinYear               *int     `yaml:"min_year" toml:"min_year"`
+	MaxYear               *int     `yaml:"max_year" toml:"max_year"`
+	MinRating             *float64 `yaml:"min_rating" toml:"min_rating"`
+	MinVotes              *int     `yaml:"min_votes" toml:"min_votes"`
+	MinIMDbRating         *float64 `yaml:"min_imdb_rating" toml:"min_imdb_rating"`
+	RottenTomatoes        *string  `yaml:"rotten_tomatoes" toml:"rotten_tomatoes"`
+	MinMetacritic         *int     `yaml:"min_metacritic" toml:"min_metacritic"`
+	AllowedCertifications *string  `yaml:"allowed_certifications" toml:"allowed_certifications"` // Comma-separated, e.g. "TV-PG,TV-14"
+	BlockedCertifications *string  `yaml:"blocked_certifications" toml:"blocked_certifications"` // Comma-separated, e.g. "TV-MA"
+	SeriesType            *string  `yaml:"series_type" toml:"series_type"`                       // "standard", "anime" or "daily"
+	SeasonFolder          *bool    `yaml:"season_folder" toml:"season_folder"`
+	Monitor               *string  `yaml:"monitor" toml:"monitor"` // "all", "future", "firstSeason", "latestSeason", "pilot" or "none"
+	SearchOnAdd           *bool    `yaml:"search_on_add" toml:"search_on_add"`
+	CronAnticipated       *string  `yaml:"cron_anticipated" toml:"cron_anticipated"`
+	CronPopular           *string  `yaml:"cron_popular" toml:"cron_popular"`
+	CronTrending          *string  `yaml:"cron_trending" toml:"cron_trending"`
+	CronWatched           *string  `yaml:"cron_watched" toml:"cron_watched"`
+	CronPlayed            *string  `yaml:"cron_played" toml:"cron_played"`
+	CronCollected         *string  `yaml:"cron_collected" toml:"cron_collected"`
+}
+
+// Load reads the config file at path, if one is given, and applies any BLOCKBUSTERR_*
+// environment variables on top of it. The file format is picked from its extension.
+func Load(path string) (*Config, error) {
+	cfg := &Config{}
+
+	if path != "" {
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading config file: %w", err)
+		}
+
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".yaml", ".yml":
+			if err := yaml.Unmarshal(contents, cfg); err != nil {
+				return nil, fmt.Errorf("error parsing YAML config file: %w", err)
+			}
+		case ".toml":
+			if _, err := toml.Decode(string(contents), cfg); err != nil {
+				return nil, fmt.Errorf("error parsing TOML config file: %w", err)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported config file extension %q, expected .yaml, .yml or .toml", filepath.Ext(path))
+		}
+	}
+
+	if err := cfg.applyEnv(); err != nil {
+		return nil, err
+	}
+
+	if cfg.Mode != nil && *cfg.Mode != "ombi" && *cfg.Mode != "radarr-sonarr" {
+		return nil, fmt.Errorf("invalid mode %q, expected \"ombi\" or \"radarr-sonarr\"", *cfg.Mode)
+	}
+
+	crons := map[string]*string{
+		"movies.cron_anticipated": cfg.Movies.CronAnticipated,
+		"movies.cron_box_office":  cfg.Movies.CronBoxOffice,
+		"movies.cron_popular":     cfg.Movies.CronPopular,
+		"movies.cron_trending":    cfg.Movies.CronTrending,
+		"movies.cron_watched":     cfg.Movies.CronWatched,
+		"movies.cron_played":      cfg.Movies.CronPlayed,
+		"movies.cron_collected":   cfg.Movies.CronCollected,
+		"shows.cron_anticipated":  cfg.Shows.CronAnticipated,
+		"shows.cron_popular":      cfg.Shows.CronPopular,
+		"shows.cron_trending":     cfg.Shows.CronTrending,
+		"shows.cron_watched":      cfg.Shows.CronWatched,
+		"shows.cron_played":       cfg.Shows.CronPlayed,
+		"shows.cron_collected":    cfg.Shows.CronCollected,
+	}
+	for field, expr := range crons {
+		// An empty expression disables the job
+		if expr == nil || strings.TrimSpace(*expr) == "" {
+			continue
+		}
+
+		if err := utils.ValidateCronExpression(*expr); err != nil {
+			return nil, fmt.Errorf("invalid cron expression for %s: %w", field, err)
+		}
+	}
+
+	periods := map[string]*string{
+		"movies.watched_period":   cfg.Movies.WatchedPeriod,
+		"movies.played_period":    cfg.Movies.PlayedPeriod,
+		"movies.collected_period": cfg.Movies.CollectedPeriod,
+		"shows.watched_period":    cfg.Shows.WatchedPeriod,
+		"shows.played_period":     cfg.Shows.PlayedPeriod,
+		"shows.collected_period":  cfg.Shows.CollectedPeriod,
+	}
+	for field, period := range periods {
+		if period != nil && !structures.IsValidTraktPeriod(*period) {
+			return nil, fmt.Errorf("invalid %s %q, expected weekly, monthly, yearly or all", field, *period)
+		}
+	}
+
+	rottenTomatoes := map[string]*string{
+		"movies.rotten_tomatoes": cfg.Movies.RottenTomatoes,
+		"shows.rotten_tomatoes":  cfg.Shows.RottenTomatoes,
+	}
+	for field, rt := range rottenTomatoes {
+		if rt == nil || strings.TrimSpace(*rt) == "" {
+			continue
+		}
+
+		if _, err := utils.ParseRottenTomatoes(*rt); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", field, err)
+		}
+	}
+
+	if st := cfg.Shows.SeriesType; st != nil && !structures.IsValidSonarrSeriesType(*st) {
+		return nil, fmt.Errorf("invalid shows.series_type %q, expected standard, anime or daily", *st)
+	}
+
+	if monitor := cfg.Shows.Monitor; monitor != nil && !structures.IsValidSonarrMonitor(*monitor) {
+		return nil, fmt.Errorf("invalid shows.monitor %q, expected all, future, firstSeason, latestSeason, pilot or none", *monitor)
+	}
+
+	return cfg, nil
+}
+
+// applyEnv overrides the file values with the matching environment variables
+func (cfg *Config) applyEnv() error {
+	envString("MODE", &cfg.Mode)
+
+	envString("TRAKT_CLIENT_ID", &cfg.Trakt.ClientID)
+	envString("TRAKT_CLIENT_SECRET", &cfg.Trakt.ClientSecret)
+
+	envString("RADARR_URL", &cfg.Radarr.URL)
+	envString("RADARR_API_KEY", &cfg.Radarr.APIKey)
+	envString("RADARR_MINIMUM_AVAILABILITY", &cfg.Radarr.MinimumAvailability)
+
+	envString("SONARR_URL", &cfg.Sonarr.URL)
+	envString("SONARR_API_KEY", &cfg.Sonarr.APIKey)
+	envString("SONARR_LANGUAGE", &cfg.Sonarr.Language)
+
+	envString("OMBI_URL", &cfg.Ombi.URL)
+	envString("OMBI_API_KEY", &cfg.Ombi.APIKey)
+	envString("OMBI_USER_ID", &cfg.Ombi.UserID)
+	envString("OMBI_LANGUAGE", &cfg.Ombi.Language)
+
+	envString("OMDB_API_KEY", &cfg.OMDb.APIKey)
+
+	envString("MOVIES_WATCHED_PERIOD", &cfg.Movies.WatchedPeriod)
+	envString("MOVIES_PLAYED_PERIOD", &cfg.Movies.PlayedPeriod)
+	envString("MOVIES_COLLECTED_PERIOD", &cfg.Movies.CollectedPeriod)
+	envString("MOVIES_ROTTEN_TOMATOES", &cfg.Movies.RottenTomatoes)
+	envString("MOVIES_ALLOWED_CERTIFICATIONS", &cfg.Movies.AllowedCertifications)
+	envString("MOVIES_BLOCKED_CERTIFICATIONS", &cfg.Movies.BlockedCertifications)
+	envString("MOVIES_CRON_ANTICIPATED", &cfg.Movies.CronAnticipated)
+	envString("MOVIES_CRON_BOX_OFFICE", &cfg.Movies.CronBoxOffice)
+	envString("MOVIES_CRON_POPULAR", &cfg.Movies.CronPopular)
+	envString("MOVIES_CRON_TRENDING", &cfg.Movies.CronTrending)
+	envString("MOVIES_CRON_WATCHED", &cfg.Movies.CronWatched)
+	envString("MOVIES_CRON_PLAYED", &cfg.Movies.CronPlayed)
+	envString("MOVIES_CRON_COLLECTED", &cfg.Movies.CronCollected)
+
+	envString("SHOWS_WATCHED_PERIOD", &cfg.Shows.WatchedPeriod)
+	envString("SHOWS_PLAYED_PERIOD", &cfg.Shows.PlayedPeriod)
+	envString("SHOWS_COLLECTED_PERIOD", &cfg.Shows.CollectedPeriod)
+	envString("SHOWS_ROTTEN_TOMATOES", &cfg.Shows.RottenTomatoes)
+	envString("SHOWS_ALLOWED_CERTIFICATIONS", &cfg.Shows.AllowedCertifications)
+	envString("SHOWS_BLOCKED_CERTIFICATIONS", &cfg.Shows.BlockedCertifications)
+	envString("SHOWS_SERIES_TYPE", &cfg.Shows.SeriesType)
+	envString("SHOWS_MONITOR", &cfg.Shows.Monitor)
+	envString("SHOWS_CRON_ANTICIPATED", &cfg.Shows.CronAnticipated)
+	envString("SHOWS_CRON_POPULAR", &cfg.Shows.CronPopular)
+	envString("SHOWS_CRON_TRENDING", &cfg.Shows.CronTrending)
+	envString("SHOWS_CRON_WATCHED", &cfg.Shows.CronWatched)
+	envString("SHOWS_CRON_PLAYED", &cfg.Shows.CronPlayed)
+	envString("SHOWS_CRON_COLLECTED", &cfg.Shows.CronCollected)
+
+	ints := map[string]**int{
+		"RADARR_QUALITY":         &cfg.Radarr.Quality,
+		"RADARR_ROOT_FOLDER":     &cfg.Radarr.RootFolder,
+		"SONARR_QUALITY":         &cfg.Sonarr.Quality,
+		"SONARR_ROOT_FOLDER":     &cfg.Sonarr.RootFolder,
+		"OMBI_MOVIE_QUALITY":     &cfg.Ombi.MovieQuality,
+		"OMBI_MOVIE_ROOT_FOLDER": &cfg.Ombi.MovieRootFolder,
+		"OMBI_SHOW_QUALITY":      &cfg.Ombi.ShowQuality,
+		"OMBI_SHOW_ROOT_FOLDER":  &cfg.Ombi.ShowRootFolder,
+		"MOVIES_ANTICIPATED":     &cfg.Movies.Anticipated,
+		"MOVIES_BOX_OFFICE":      &cfg.Movies.BoxOffice,
+		"MOVIES_POPULAR":         &cfg.Movies.Popular,
+		"MOVIES_TRENDING":        &cfg.Movies.Trending,
+		"MOVIES_WATCHED":         &cfg.Movies.Watched,
+		"MOVIES_PLAYED":          &cfg.Movies.Played,
+		"MOVIES_COLLECTED":       &cfg.Movies.Collected,
+		"MOVIES_MAX_RUNTIME":     &cfg.Movies.MaxRuntime,
+		"MOVIES_MIN_RUNTIME":     &cfg.Movies.MinRuntime,
+		"MOVIES_MIN_YEAR":        &cfg.Movies.MinYear,
+		"MOVIES_MAX_YEAR":        &cfg.Movies.MaxYear,
+		"MOVIES_MIN_VOTES":       &cfg.Movies.MinVotes,
+		"MOVIES_MIN_METACRITIC":  &cfg.Movies.MinMetacritic,
+		"SHOWS_ANTICIPATED":      &cfg.Shows.Anticipated,
+		"SHOWS_POPULAR":          &cfg.Shows.Popular,
+		"SHOWS_TRENDING":         &cfg.Shows.Trending,
+		"SHOWS_WATCHED":          &cfg.Shows.Watched,
+		"SHOWS_PLAYED":           &cfg.Shows.Played,
+		"SHOWS_COLLECTED":        &cfg.Shows.Collected,
+		"SHOWS_MAX_RUNTIME":      &cfg.Shows.MaxRuntime,
+		"SHOWS_MIN_RUNTIME":      &cfg.Shows.MinRuntime,
+		"SHOWS_MIN_YEAR":         &cfg.Shows.MinYear,
+		"SHOWS_MAX_YEAR":         &cfg.Shows.MaxYear,
+		"SHOWS_MIN_VOTES":        &cfg.Shows.MinVotes,
+		"SHOWS_MIN_METACRITIC":   &cfg.Shows.MinMetacritic,
+	}
+	for name, dst := range ints {
+		if err := envInt(name, dst); err != nil {
+			return err
+		}
+	}
+
+	floats := map[string]**float64{
+		"MOVIES_MIN_RATING":      &cfg.Movies.MinRating,
+		"MOVIES_MIN_IMDB_RATING": &cfg.Movies.MinIMDbRating,
+		"SHOWS_MIN_RATING":       &cfg.Shows.MinRating,
+		"SHOWS_MIN_IMDB_RATING":  &cfg.Shows.MinIMDbRating,
+	}
+	for name, dst := range floats {
+		if err := envFloat(name, dst); err != nil {
+			return err
+		}
+	}
+
+	bools := map[string]**bool{
+		"SONARR_SEASON_FOLDER": &cfg.Sonarr.SeasonFolder,
+		"SHOWS_SEASON_FOLDER":  &cfg.Shows.SeasonFolder,
+		"SHOWS_SEARCH_ON_ADD":  &cfg.Shows.SearchOnAdd,
+	}
+	for name, dst := range bools {
+		if err := envBool(name, dst); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// envString sets dst to the value of the prefixed environment variable if it's set
+func envString(name string, dst **string) {
+	if value, ok := os.LookupEnv(EnvPrefix + name); ok {
+		*dst = &value
+	}
+}
+
+// envInt sets dst to the value of the prefixed environment variable if it's set
+func envInt(name string, dst **int) error {
+	value, ok := os.LookupEnv(EnvPrefix + name)
+	if !ok {
+		return nil
+	}
+
+	parsed, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return fmt.Errorf("invalid value for %s%s, expected a number: %w", EnvPrefix, name, err)
+	}
+
+	*dst = &parsed
+	return nil
+}
+
+// envFloat sets dst to the value of the prefixed environment variable if it's set
+func envFloat(name string, dst **float64) error {
+	value, ok := os.LookupEnv(EnvPrefix + name)
+	if !ok {
+		return nil
+	}
+
+	parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return fmt.Errorf("invalid value for %s%s, expected a number: %w", EnvPrefix, name, err)
+	}
+
+	*dst = &parsed
+	return nil
+}
+
+// envBool sets dst to the value of the prefixed environment variable if it's set
+func envBool(name string, dst **bool) error {
+	value, ok := os.LookupEnv(EnvPrefix + name)
+	if !ok {
+		return nil
+	}
+
+	parsed, err := strconv.ParseBool(strings.TrimSpace(value))
+	if err != nil {
+		return fmt.Errorf("invalid value for %s%s, expected true or false: %w", EnvPrefix, name, err)
+	}
+
+	*dst = &parsed
+	return nil
+}
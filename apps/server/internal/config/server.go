@@ -0,0 +1,187 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Server holds the runtime options for the HTTP server and the database
+type Server struct {
+	Address     string // Address the API server binds to
+	Port        int    // Port the API server listens on
+	TLSCert     string // Path to the TLS certificate, TLS is enabled when this and TLSKey are set
+	TLSKey      string // Path to the TLS private key
+	CORSOrigins string // Comma-separated list of origins allowed to call the API
+	BasePath    string // Path prefix the API is served under when behind a reverse proxy (e.g., /blockbusterr)
+	DBPath      string // Path to the SQLite database file
+	SecretKey   string // Key secrets are encrypted with, generated and stored in SecretFile when empty
+	SecretFile  string // Path of the generated secret key when SecretKey is empty, kept apart from the database
+
+	// BootstrapToken creates the admin user of installs that finished the setup before authentication
+	// existed. It's generated and logged at startup when empty and such an install has no users yet.
+	BootstrapToken string
+}
+
+// RegisterServerFlags adds the server flags to fs. Each flag defaults to its BLOCKBUSTERR_*
+// environment variable, falling back to the built-in default when that isn't set.
+func RegisterServerFlags(fs *flag.FlagSet, version string) *Server {
+	opts := &Server{}
+
+	// Docker images keep their data in a mounted volume, development builds next to the binary
+	// The generated secret key is kept out of the database directory, so a copy of the database doesn't include it
+	defaultDBPath := "/app/data/settings.db"
+	defaultSecretFile := "/app/config/secret.key"
+	if version == "dev" {
+		defaultDBPath = "blockbusterr.db"
+		defaultSecretFile = ""
+		if dir, err := os.UserConfigDir(); err == nil {
+			defaultSecretFile = filepath.Join(dir, "blockbusterr", "secret.key")
+		}
+	}
+
+	port := 3000
+	if value, ok := os.LookupEnv(EnvPrefix + "PORT"); ok {
+		// An invalid port is reported by Validate
+		if parsed, err := strconv.Atoi(value); err == nil {
+			port = parsed
+		} else {
+			port = -1
+		}
+	}
+
+	fs.StringVar(&opts.Address, "address", envOr("ADDRESS", "0.0.0.0"), "Address the API server binds to")
+	fs.IntVar(&opts.Port, "port", port, "Port the API server listens on")
+	fs.StringVar(&opts.TLSCert, "tls-cert", envOr("TLS_CERT", ""), "Path to a TLS certificate, enables HTTPS together with -tls-key")
+	fs.StringVar(&opts.TLSKey, "tls-key", envOr("TLS_KEY", ""), "Path to the TLS private key")
+	fs.StringVar(&opts.CORSOrigins, "cors-origins", envOr("CORS_ORIGINS", "http://localhost:5555,http://localhost:5173"), "Comma-separated list of origins allowed to call the API")
+	fs.StringVar(&opts.BasePath, "base-path", envOr("BASE_PATH", ""), "Path prefix the API is served under, for reverse proxies")
+	fs.StringVar(&opts.DBPath, "db-path", envOr("DB_PATH", defaultDBPath), "Path to the SQLite database file")
+	fs.StringVar(&opts.SecretFile, "secret-key-file", envOr("SECRET_KEY_FILE", defaultSecretFile), "Path the generated secret key is stored at when no secret key is set, must be outside the database directory")
+
+	// The key itself is only read from the environment, so it doesn't show up in the process list
+	opts.SecretKey = envOr("SECRET_KEY", "")
+	opts.BootstrapToken = envOr("BOOTSTRAP_TOKEN", "")
+
+	return opts
+}
+
+// Validate checks the options and normalizes the base path and CORS origins
+func (s *Server) Validate() error {
+	if s.Port <= 0 || s.Port > 65535 {
+		return fmt.Errorf("invalid port, expected a number between 1 and 65535")
+	}
+
+	if (s.TLSCert == "") != (s.TLSKey == "") {
+		return fmt.Errorf("both a TLS certificate and a TLS key are required to enable TLS")
+	}
+
+	if s.DBPath == "" {
+		return fmt.Errorf("database path can't be empty")
+	}
+
+	if s.SecretKey == "" {
+		if s.SecretFile == "" {
+			return fmt.Errorf("no secret key is set, set %sSECRET_KEY or a secret key file", EnvPrefix)
+		}
+
+		// Anyone with a copy of the database directory could read the secrets if the key was stored there
+		sameDir, err := sameDirectory(s.SecretFile, s.DBPath)
+		if err != nil {
+			return err
+		}
+		if sameDir {
+			return fmt.Errorf("secret key file %s can't be stored in the same directory as the database", s.SecretFile)
+		}
+	}
+
+	// "/blockbusterr/" and "blockbusterr" both become "/blockbusterr", "/" becomes no prefix at all
+	s.BasePath = strings.Trim(strings.TrimSpace(s.BasePath), "/")
+	if s.BasePath != "" {
+		s.BasePath = "/" + s.BasePath
+	}
+
+	origins, err := parseCORSOrigins(s.CORSOrigins)
+	if err != nil {
+		return err
+	}
+	s.CORSOrigins = strings.Join(origins, ",")
+
+	return nil
+}
+
+// sameDirectory reports whether the files at a and b are in the same directory
+func sameDirectory(a, b string) (bool, error) {
+	dirA, err := filepath.Abs(filepath.Dir(a))
+	if err != nil {
+		return false, fmt.Errorf("error resolving path %s: %w", a, err)
+	}
+
+	dirB, err := filepath.Abs(filepath.Dir(b))
+	if err != nil {
+		return false, fmt.Errorf("error resolving path %s: %w", b, err)
+	}
+
+	return dirA == dirB, nil
+}
+
+// parseCORSOrigins splits and checks the comma-separated CORS origins. Either every origin has an http(s) scheme
+// and a host, or the only origin is "*". An empty value is rejected instead of falling back to allowing every origin.
+func parseCORSOrigins(value string) ([]string, error) {
+	var origins []string
+	for _, origin := range strings.Split(value, ",") {
+		origin = strings.TrimSpace(origin)
+		if origin == "" {
+			return nil, fmt.Errorf("invalid CORS origins %q, origins can't be empty", value)
+		}
+
+		if origin != "*" {
+			// Subdomain wildcards like https://*.example.com are checked without the wildcard
+			parsed, err := url.Parse(strings.Replace(origin, "://*.", "://", 1))
+			if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" ||
+				(parsed.Path != "" && parsed.Path != "/") || parsed.RawQuery != "" || parsed.Fragment != "" {
+				return nil, fmt.Errorf("invalid CORS origin %q, expected an http:// or https:// origin like https://example.com", origin)
+			}
+		}
+
+		origins = append(origins, origin)
+	}
+
+	if len(origins) > 1 && slices.Contains(origins, "*") {
+		return nil, fmt.Errorf("invalid CORS origins %q, \"*\" can't be combined with other origins", value)
+	}
+
+	return origins, nil
+}
+
+// AllowsAnyOrigin reports whether the API accepts requests from every origin. An empty list of
+// origins counts as well, since that's what the CORS middleware falls back to.
+func (s *Server) AllowsAnyOrigin() bool {
+	origins, err := parseCORSOrigins(s.CORSOrigins)
+	return err != nil || slices.Contains(origins, "*")
+}
+
+// ListenAddr returns the address the API server listens on
+func (s *Server) ListenAddr() string {
+	return net.JoinHostPort(s.Address, strconv.Itoa(s.Port))
+}
+
+// TLSEnabled reports whether the API server should serve HTTPS
+func (s *Server) TLSEnabled() bool {
+	return s.TLSCert != "" && s.TLSKey != ""
+}
+
+// envOr returns the value of the prefixed environment variable, or fallback if it isn't set
+func envOr(name, fallback string) string {
+	if value, ok := os.LookupEnv(EnvPrefix + name); ok {
+		return value
+	}
+
+	return fallback
+}
@@ -0,0 +1,182 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type CleanupSettings struct {
+	ID          int            `db:"id"`           // Primary key with auto-increment
+	Cron        sql.NullString `db:"cron"`         // Cron expression the cleanup job runs on
+	MinAgeDays  int            `db:"min_age_days"` // Titles are only cleaned up once they were added at least this many days ago
+	Action      string         `db:"action"`       // Whether titles are unmonitored or deleted (unmonitor, delete)
+	DeleteFiles bool           `db:"delete_files"` // Whether deleting a title also deletes its files
+}
+
+type CleanupExclusion struct {
+	ID        int            `db:"id"`         // Primary key with auto-increment
+	MediaType string         `db:"media_type"` // Type of media (MOVIE, SHOW)
+	MediaID   int            `db:"media_id"`   // TMDb ID of a movie or TVDB ID of a show
+	Title     sql.NullString `db:"title"`      // Title of the excluded media
+	CreatedAt time.Time      `db:"created_at"` // Time the exclusion was added
+}
+
+// AddedTitle is a title the scheduler added to a Radarr or Sonarr instance
+type AddedTitle struct {
+	MediaID    int           // TMDb ID of a movie or TVDB ID of a show
+	InstanceID int           // Radarr or Sonarr instance the media was added to
+	Title      string        // Title of the media
+	Year       sql.NullInt32 // Year the media was released
+	Source     string        // List the media was first added from
+	AddedAt    time.Time     // Time the media was first added
+}
+
+var (
+	ErrNoCleanupExclusion     = fmt.Errorf("no cleanup exclusion found")
+	ErrCleanupExclusionExists = fmt.Errorf("cleanup exclusion already exists")
+)
+
+// GetCleanupSettings returns the settings of the cleanup job
+func (q *Queries) GetCleanupSettings(ctx context.Context) (CleanupSettings, error) {
+	var settings CleanupSettings
+
+	err := q.db.QueryRowContext(ctx, `
+		SELECT id, cron, min_age_days, action, delete_files
+		FROM cleanup_settings
+		LIMIT 1;
+	`).Scan(
+		&settings.ID,
+		&settings.Cron,
+		&settings.MinAgeDays,
+		&settings.Action,
+		&settings.DeleteFiles,
+	)
+	if err != nil {
+		return settings, fmt.Errorf("error fetching cleanup settings: %v", err)
+	}
+
+	return settings, nil
+}
+
+// UpdateCleanupSettings updates the settings of the cleanup job
+func (q *Queries) UpdateCleanupSettings(ctx context.Context, settings CleanupSettings) error {
+	query := `
+		UPDATE cleanup_settings
+		SET cron = $1, min_age_days = $2, action = $3, delete_files = $4
+		WHERE id = (SELECT MIN(id) FROM cleanup_settings);
+	`
+
+	_, err := q.db.ExecContext(ctx, query, settings.Cron, settings.MinAgeDays, settings.Action, settings.DeleteFiles)
+	if err != nil {
+		return fmt.Errorf("error updating cleanup settings: %v", err)
+	}
+
+	return nil
+}
+
+// GetCleanupExclusions returns every title the cleanup job never touches
+func (q *Queries) GetCleanupExclusions(ctx context.Context) ([]CleanupExclusion, error) {
+	rows, err := q.db.QueryContext(ctx, `
+		SELECT id, media_type, media_id, title, created_at
+		FROM cleanup_exclusions
+		ORDER BY id;
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("error querying cleanup exclusions: %v", err)
+	}
+	defer rows.Close()
+
+	exclusions := []CleanupExclusion{}
+	for rows.Next() {
+		var exclusion CleanupExclusion
+		if err := rows.Scan(&exclusion.ID, &exclusion.MediaType, &exclusion.MediaID, &exclusion.Title, &exclusion.CreatedAt); err != nil {
+			return nil, fmt.Errorf("error scanning cleanup exclusion: %v", err)
+		}
+		exclusions = append(exclusions, exclusion)
+	}
+
+	return exclusions, rows.Err()
+}
+
+// AddCleanupExclusion excludes a title from the cleanup job and returns the ID of the exclusion
+func (q *Queries) AddCleanupExclusion(ctx context.Context, mediaType string, mediaID int, title sql.NullString) (int, error) {
+	result, err := q.db.ExecContext(ctx, `
+		INSERT OR IGNORE INTO cleanup_exclusions (media_type, media_id, title)
+		VALUES ($1, $2, $3);
+	`, mediaType, mediaID, title)
+	if err != nil {
+		return 0, fmt.Errorf("error adding cleanup exclusion: %v", err)
+	}
+
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return 0, ErrCleanupExclusionExists
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(id), nil
+}
+
+// DeleteCleanupExclusion removes the cleanup exclusion with the given ID
+func (q *Queries) DeleteCleanupExclusion(ctx context.Context, id int) error {
+	result, err := q.db.ExecContext(ctx, `DELETE FROM cleanup_exclusions WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("error deleting cleanup exclusion: %v", err)
+	}
+
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return ErrNoCleanupExclusion
+	}
+
+	return nil
+}
+
+// GetAddedTitles returns the titles of a media type the scheduler added to Radarr or Sonarr at least minAgeDays ago.
+// Each title is returned once per instance it was added to, along with the list and time it was first added there.
+func (q *Queries) GetAddedTitles(ctx context.Context, mediaType string, minAgeDays int) ([]AddedTitle, error) {
+	idColumn := "tmdb_id"
+	if mediaType == "SHOW" {
+		idColumn = "tvdb_id"
+	}
+
+	query := `
+		SELECT h.` + idColumn + `, i.instance_id, h.title, h.year, h.source, MIN(h.created_at) AS added_at
+		FROM request_history h
+		JOIN request_history_instances i ON i.history_id = h.id
+		WHERE h.media_type = $1
+			AND h.outcome = 'added'
+			AND h.backend IN ('radarr', 'sonarr')
+			AND h.` + idColumn + ` IS NOT NULL
+		GROUP BY h.` + idColumn + `, i.instance_id
+		HAVING MIN(h.created_at) <= datetime('now', $2)
+		ORDER BY added_at;
+	`
+
+	rows, err := q.db.QueryContext(ctx, query, mediaType, fmt.Sprintf("-%d days", minAgeDays))
+	if err != nil {
+		return nil, fmt.Errorf("error querying added titles: %v", err)
+	}
+	defer rows.Close()
+
+	titles := []AddedTitle{}
+	for rows.Next() {
+		var title AddedTitle
+		var addedAt string
+		if err := rows.Scan(&title.MediaID, &title.InstanceID, &title.Title, &title.Year, &title.Source, &addedAt); err != nil {
+			return nil, fmt.Errorf("error scanning added title: %v", err)
+		}
+
+		title.AddedAt, err = time.Parse(time.DateTime, addedAt)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing time %q a title was added: %v", addedAt, err)
+		}
+		titles = append(titles, title)
+	}
+
+	return titles, rows.Err()
+}
@@ -1,10 +1,21 @@
 package db
 
-import "database/sql"
+import (
+	"database/sql"
+
+	"github.com/mahcks/blockbusterr/internal/secrets"
+	"github.com/mahcks/blockbusterr/pkg/structures"
+)
 
 // Queries struct to hold the database connection
 type Queries struct {
 	db *sql.DB
+
+	// Functions called with every log entry after it is inserted
+	logListeners []func(structures.Log)
+
+	// Cipher secrets are encrypted with before they are stored
+	secrets *secrets.Cipher
 }
 
 // NewQueries initializes a new Queries struct
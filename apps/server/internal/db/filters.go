@@ -0,0 +1,179 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+var ErrNoFilterEntry = errors.New("no filter entry found")
+
+// FilterList is one of the child tables of the movie or show settings holding a list of allowed or blacklisted values
+type FilterList struct {
+	Table          string // Name of the child table
+	SettingsColumn string // Foreign key column to the movie or show settings
+	ValueColumn    string // Column holding the value of an entry
+	HasMatchMode   bool   // Whether the table has a match_mode column, only the title keyword tables do
+}
+
+var (
+	MovieAllowedCountriesList    = FilterList{Table: "movie_allowed_countries", SettingsColumn: "movie_settings_id", ValueColumn: "country_code"}
+	MovieAllowedLanguagesList    = FilterList{Table: "movie_allowed_languages", SettingsColumn: "movie_settings_id", ValueColumn: "language_code"}
+	MovieBlacklistedGenresList   = FilterList{Table: "movie_blacklisted_genres", SettingsColumn: "movie_settings_id", ValueColumn: "genre"}
+	MovieBlacklistedKeywordsList = FilterList{Table: "movie_blacklisted_title_keywords", SettingsColumn: "movie_settings_id", ValueColumn: "keyword", HasMatchMode: true}
+	MovieBlacklistedTMDBIDsList  = FilterList{Table: "movie_blacklisted_tmdb_ids", SettingsColumn: "movie_settings_id", ValueColumn: "tmdb_id"}
+
+	ShowAllowedCountriesList    = FilterList{Table: "show_allowed_countries", SettingsColumn: "show_settings_id", ValueColumn: "country_code"}
+	ShowAllowedLanguagesList    = FilterList{Table: "show_allowed_languages", SettingsColumn: "show_settings_id", ValueColumn: "language_code"}
+	ShowBlacklistedGenresList   = FilterList{Table: "show_blacklisted_genres", SettingsColumn: "show_settings_id", ValueColumn: "genre"}
+	ShowBlacklistedNetworksList = FilterList{Table: "show_blacklisted_networks", SettingsColumn: "show_settings_id", ValueColumn: "network"}
+	ShowBlacklistedKeywordsList = FilterList{Table: "show_blacklisted_title_keywords", SettingsColumn: "show_settings_id", ValueColumn: "keyword", HasMatchMode: true}
+	ShowBlacklistedTVDBIDsList  = FilterList{Table: "show_blacklisted_tvdb_ids", SettingsColumn: "show_settings_id", ValueColumn: "tvdb_id"}
+)
+
+// FilterEntry is a row of a filter list
+type FilterEntry struct {
+	ID        int    `db:"id"`         // Primary key with auto-increment
+	Value     string `db:"value"`      // Country or language code, genre, network, keyword or ID
+	MatchMode string `db:"match_mode"` // How a title keyword is matched, empty for the other lists
+}
+
+func (l FilterList) selectColumns() string {
+	if l.HasMatchMode {
+		return fmt.Sprintf("id, %s, match_mode", l.ValueColumn)
+	}
+
+	return fmt.Sprintf("id, %s, ''", l.ValueColumn)
+}
+
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+func getFilterEntries(ctx context.Context, q queryer, list FilterList) ([]FilterEntry, error) {
+	rows, err := q.QueryContext(ctx, fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		WHERE %s = 1
+		ORDER BY id;
+	`, list.selectColumns(), list.Table, list.SettingsColumn))
+	if err != nil {
+		return nil, fmt.Errorf("error fetching %s: %v", list.Table, err)
+	}
+	defer rows.Close()
+
+	entries := []FilterEntry{}
+	for rows.Next() {
+		var entry FilterEntry
+		if err := rows.Scan(&entry.ID, &entry.Value, &entry.MatchMode); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// GetFilterEntries returns the entries of a filter list
+func (q *Queries) GetFilterEntries(ctx context.Context, list FilterList) ([]FilterEntry, error) {
+	return getFilterEntries(ctx, q.db, list)
+}
+
+// AddFilterEntries adds entries to a filter list in one transaction, skipping values that are already on it.
+// The entries of the list are returned afterwards.
+func (q *Queries) AddFilterEntries(ctx context.Context, list FilterList, entries []FilterEntry) ([]FilterEntry, error) {
+	tx, err := q.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := insertFilterEntries(ctx, tx, list, entries); err != nil {
+		return nil, err
+	}
+
+	updated, err := getFilterEntries(ctx, tx, list)
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, tx.Commit()
+}
+
+// ReplaceFilterEntries replaces every entry of a filter list in one transaction.
+// The entries of the list are returned afterwards.
+func (q *Queries) ReplaceFilterEntries(ctx context.Context, list FilterList, entries []FilterEntry) ([]FilterEntry, error) {
+	tx, err := q.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s WHERE %s = 1;`, list.Table, list.SettingsColumn))
+	if err != nil {
+		return nil, fmt.Errorf("error clearing %s: %v", list.Table, err)
+	}
+
+	if err := insertFilterEntries(ctx, tx, list, entries); err != nil {
+		return nil, err
+	}
+
+	updated, err := getFilterEntries(ctx, tx, list)
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, tx.Commit()
+}
+
+// insertFilterEntries inserts the entries whose value isn't on the list yet. A keyword that is already on the
+// list gets the match mode of the new entry.
+func insertFilterEntries(ctx context.Context, tx *sql.Tx, list FilterList, entries []FilterEntry) error {
+	settingsColumn := list.SettingsColumn
+	for _, entry := range entries {
+		var id int
+		err := tx.QueryRowContext(ctx, fmt.Sprintf(`SELECT id FROM %s WHERE %s = 1 AND %s = $1;`, list.Table, settingsColumn, list.ValueColumn), entry.Value).Scan(&id)
+		switch {
+		case err == nil:
+			if list.HasMatchMode {
+				if _, err := tx.ExecContext(ctx, fmt.Sprintf(`UPDATE %s SET match_mode = $1 WHERE id = $2;`, list.Table), entry.MatchMode, id); err != nil {
+					return fmt.Errorf("error updating %s entry %q: %v", list.Table, entry.Value, err)
+				}
+			}
+			continue
+		case !errors.Is(err, sql.ErrNoRows):
+			return fmt.Errorf("error checking %s entry %q: %v", list.Table, entry.Value, err)
+		}
+
+		if list.HasMatchMode {
+			_, err = tx.ExecContext(ctx, fmt.Sprintf(`INSERT INTO %s (%s, %s, match_mode) VALUES (1, $1, $2);`, list.Table, settingsColumn, list.ValueColumn), entry.Value, entry.MatchMode)
+		} else {
+			_, err = tx.ExecContext(ctx, fmt.Sprintf(`INSERT INTO %s (%s, %s) VALUES (1, $1);`, list.Table, settingsColumn, list.ValueColumn), entry.Value)
+		}
+		if err != nil {
+			return fmt.Errorf("error adding %s entry %q: %v", list.Table, entry.Value, err)
+		}
+	}
+
+	return nil
+}
+
+// DeleteFilterEntry removes an entry from a filter list
+func (q *Queries) DeleteFilterEntry(ctx context.Context, list FilterList, id int) error {
+	res, err := q.db.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s WHERE id = $1 AND %s = 1;`, list.Table, list.SettingsColumn), id)
+	if err != nil {
+		return fmt.Errorf("error deleting %s entry: %v", list.Table, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrNoFilterEntry
+	}
+
+	return nil
+}
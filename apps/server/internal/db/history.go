@@ -0,0 +1,162 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type RequestHistory struct {
+	ID        int            `db:"id"`         // Primary key with auto-increment
+	RunID     string         `db:"run_id"`     // ID of the job run that considered the candidate
+	MediaType string         `db:"media_type"` // Type of media (MOVIE, SHOW)
+	Title     string         `db:"title"`      // Title of the candidate
+	Year      sql.NullInt32  `db:"year"`       // Year the candidate was released
+	TMDBID    sql.NullInt32  `db:"tmdb_id"`    // TMDb ID of the candidate
+	TVDBID    sql.NullInt32  `db:"tvdb_id"`    // TVDB ID of the candidate
+	IMDBID    sql.NullString `db:"imdb_id"`    // IMDb ID of the candidate
+	Source    string         `db:"source"`     // List the candidate came from (e.g., movie-trending)
+	Backend   string         `db:"backend"`    // Where the candidate was requested (radarr, sonarr, ombi)
+	Outcome   string         `db:"outcome"`    // What happened to the candidate (added, exists, failed, dry_run)
+	Error     sql.NullString `db:"error"`      // Error text if the request failed
+	Reason    sql.NullString `db:"reason"`     // Why the candidate was skipped, or per-instance details of the outcome
+	CreatedAt time.Time      `db:"created_at"` // Time the candidate was considered
+
+	InstanceIDs []int `db:"-"` // Radarr or Sonarr instances the candidate was added to, stored in request_history_instances
+}
+
+// RequestHistoryFilter holds the optional filters for querying the request history
+type RequestHistoryFilter struct {
+	RunID     string
+	MediaType string
+	Source    string
+	Backend   string
+	Outcome   string
+	Search    string
+}
+
+// InsertRequestHistory stores a history entry along with the instances the candidate was added to
+func (q *Queries) InsertRequestHistory(ctx context.Context, entry RequestHistory) error {
+	tx, err := q.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO request_history (run_id, media_type, title, year, tmdb_id, tvdb_id, imdb_id, source, backend, outcome, error, reason)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12);
+	`
+
+	result, err := tx.ExecContext(ctx, query,
+		entry.RunID,
+		entry.MediaType,
+		entry.Title,
+		entry.Year,
+		entry.TMDBID,
+		entry.TVDBID,
+		entry.IMDBID,
+		entry.Source,
+		entry.Backend,
+		entry.Outcome,
+		entry.Error,
+		entry.Reason,
+	)
+	if err != nil {
+		return fmt.Errorf("error inserting request history: %v", err)
+	}
+
+	historyID, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("error fetching request history ID: %v", err)
+	}
+
+	for _, instanceID := range entry.InstanceIDs {
+		_, err := tx.ExecContext(ctx, `INSERT OR IGNORE INTO request_history_instances (history_id, instance_id) VALUES ($1, $2)`, historyID, instanceID)
+		if err != nil {
+			return fmt.Errorf("error recording instance %d of request history: %v", instanceID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing transaction: %v", err)
+	}
+
+	return nil
+}
+
+func (q *Queries) GetRequestHistory(ctx context.Context, take, skip int, filter RequestHistoryFilter) ([]RequestHistory, error) {
+	// Base query
+	query := `SELECT id, run_id, media_type, title, year, tmdb_id, tvdb_id, imdb_id, source, backend, outcome, error, reason, created_at FROM request_history WHERE 1=1`
+	args := []interface{}{}
+	argIndex := 1
+
+	// Add an exact match condition for every filter that was provided
+	conditions := []struct {
+		column string
+		value  string
+	}{
+		{"run_id", filter.RunID},
+		{"media_type", filter.MediaType},
+		{"source", filter.Source},
+		{"backend", filter.Backend},
+		{"outcome", filter.Outcome},
+	}
+	for _, condition := range conditions {
+		if condition.value == "" {
+			continue
+		}
+		query += fmt.Sprintf(" AND %s = $%d", condition.column, argIndex)
+		args = append(args, condition.value)
+		argIndex++
+	}
+
+	// Add search condition if provided
+	if filter.Search != "" {
+		query += fmt.Sprintf(" AND (title LIKE $%d OR error LIKE $%d OR reason LIKE $%d)", argIndex, argIndex+1, argIndex+2)
+		args = append(args, "%"+filter.Search+"%", "%"+filter.Search+"%", "%"+filter.Search+"%")
+		argIndex += 3
+	}
+
+	// Add order, limit, and offset for pagination
+	query += fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
+	args = append(args, take, skip)
+
+	rows, err := q.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying request history: %v", err)
+	}
+	defer rows.Close()
+
+	var history []RequestHistory
+	for rows.Next() {
+		var entry RequestHistory
+		err := rows.Scan(
+			&entry.ID,
+			&entry.RunID,
+			&entry.MediaType,
+			&entry.Title,
+			&entry.Year,
+			&entry.TMDBID,
+			&entry.TVDBID,
+			&entry.IMDBID,
+			&entry.Source,
+			&entry.Backend,
+			&entry.Outcome,
+			&entry.Error,
+			&entry.Reason,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning request history row: %v", err)
+		}
+		history = append(history, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating request history: %v", err)
+	}
+
+	return history, nil
+}
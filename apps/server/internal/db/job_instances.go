@@ -0,0 +1,172 @@
+package db
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	movieJobPrefix = "movie-" // Prefix of the movie list jobs, which send to Radarr instances
+	showJobPrefix  = "show-"  // Prefix of the show list jobs, which send to Sonarr instances
+
+	// ListSourceJobPrefix is the prefix of the list source jobs, followed by the ID of the source
+	ListSourceJobPrefix = "list-"
+)
+
+// GetJobInstanceIDs returns the IDs of the instances a list job is assigned to.
+// An empty result means the job sends to every instance.
+func (q *Queries) GetJobInstanceIDs(ctx context.Context, jobType string) ([]int, error) {
+	rows, err := q.db.QueryContext(ctx, `
+		SELECT instance_id
+		FROM job_instances
+		WHERE job_type = $1
+		ORDER BY instance_id;
+	`, jobType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []int{}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// SetJobInstanceIDs replaces the instances a list job is assigned to
+func (q *Queries) SetJobInstanceIDs(ctx context.Context, jobType string, ids []int) error {
+	tx, err := q.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM job_instances WHERE job_type = $1`, jobType); err != nil {
+		return fmt.Errorf("error clearing job instances: %v", err)
+	}
+
+	for _, id := range ids {
+		_, err := tx.ExecContext(ctx, `INSERT OR IGNORE INTO job_instances (job_type, instance_id) VALUES ($1, $2)`, jobType, id)
+		if err != nil {
+			return fmt.Errorf("error assigning instance %d: %v", id, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing transaction: %v", err)
+	}
+
+	return nil
+}
+
+// GetRadarrInstancesForJob returns the Radarr instances a movie list job sends to.
+// Jobs without assigned instances send to every instance that has a URL set.
+func (q *Queries) GetRadarrInstancesForJob(ctx context.Context, jobType string) ([]RadarrSettings, error) {
+	ids, err := q.GetJobInstanceIDs(ctx, jobType)
+	if err != nil {
+		return nil, err
+	}
+
+	assigned := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		assigned[id] = true
+	}
+
+	all, err := q.GetRadarrInstances(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	instances := []RadarrSettings{}
+	for _, instance := range all {
+		if len(ids) > 0 && !assigned[instance.ID] {
+			continue
+		}
+
+		if len(ids) == 0 && (!instance.URL.Valid || instance.URL.String == "") {
+			continue
+		}
+
+		instances = append(instances, instance)
+	}
+
+	return instances, nil
+}
+
+// GetSonarrInstancesForJob returns the Sonarr instances a show list job sends to.
+// Jobs without assigned instances send to every instance that has a URL set.
+func (q *Queries) GetSonarrInstancesForJob(ctx context.Context, jobType string) ([]SonarrSettings, error) {
+	ids, err := q.GetJobInstanceIDs(ctx, jobType)
+	if err != nil {
+		return nil, err
+	}
+
+	assigned := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		assigned[id] = true
+	}
+
+	all, err := q.GetSonarrInstances(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	instances := []SonarrSettings{}
+	for _, instance := range all {
+		if len(ids) > 0 && !assigned[instance.ID] {
+			continue
+		}
+
+		if len(ids) == 0 && (!instance.URL.Valid || instance.URL.String == "") {
+			continue
+		}
+
+		instances = append(instances, instance)
+	}
+
+	return instances, nil
+}
+
+// deleteInstance removes a Radarr or Sonarr instance and unassigns it from the list jobs and list sources of its kind
+func (q *Queries) deleteInstance(ctx context.Context, table, jobPrefix string, id int, errNotFound error) error {
+	mediaType := "show"
+	if jobPrefix == movieJobPrefix {
+		mediaType = "movie"
+	}
+
+	tx, err := q.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, table), id)
+	if err != nil {
+		return fmt.Errorf("error deleting %s instance: %v", table, err)
+	}
+
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return errNotFound
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM job_instances
+		WHERE instance_id = $1
+		AND (job_type LIKE $2 OR job_type IN (SELECT $3 || id FROM list_sources WHERE media_type = $4));
+	`, id, jobPrefix+"%", ListSourceJobPrefix, mediaType)
+	if err != nil {
+		return fmt.Errorf("error unassigning %s instance: %v", table, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing transaction: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// GetJobMonitor returns the Sonarr monitor strategy of a show list job.
+// An empty result means the job uses the monitor option of the show settings.
+func (q *Queries) GetJobMonitor(ctx context.Context, jobType string) (string, error) {
+	var monitor string
+	err := q.db.QueryRowContext(ctx, `SELECT monitor FROM job_monitor WHERE job_type = $1`, jobType).Scan(&monitor)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return monitor, nil
+}
+
+// SetJobMonitor sets the Sonarr monitor strategy of a show list job. An empty strategy removes it.
+func (q *Queries) SetJobMonitor(ctx context.Context, jobType, monitor string) error {
+	if monitor == "" {
+		_, err := q.db.ExecContext(ctx, `DELETE FROM job_monitor WHERE job_type = $1`, jobType)
+		return err
+	}
+
+	_, err := q.db.ExecContext(ctx, `
+		INSERT INTO job_monitor (job_type, monitor)
+		VALUES ($1, $2)
+		ON CONFLICT(job_type) DO UPDATE SET monitor = excluded.monitor;
+	`, jobType, monitor)
+	return err
+}
@@ -0,0 +1,95 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type JobRun struct {
+	ID         string         `db:"id"`          // Unique ID of the run
+	JobType    string         `db:"job_type"`    // List the job ran for (e.g., movie-trending)
+	Status     string         `db:"status"`      // Current status of the run (running, completed, failed)
+	Added      int            `db:"added"`       // Number of items that were added/requested
+	Skipped    int            `db:"skipped"`     // Number of items that were filtered out, already existed or only reported in dry-run mode
+	Failed     int            `db:"failed"`      // Number of items whose request failed
+	Owned      int            `db:"owned"`       // Number of items that were already in the media server library
+	Error      sql.NullString `db:"error"`       // Why the run failed
+	StartedAt  time.Time      `db:"started_at"`  // Time the run started
+	FinishedAt sql.NullTime   `db:"finished_at"` // Time the run finished
+}
+
+var ErrNoJobRun = fmt.Errorf("no job run found")
+
+func (q *Queries) InsertJobRun(ctx context.Context, run JobRun) error {
+	query := `
+		INSERT INTO job_runs (id, job_type, status, started_at)
+		VALUES ($1, $2, $3, $4);
+	`
+
+	_, err := q.db.ExecContext(ctx, query, run.ID, run.JobType, run.Status, run.StartedAt)
+	if err != nil {
+		return fmt.Errorf("error inserting job run: %v", err)
+	}
+
+	return nil
+}
+
+// FinishJobRun stores the final status and counts of a run
+func (q *Queries) FinishJobRun(ctx context.Context, run JobRun) error {
+	query := `
+		UPDATE job_runs
+		SET status = $1, added = $2, skipped = $3, failed = $4, owned = $5, error = $6, finished_at = $7
+		WHERE id = $8;
+	`
+
+	_, err := q.db.ExecContext(ctx, query, run.Status, run.Added, run.Skipped, run.Failed, run.Owned, run.Error, run.FinishedAt, run.ID)
+	if err != nil {
+		return fmt.Errorf("error finishing job run: %v", err)
+	}
+
+	return nil
+}
+
+// FailInterruptedJobRuns marks runs that were still running when the server stopped as failed
+func (q *Queries) FailInterruptedJobRuns(ctx context.Context) error {
+	query := `
+		UPDATE job_runs
+		SET status = 'failed', error = 'interrupted by a server restart', finished_at = CURRENT_TIMESTAMP
+		WHERE status = 'running';
+	`
+
+	_, err := q.db.ExecContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("error failing interrupted job runs: %v", err)
+	}
+
+	return nil
+}
+
+func (q *Queries) GetJobRunByID(ctx context.Context, id string) (JobRun, error) {
+	query := `SELECT id, job_type, status, added, skipped, failed, owned, error, started_at, finished_at FROM job_runs WHERE id = $1`
+
+	var run JobRun
+	err := q.db.QueryRowContext(ctx, query, id).Scan(
+		&run.ID,
+		&run.JobType,
+		&run.Status,
+		&run.Added,
+		&run.Skipped,
+		&run.Failed,
+		&run.Owned,
+		&run.Error,
+		&run.StartedAt,
+		&run.FinishedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return run, ErrNoJobRun
+		}
+		return run, fmt.Errorf("error fetching job run: %v", err)
+	}
+
+	return run, nil
+}
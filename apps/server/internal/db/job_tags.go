@@ -0,0 +1,58 @@
+package db
+
+import (
+	"context"
+	"fmt"
+)
+
+// GetJobTags returns the tags a list job adds titles with.
+// An empty result means the job uses the tag of its source.
+func (q *Queries) GetJobTags(ctx context.Context, jobType string) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, `
+		SELECT tag
+		FROM job_tags
+		WHERE job_type = $1
+		ORDER BY tag;
+	`, jobType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := []string{}
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, rows.Err()
+}
+
+// SetJobTags replaces the tags a list job adds titles with
+func (q *Queries) SetJobTags(ctx context.Context, jobType string, tags []string) error {
+	tx, err := q.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM job_tags WHERE job_type = $1`, jobType); err != nil {
+		return fmt.Errorf("error clearing job tags: %v", err)
+	}
+
+	for _, tag := range tags {
+		_, err := tx.ExecContext(ctx, `INSERT OR IGNORE INTO job_tags (job_type, tag) VALUES ($1, $2)`, jobType, tag)
+		if err != nil {
+			return fmt.Errorf("error adding tag %q: %v", tag, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing transaction: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,162 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type ListSource struct {
+	ID         int            `db:"id"`          // Primary key with auto-increment
+	Name       string         `db:"name"`        // Name the source is shown with
+	MediaType  string         `db:"media_type"`  // Either "movie" or "show"
+	SourceType string         `db:"source_type"` // Either "list", "watchlist" or "recommendations"
+	Username   sql.NullString `db:"username"`    // Trakt user the list or watchlist belongs to
+	ListSlug   sql.NullString `db:"list_slug"`   // Slug or Trakt ID of the list
+	Cron       sql.NullString `db:"cron"`        // Cron expression the source is requested on
+	Limit      int            `db:"limit"`       // How many items are requested every run
+	MinYear    sql.NullInt32  `db:"min_year"`    // Skip items released before the specified year
+	MaxYear    sql.NullInt32  `db:"max_year"`    // Skip items released after the specified year
+	MinRuntime sql.NullInt32  `db:"min_runtime"` // Skip items shorter than the specified time in minutes
+	MaxRuntime sql.NullInt32  `db:"max_runtime"` // Skip items longer than the specified time in minutes
+	Enabled    bool           `db:"enabled"`     // Whether the source is scheduled
+	CreatedAt  time.Time      `db:"created_at"`  // Time the source was added
+}
+
+var ErrNoListSource = fmt.Errorf("no list source found")
+
+const listSourceColumns = "id, name, media_type, source_type, username, list_slug, cron, `limit`, min_year, max_year, min_runtime, max_runtime, enabled, created_at"
+
+func scanListSource(row interface{ Scan(...any) error }) (ListSource, error) {
+	var source ListSource
+	err := row.Scan(
+		&source.ID,
+		&source.Name,
+		&source.MediaType,
+		&source.SourceType,
+		&source.Username,
+		&source.ListSlug,
+		&source.Cron,
+		&source.Limit,
+		&source.MinYear,
+		&source.MaxYear,
+		&source.MinRuntime,
+		&source.MaxRuntime,
+		&source.Enabled,
+		&source.CreatedAt,
+	)
+	return source, err
+}
+
+// GetListSources returns every list source ordered by ID
+func (q *Queries) GetListSources(ctx context.Context) ([]ListSource, error) {
+	rows, err := q.db.QueryContext(ctx, `SELECT `+listSourceColumns+` FROM list_sources ORDER BY id;`)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching list sources: %v", err)
+	}
+	defer rows.Close()
+
+	sources := []ListSource{}
+	for rows.Next() {
+		source, err := scanListSource(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning list source: %v", err)
+		}
+		sources = append(sources, source)
+	}
+
+	return sources, rows.Err()
+}
+
+// GetListSource returns the list source with the given ID
+func (q *Queries) GetListSource(ctx context.Context, id int) (ListSource, error) {
+	source, err := scanListSource(q.db.QueryRowContext(ctx, `SELECT `+listSourceColumns+` FROM list_sources WHERE id = $1;`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return source, ErrNoListSource
+		}
+		return source, fmt.Errorf("error fetching list source: %v", err)
+	}
+
+	return source, nil
+}
+
+// CreateListSource adds a list source and returns its ID
+func (q *Queries) CreateListSource(ctx context.Context, source ListSource) (int, error) {
+	query := "INSERT INTO list_sources (name, media_type, source_type, username, list_slug, cron, `limit`, min_year, max_year, min_runtime, max_runtime, enabled) " +
+		"VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12);"
+
+	result, err := q.db.ExecContext(ctx, query,
+		source.Name, source.MediaType, source.SourceType, source.Username, source.ListSlug, source.Cron, source.Limit,
+		source.MinYear, source.MaxYear, source.MinRuntime, source.MaxRuntime, source.Enabled,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("error inserting list source: %v", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(id), nil
+}
+
+// UpdateListSource replaces the list source with the same ID
+func (q *Queries) UpdateListSource(ctx context.Context, source ListSource) error {
+	query := "UPDATE list_sources " +
+		"SET name = $1, media_type = $2, source_type = $3, username = $4, list_slug = $5, cron = $6, `limit` = $7, " +
+		"min_year = $8, max_year = $9, min_runtime = $10, max_runtime = $11, enabled = $12 " +
+		"WHERE id = $13;"
+
+	result, err := q.db.ExecContext(ctx, query,
+		source.Name, source.MediaType, source.SourceType, source.Username, source.ListSlug, source.Cron, source.Limit,
+		source.MinYear, source.MaxYear, source.MinRuntime, source.MaxRuntime, source.Enabled, source.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("error updating list source: %v", err)
+	}
+
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return ErrNoListSource
+	}
+
+	return nil
+}
+
+// DeleteListSource removes a list source along with its instance assignments, monitor strategy and tags
+func (q *Queries) DeleteListSource(ctx context.Context, id int, jobType string) error {
+	tx, err := q.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM list_sources WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("error deleting list source: %v", err)
+	}
+
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return ErrNoListSource
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM job_instances WHERE job_type = $1`, jobType); err != nil {
+		return fmt.Errorf("error unassigning list source instances: %v", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM job_monitor WHERE job_type = $1`, jobType); err != nil {
+		return fmt.Errorf("error removing list source monitor strategy: %v", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM job_tags WHERE job_type = $1`, jobType); err != nil {
+		return fmt.Errorf("error removing list source tags: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing transaction: %v", err)
+	}
+
+	return nil
+}
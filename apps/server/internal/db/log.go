@@ -10,14 +10,41 @@ import (
 func (q *Queries) InsertLog(ctx context.Context, level structures.LogLevel, label, message string) error {
 	query := `INSERT INTO logs (level, label, message) VALUES ($1, $2, $3)`
 
-	_, err := q.db.ExecContext(ctx, query, level.String(), label, message)
+	res, err := q.db.ExecContext(ctx, query, level.String(), label, message)
 	if err != nil {
 		return fmt.Errorf("error inserting log: %v", err)
 	}
 
+	if len(q.logListeners) == 0 {
+		return nil
+	}
+
+	// Read the row back so listeners get the stored ID and timestamp
+	id, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("error getting inserted log ID: %v", err)
+	}
+
+	var entry structures.Log
+	err = q.db.QueryRowContext(ctx, `SELECT id, level, label, message, timestamp FROM logs WHERE id = $1`, id).
+		Scan(&entry.ID, &entry.Level, &entry.Label, &entry.Message, &entry.Timestamp)
+	if err != nil {
+		return fmt.Errorf("error fetching inserted log: %v", err)
+	}
+
+	for _, listener := range q.logListeners {
+		listener(entry)
+	}
+
 	return nil
 }
 
+// OnLogCreated registers a function to call with every log entry after it is inserted.
+// Listeners must be registered during startup, before any logs are inserted.
+func (q *Queries) OnLogCreated(listener func(structures.Log)) {
+	q.logListeners = append(q.logListeners, listener)
+}
+
 func (q *Queries) GetLogs(ctx context.Context, take, skip int, filter, search string) ([]structures.Log, error) {
 	// Base query
 	query := `SELECT id, level, label, message, timestamp FROM logs WHERE 1=1`
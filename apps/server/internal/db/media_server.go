@@ -0,0 +1,148 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type MediaServerSettings struct {
+	ID         int            `db:"id"`          // Primary key with auto-increment
+	Enabled    bool           `db:"enabled"`     // Whether titles in the media server library are skipped
+	ServerType sql.NullString `db:"server_type"` // Kind of media server (plex, jellyfin, emby)
+	URL        sql.NullString `db:"url"`         // Base URL of the media server
+	APIKey     sql.NullString `db:"api_key"`     // Plex token or Jellyfin/Emby API key
+	Cron       sql.NullString `db:"cron"`        // Cron expression the library cache is refreshed on
+	SyncedAt   sql.NullTime   `db:"synced_at"`   // Time the library cache was last refreshed
+}
+
+type MediaServerItem struct {
+	MediaType string         `db:"media_type"` // Type of media (MOVIE, SHOW)
+	Title     string         `db:"title"`      // Title of the library item
+	Year      sql.NullInt32  `db:"year"`       // Year the library item was released
+	IMDBID    sql.NullString `db:"imdb_id"`    // IMDb ID of the library item
+	TMDBID    sql.NullInt32  `db:"tmdb_id"`    // TMDb ID of the library item
+	TVDBID    sql.NullInt32  `db:"tvdb_id"`    // TVDB ID of the library item
+}
+
+// GetMediaServerSettings returns the settings of the media server
+func (q *Queries) GetMediaServerSettings(ctx context.Context) (MediaServerSettings, error) {
+	var settings MediaServerSettings
+
+	err := q.db.QueryRowContext(ctx, `
+		SELECT id, enabled, server_type, url, api_key, cron, synced_at
+		FROM media_server
+		LIMIT 1;
+	`).Scan(
+		&settings.ID,
+		&settings.Enabled,
+		&settings.ServerType,
+		&settings.URL,
+		&settings.APIKey,
+		&settings.Cron,
+		&settings.SyncedAt,
+	)
+	if err != nil {
+		return settings, fmt.Errorf("error fetching media server settings: %v", err)
+	}
+
+	return settings, nil
+}
+
+// UpdateMediaServerSettings updates the settings of the media server
+func (q *Queries) UpdateMediaServerSettings(ctx context.Context, settings MediaServerSettings) error {
+	apiKey, err := q.encryptNullSecret(settings.APIKey)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE media_server
+		SET enabled = $1, server_type = $2, url = $3, api_key = $4, cron = $5
+		WHERE id = (SELECT MIN(id) FROM media_server);
+	`
+
+	_, err = q.db.ExecContext(ctx, query, settings.Enabled, settings.ServerType, settings.URL, apiKey, settings.Cron)
+	if err != nil {
+		return fmt.Errorf("error updating media server settings: %v", err)
+	}
+
+	return nil
+}
+
+// ReplaceMediaServerLibrary replaces the cached media server library and records when it was refreshed
+func (q *Queries) ReplaceMediaServerLibrary(ctx context.Context, items []MediaServerItem) error {
+	tx, err := q.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM media_server_library`); err != nil {
+		return fmt.Errorf("error clearing media server library: %v", err)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO media_server_library (media_type, title, year, imdb_id, tmdb_id, tvdb_id)
+		VALUES ($1, $2, $3, $4, $5, $6);
+	`)
+	if err != nil {
+		return fmt.Errorf("error preparing media server library insert: %v", err)
+	}
+	defer stmt.Close()
+
+	for _, item := range items {
+		if _, err := stmt.ExecContext(ctx, item.MediaType, item.Title, item.Year, item.IMDBID, item.TMDBID, item.TVDBID); err != nil {
+			return fmt.Errorf("error adding %q to media server library: %v", item.Title, err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE media_server SET synced_at = CURRENT_TIMESTAMP WHERE id = (SELECT MIN(id) FROM media_server)`); err != nil {
+		return fmt.Errorf("error updating media server sync time: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing transaction: %v", err)
+	}
+
+	return nil
+}
+
+// GetMediaServerLibrary returns the cached media server library items of a media type
+func (q *Queries) GetMediaServerLibrary(ctx context.Context, mediaType string) ([]MediaServerItem, error) {
+	rows, err := q.db.QueryContext(ctx, `
+		SELECT media_type, title, year, imdb_id, tmdb_id, tvdb_id
+		FROM media_server_library
+		WHERE media_type = $1;
+	`, mediaType)
+	if err != nil {
+		return nil, fmt.Errorf("error querying media server library: %v", err)
+	}
+	defer rows.Close()
+
+	items := []MediaServerItem{}
+	for rows.Next() {
+		var item MediaServerItem
+		if err := rows.Scan(&item.MediaType, &item.Title, &item.Year, &item.IMDBID, &item.TMDBID, &item.TVDBID); err != nil {
+			return nil, fmt.Errorf("error scanning media server library item: %v", err)
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+// CountMediaServerLibrary returns the number of movies and shows in the cached media server library
+func (q *Queries) CountMediaServerLibrary(ctx context.Context) (movies int, shows int, err error) {
+	err = q.db.QueryRowContext(ctx, `
+		SELECT
+			COUNT(CASE WHEN media_type = 'MOVIE' THEN 1 END),
+			COUNT(CASE WHEN media_type = 'SHOW' THEN 1 END)
+		FROM media_server_library;
+	`).Scan(&movies, &shows)
+	if err != nil {
+		return 0, 0, fmt.Errorf("error counting media server library: %v", err)
+	}
+
+	return movies, shows, nil
+}
@@ -0,0 +1,9 @@
+package db
+
+import "embed"
+
+// Migrations holds the numbered SQL migration files (e.g. 0001_initial_schema.sql)
+// that are applied in order when the server starts
+//
+//go:embed migrations/*.sql
+var Migrations embed.FS
@@ -0,0 +1,32 @@
+-- Table to keep track of every candidate the scheduler considered and what happened to it
+CREATE TABLE `request_history` (
+    `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+    -- Primary key with auto-increment
+    `run_id` TEXT NOT NULL,
+    -- ID of the job run that considered the candidate
+    `media_type` TEXT CHECK(media_type IN ('MOVIE', 'SHOW')),
+    -- Type of media (MOVIE, SHOW)
+    `title` TEXT NOT NULL,
+    -- Title of the candidate
+    `year` INTEGER,
+    -- Year the candidate was released
+    `tmdb_id` INTEGER,
+    -- TMDb ID of the candidate (nullable)
+    `tvdb_id` INTEGER,
+    -- TVDB ID of the candidate (nullable)
+    `imdb_id` TEXT,
+    -- IMDb ID of the candidate (nullable)
+    `source` TEXT NOT NULL,
+    -- List the candidate came from (e.g., 'movie-trending', 'show-popular')
+    `backend` TEXT NOT NULL,
+    -- Where the candidate was requested (e.g., 'radarr', 'sonarr', 'ombi')
+    `outcome` TEXT NOT NULL,
+    -- What happened to the candidate (e.g., 'added', 'exists', 'failed')
+    `error` TEXT,
+    -- Error text if the request failed (nullable)
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX `idx_request_history_run_id` ON `request_history` (`run_id`);
+
+CREATE INDEX `idx_request_history_created_at` ON `request_history` (`created_at`);
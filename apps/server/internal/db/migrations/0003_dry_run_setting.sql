@@ -0,0 +1,5 @@
+-- When enabled, scheduled jobs log and record what they would request instead of calling Radarr, Sonarr or Ombi
+INSERT
+    OR IGNORE INTO settings (key, value, type)
+VALUES
+    ('DRY_RUN', 'false', 'boolean');
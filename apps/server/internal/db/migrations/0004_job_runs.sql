@@ -0,0 +1,23 @@
+-- Table to keep track of every execution of a list job
+CREATE TABLE `job_runs` (
+    `id` TEXT PRIMARY KEY,
+    -- Unique ID of the run, shared with the request history entries it recorded
+    `job_type` TEXT NOT NULL,
+    -- List the job ran for (e.g., 'movie-trending', 'show-popular')
+    `status` TEXT NOT NULL DEFAULT 'running' CHECK(status IN ('running', 'completed', 'failed')),
+    -- Current status of the run
+    `added` INTEGER NOT NULL DEFAULT 0,
+    -- Number of items that were added/requested
+    `skipped` INTEGER NOT NULL DEFAULT 0,
+    -- Number of items that were filtered out, already existed or only reported in dry-run mode
+    `failed` INTEGER NOT NULL DEFAULT 0,
+    -- Number of items whose request failed
+    `error` TEXT,
+    -- Why the run failed (nullable)
+    `started_at` DATETIME NOT NULL,
+    -- Time the run started
+    `finished_at` DATETIME
+    -- Time the run finished (nullable while running)
+);
+
+CREATE INDEX `idx_job_runs_job_type` ON `job_runs` (`job_type`);
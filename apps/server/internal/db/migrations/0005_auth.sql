@@ -0,0 +1,25 @@
+-- Table for the users that can sign in to the UI and API
+CREATE TABLE `users` (
+    `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+    `username` TEXT UNIQUE NOT NULL COLLATE NOCASE,
+    -- Name the user signs in with
+    `password_hash` TEXT NOT NULL,
+    -- bcrypt hash of the password
+    `api_key_hash` TEXT UNIQUE,
+    -- SHA-256 hash of the user's API key (nullable until one is generated)
+    `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Table for the signed in UI sessions
+CREATE TABLE `sessions` (
+    `token_hash` TEXT PRIMARY KEY,
+    -- SHA-256 hash of the token stored in the session cookie
+    `user_id` INTEGER NOT NULL,
+    -- User the session belongs to
+    `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `expires_at` DATETIME NOT NULL,
+    -- Time after which the session is no longer accepted
+    FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+);
+
+CREATE INDEX `idx_sessions_user_id` ON `sessions` (`user_id`);
@@ -0,0 +1,15 @@
+-- Radarr and Sonarr can have more than one instance (e.g., a 1080p and a 4K Radarr)
+ALTER TABLE radarr ADD COLUMN `name` TEXT NOT NULL DEFAULT 'Radarr';
+-- Name the instance is shown with
+
+ALTER TABLE sonarr ADD COLUMN `name` TEXT NOT NULL DEFAULT 'Sonarr';
+-- Name the instance is shown with
+
+-- Table for the Radarr/Sonarr instances each list job sends to. A job without any rows sends to every instance.
+CREATE TABLE `job_instances` (
+    `job_type` TEXT NOT NULL,
+    -- List job the instance is assigned to (e.g., 'movie-trending', 'show-popular')
+    `instance_id` INTEGER NOT NULL,
+    -- ID of the Radarr instance for movie jobs or the Sonarr instance for show jobs
+    PRIMARY KEY (`job_type`, `instance_id`)
+);
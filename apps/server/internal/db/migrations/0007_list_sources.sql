@@ -0,0 +1,29 @@
+-- Table for the user-defined Trakt lists the scheduler requests from, next to the built-in movie/show lists
+CREATE TABLE `list_sources` (
+    `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+    `name` TEXT NOT NULL,
+    -- Name the source is shown with
+    `media_type` TEXT NOT NULL CHECK(media_type IN ('movie', 'show')),
+    -- Whether the source requests movies from Radarr or shows from Sonarr
+    `source_type` TEXT NOT NULL CHECK(source_type IN ('list', 'watchlist', 'recommendations')),
+    -- Where the items come from: a public list, a user's watchlist or the recommendations
+    `username` TEXT,
+    -- Trakt user the list or watchlist belongs to (nullable for recommendations)
+    `list_slug` TEXT,
+    -- Slug or Trakt ID of the list (nullable unless source_type is 'list')
+    `cron` TEXT,
+    -- Cron expression the source is requested on, the source is only run manually without one (nullable)
+    `limit` INTEGER NOT NULL DEFAULT 10,
+    -- How many items are requested every run
+    `min_year` INTEGER,
+    -- Skip items released before the specified year (nullable)
+    `max_year` INTEGER,
+    -- Skip items released after the specified year (nullable)
+    `min_runtime` INTEGER,
+    -- Skip items with a runtime shorter than the specified time in minutes (nullable)
+    `max_runtime` INTEGER,
+    -- Skip items with a runtime longer than the specified time in minutes (nullable)
+    `enabled` BOOLEAN NOT NULL DEFAULT 1,
+    -- Whether the source is scheduled
+    `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
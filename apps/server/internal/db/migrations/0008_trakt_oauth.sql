@@ -0,0 +1,15 @@
+-- Tokens from the Trakt device-code OAuth flow, used for private lists, watchlists and recommendations
+ALTER TABLE trakt ADD COLUMN `access_token` TEXT;
+-- Access token sent as a bearer token (nullable until an account is authorized)
+
+ALTER TABLE trakt ADD COLUMN `refresh_token` TEXT;
+-- Token used to get a new access token before it expires (nullable)
+
+ALTER TABLE trakt ADD COLUMN `token_expires_at` DATETIME;
+-- Time the access token expires (nullable)
+
+-- When enabled and a Trakt account is authorized, titles the account already watched aren't requested
+INSERT
+    OR IGNORE INTO settings (key, value, type)
+VALUES
+    ('TRAKT_SKIP_WATCHED', 'false', 'boolean');
@@ -0,0 +1,42 @@
+-- Most watched, most played and most collected lists over a period, for movies and shows
+ALTER TABLE movie_settings ADD COLUMN `watched` INTEGER;
+-- How many movies will be pulled from the most watched list
+ALTER TABLE movie_settings ADD COLUMN `watched_period` TEXT NOT NULL DEFAULT 'weekly';
+-- Period of the most watched list ('weekly', 'monthly', 'yearly' or 'all')
+ALTER TABLE movie_settings ADD COLUMN `cron_job_watched` TEXT;
+-- Cron job expression for the most watched list
+
+ALTER TABLE movie_settings ADD COLUMN `played` INTEGER;
+-- How many movies will be pulled from the most played list
+ALTER TABLE movie_settings ADD COLUMN `played_period` TEXT NOT NULL DEFAULT 'weekly';
+-- Period of the most played list ('weekly', 'monthly', 'yearly' or 'all')
+ALTER TABLE movie_settings ADD COLUMN `cron_job_played` TEXT;
+-- Cron job expression for the most played list
+
+ALTER TABLE movie_settings ADD COLUMN `collected` INTEGER;
+-- How many movies will be pulled from the most collected list
+ALTER TABLE movie_settings ADD COLUMN `collected_period` TEXT NOT NULL DEFAULT 'weekly';
+-- Period of the most collected list ('weekly', 'monthly', 'yearly' or 'all')
+ALTER TABLE movie_settings ADD COLUMN `cron_job_collected` TEXT;
+-- Cron job expression for the most collected list
+
+ALTER TABLE show_settings ADD COLUMN `watched` INTEGER;
+-- How many shows will be pulled from the most watched list
+ALTER TABLE show_settings ADD COLUMN `watched_period` TEXT NOT NULL DEFAULT 'weekly';
+-- Period of the most watched list ('weekly', 'monthly', 'yearly' or 'all')
+ALTER TABLE show_settings ADD COLUMN `cron_job_watched` TEXT;
+-- Cron job expression for the most watched list
+
+ALTER TABLE show_settings ADD COLUMN `played` INTEGER;
+-- How many shows will be pulled from the most played list
+ALTER TABLE show_settings ADD COLUMN `played_period` TEXT NOT NULL DEFAULT 'weekly';
+-- Period of the most played list ('weekly', 'monthly', 'yearly' or 'all')
+ALTER TABLE show_settings ADD COLUMN `cron_job_played` TEXT;
+-- Cron job expression for the most played list
+
+ALTER TABLE show_settings ADD COLUMN `collected` INTEGER;
+-- How many shows will be pulled from the most collected list
+ALTER TABLE show_settings ADD COLUMN `collected_period` TEXT NOT NULL DEFAULT 'weekly';
+-- Period of the most collected list ('weekly', 'monthly', 'yearly' or 'all')
+ALTER TABLE show_settings ADD COLUMN `cron_job_collected` TEXT;
+-- Cron job expression for the most collected list
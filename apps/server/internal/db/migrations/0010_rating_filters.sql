@@ -0,0 +1,28 @@
+-- Rating, vote and certification filters for the candidates of the movie and show lists
+ALTER TABLE movie_settings ADD COLUMN `min_rating` REAL;
+-- Skip movies with a lower Trakt rating (0-10)
+ALTER TABLE movie_settings ADD COLUMN `min_votes` INTEGER;
+-- Skip movies with fewer Trakt votes
+ALTER TABLE movie_settings ADD COLUMN `min_imdb_rating` REAL;
+-- Skip movies with a lower IMDb rating on OMDb (0-10)
+ALTER TABLE movie_settings ADD COLUMN `min_metacritic` INTEGER;
+-- Skip movies with a lower Metacritic score on OMDb (0-100), rotten_tomatoes holds the Rotten Tomatoes threshold
+ALTER TABLE movie_settings ADD COLUMN `allowed_certifications` TEXT;
+-- Comma-separated certifications a movie must have one of (e.g., 'g,pg,pg-13')
+ALTER TABLE movie_settings ADD COLUMN `blocked_certifications` TEXT;
+-- Comma-separated certifications to skip (e.g., 'nc-17')
+
+ALTER TABLE show_settings ADD COLUMN `min_rating` REAL;
+-- Skip shows with a lower Trakt rating (0-10)
+ALTER TABLE show_settings ADD COLUMN `min_votes` INTEGER;
+-- Skip shows with fewer Trakt votes
+ALTER TABLE show_settings ADD COLUMN `min_imdb_rating` REAL;
+-- Skip shows with a lower IMDb rating on OMDb (0-10)
+ALTER TABLE show_settings ADD COLUMN `rotten_tomatoes` TEXT;
+-- Skip shows with a lower Rotten Tomatoes score on OMDb (0-100)
+ALTER TABLE show_settings ADD COLUMN `min_metacritic` INTEGER;
+-- Skip shows with a lower Metacritic score on OMDb (0-100)
+ALTER TABLE show_settings ADD COLUMN `allowed_certifications` TEXT;
+-- Comma-separated certifications a show must have one of (e.g., 'tv-pg,tv-14')
+ALTER TABLE show_settings ADD COLUMN `blocked_certifications` TEXT;
+-- Comma-separated certifications to skip (e.g., 'tv-ma')
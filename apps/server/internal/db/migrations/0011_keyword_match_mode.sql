@@ -0,0 +1,6 @@
+-- Blacklisted title keywords can match anywhere in the title, only as a whole word, or as a regular expression
+ALTER TABLE movie_blacklisted_title_keywords ADD COLUMN `match_mode` TEXT NOT NULL DEFAULT 'substring' CHECK(match_mode IN ('substring', 'word', 'regex'));
+-- How the keyword is matched against the title: 'substring', 'word' or 'regex' (RE2 syntax)
+
+ALTER TABLE show_blacklisted_title_keywords ADD COLUMN `match_mode` TEXT NOT NULL DEFAULT 'substring' CHECK(match_mode IN ('substring', 'word', 'regex'));
+-- How the keyword is matched against the title: 'substring', 'word' or 'regex' (RE2 syntax)
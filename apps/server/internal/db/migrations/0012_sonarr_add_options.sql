@@ -0,0 +1,9 @@
+-- Options shows are added to Sonarr with
+ALTER TABLE show_settings ADD COLUMN `series_type` TEXT NOT NULL DEFAULT 'standard' CHECK(`series_type` IN ('standard', 'anime', 'daily'));
+-- Series type Sonarr names and searches the episodes by
+ALTER TABLE show_settings ADD COLUMN `season_folder` INTEGER NOT NULL DEFAULT 1;
+-- Whether episodes are sorted into season folders, a Sonarr instance with its own season folder setting overrides it
+ALTER TABLE show_settings ADD COLUMN `monitor` TEXT NOT NULL DEFAULT 'all' CHECK(`monitor` IN ('all', 'future', 'firstSeason', 'latestSeason', 'pilot', 'none'));
+-- Episodes Sonarr monitors when the series is added
+ALTER TABLE show_settings ADD COLUMN `search_on_add` INTEGER NOT NULL DEFAULT 1;
+-- Whether Sonarr searches for the monitored episodes right after the series is added
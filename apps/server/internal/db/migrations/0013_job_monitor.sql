@@ -0,0 +1,7 @@
+-- Table for the Sonarr monitor strategy of a show list job. A job without a row uses the monitor option of the show settings.
+CREATE TABLE `job_monitor` (
+    `job_type` TEXT PRIMARY KEY,
+    -- Show list job the strategy belongs to (e.g., 'show-trending', 'list-3')
+    `monitor` TEXT NOT NULL CHECK(`monitor` IN ('all', 'future', 'firstSeason', 'latestSeason', 'pilot', 'none'))
+    -- Episodes Sonarr monitors when the job adds a series, Ombi requests are mapped to the closest seasons
+);
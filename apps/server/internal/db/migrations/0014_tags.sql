@@ -0,0 +1,26 @@
+-- When enabled, titles are added to Radarr and Sonarr with the tags below so they can be told apart from the rest of the library
+INSERT
+    OR IGNORE INTO settings (key, value, type)
+VALUES
+    ('TAGS_ENABLED', 'true', 'boolean');
+
+-- Comma-separated tags every title is added with
+INSERT
+    OR IGNORE INTO settings (key, value, type)
+VALUES
+    ('TAGS', 'blockbusterr', 'text');
+
+-- When enabled, titles are also tagged with the list they came from (e.g., 'trakt-trending'), unless the job has its own tags
+INSERT
+    OR IGNORE INTO settings (key, value, type)
+VALUES
+    ('SOURCE_TAGS', 'true', 'boolean');
+
+-- Table for the tags a list job adds titles with in place of the tag of its source
+CREATE TABLE `job_tags` (
+    `job_type` TEXT NOT NULL,
+    -- List job the tag belongs to (e.g., 'movie-trending', 'list-3')
+    `tag` TEXT NOT NULL,
+    -- Radarr/Sonarr tag label
+    PRIMARY KEY (`job_type`, `tag`)
+);
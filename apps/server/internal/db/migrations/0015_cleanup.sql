@@ -0,0 +1,33 @@
+-- Table for the cleanup job, which removes titles blockbusterr added that were never downloaded or watched
+CREATE TABLE `cleanup_settings` (
+    `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+    -- Primary key with auto-increment
+    `cron` TEXT,
+    -- Cron expression the cleanup job runs on, the job isn't scheduled without one
+    `min_age_days` INTEGER NOT NULL DEFAULT 30,
+    -- Titles are only cleaned up once they were added at least this many days ago
+    `action` TEXT NOT NULL DEFAULT 'unmonitor' CHECK(action IN ('unmonitor', 'delete')),
+    -- Whether titles are unmonitored or deleted from Radarr/Sonarr
+    `delete_files` BOOLEAN NOT NULL DEFAULT 0
+    -- Whether deleting a title also deletes its files
+);
+
+INSERT INTO
+    cleanup_settings (cron)
+VALUES
+    (NULL);
+
+-- Table for the titles the cleanup job never touches
+CREATE TABLE `cleanup_exclusions` (
+    `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+    -- Primary key with auto-increment
+    `media_type` TEXT NOT NULL CHECK(media_type IN ('MOVIE', 'SHOW')),
+    -- Type of media (MOVIE, SHOW)
+    `media_id` INTEGER NOT NULL,
+    -- TMDb ID of a movie or TVDB ID of a show
+    `title` TEXT,
+    -- Title of the excluded media (nullable)
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    -- Time the exclusion was added
+    UNIQUE (`media_type`, `media_id`)
+);
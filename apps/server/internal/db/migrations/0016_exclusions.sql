@@ -0,0 +1,11 @@
+-- When enabled, titles on the Radarr exclusion list or Sonarr import list exclusions are treated as blacklisted
+INSERT
+    OR IGNORE INTO settings (key, value, type)
+VALUES
+    ('RESPECT_EXCLUSIONS', 'true', 'boolean');
+
+-- When enabled, the blacklisted TMDb and TVDB IDs are added to the Radarr and Sonarr exclusion lists
+INSERT
+    OR IGNORE INTO settings (key, value, type)
+VALUES
+    ('PUSH_EXCLUSIONS', 'false', 'boolean');
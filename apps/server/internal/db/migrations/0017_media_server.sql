@@ -0,0 +1,45 @@
+-- Table for the media server whose library is checked before titles are requested
+CREATE TABLE `media_server` (
+    `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+    -- Primary key with auto-increment
+    `enabled` BOOLEAN NOT NULL DEFAULT 0,
+    -- Whether titles in the media server library are skipped
+    `server_type` TEXT CHECK(server_type IN ('plex', 'jellyfin', 'emby')),
+    -- Kind of media server (plex, jellyfin, emby)
+    `url` TEXT,
+    -- Base URL of the media server
+    `api_key` TEXT,
+    -- Plex token or Jellyfin/Emby API key
+    `cron` TEXT,
+    -- Cron expression the library cache is refreshed on
+    `synced_at` DATETIME
+    -- Time the library cache was last refreshed (nullable)
+);
+
+INSERT INTO
+    media_server (cron)
+VALUES
+    ('0 */6 * * *');
+
+-- Table caching the movies and shows in the media server library
+CREATE TABLE `media_server_library` (
+    `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+    -- Primary key with auto-increment
+    `media_type` TEXT NOT NULL CHECK(media_type IN ('MOVIE', 'SHOW')),
+    -- Type of media (MOVIE, SHOW)
+    `title` TEXT NOT NULL,
+    -- Title of the library item
+    `year` INTEGER,
+    -- Year the library item was released (nullable)
+    `imdb_id` TEXT,
+    -- IMDb ID of the library item (nullable)
+    `tmdb_id` INTEGER,
+    -- TMDb ID of the library item (nullable)
+    `tvdb_id` INTEGER
+    -- TVDB ID of the library item (nullable)
+);
+
+CREATE INDEX `idx_media_server_library_media_type` ON `media_server_library` (`media_type`);
+
+-- Number of items of a job run that were skipped because they're already in the media server library
+ALTER TABLE `job_runs` ADD COLUMN `owned` INTEGER NOT NULL DEFAULT 0;
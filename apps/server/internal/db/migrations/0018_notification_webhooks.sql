@@ -0,0 +1,21 @@
+-- Table for generic HTTP webhooks every notification is sent to
+CREATE TABLE `notification_webhooks` (
+    `id` INTEGER PRIMARY KEY AUTOINCREMENT,
+    -- Primary key with auto-increment
+    `name` TEXT NOT NULL,
+    -- Name the webhook is shown with
+    `enabled` BOOLEAN NOT NULL DEFAULT 1,
+    -- Whether notifications are sent to the webhook
+    `url` TEXT NOT NULL,
+    -- URL the request is sent to
+    `method` TEXT NOT NULL DEFAULT 'POST' CHECK(method IN ('GET', 'POST', 'PUT', 'PATCH')),
+    -- HTTP method of the request
+    `headers` TEXT,
+    -- JSON object of extra request headers, stored encrypted since it usually holds a token (nullable)
+    `body_template` TEXT,
+    -- Go text/template the request body is rendered from, the default JSON body is used when empty (nullable)
+    `max_retries` INTEGER NOT NULL DEFAULT 3,
+    -- How many times a failed request is retried
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP
+    -- Time the webhook was added
+);
@@ -0,0 +1,2 @@
+-- Why the candidate was skipped, or per-instance details of the outcome (nullable)
+ALTER TABLE `request_history` ADD COLUMN `reason` TEXT;
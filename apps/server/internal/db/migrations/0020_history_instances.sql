@@ -0,0 +1,36 @@
+-- Table for the Radarr/Sonarr instances a title was added to, so the cleanup job only touches those instances
+CREATE TABLE `request_history_instances` (
+    `history_id` INTEGER NOT NULL,
+    -- ID of the request history entry the title was recorded with
+    `instance_id` INTEGER NOT NULL,
+    -- ID of the Radarr instance for movies or the Sonarr instance for shows
+    PRIMARY KEY (`history_id`, `instance_id`)
+);
+
+-- Titles added before the instances were recorded can only be attributed when there is a single instance,
+-- with more than one the cleanup job leaves them alone
+INSERT
+    OR IGNORE INTO request_history_instances (history_id, instance_id)
+SELECT
+    request_history.id,
+    radarr.id
+FROM
+    request_history,
+    radarr
+WHERE
+    request_history.backend = 'radarr'
+    AND request_history.outcome = 'added'
+    AND (SELECT COUNT(*) FROM radarr) = 1;
+
+INSERT
+    OR IGNORE INTO request_history_instances (history_id, instance_id)
+SELECT
+    request_history.id,
+    sonarr.id
+FROM
+    request_history,
+    sonarr
+WHERE
+    request_history.backend = 'sonarr'
+    AND request_history.outcome = 'added'
+    AND (SELECT COUNT(*) FROM sonarr) = 1;
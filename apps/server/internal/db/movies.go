@@ -17,7 +17,15 @@ type MovieSettings struct {
 	MinRuntime     sql.NullInt32  `db:"min_runtime"`     // Blacklist movies with runtime shorter than the specified time (in minutes)
 	MinYear        sql.NullInt32  `db:"min_year"`        // Blacklist movies released before the specified year. If left empty/is zero, it'll ignore the year.
 	MaxYear        sql.NullInt32  `db:"max_year"`        // Blacklist movies released after the specified year. If left empty/is zero, it'll be the current year
-	RottenTomatoes sql.NullString `db:"rotten_tomatoes"` // Rotten Tomatoes rating filter for movies
+	RottenTomatoes sql.NullString `db:"rotten_tomatoes"` // Minimum Rotten Tomatoes score on OMDb (0-100)
+
+	// Rating, vote and certification filters
+	MinRating             sql.NullFloat64 `db:"min_rating"`             // Minimum Trakt rating (0-10)
+	MinVotes              sql.NullInt32   `db:"min_votes"`              // Minimum number of Trakt votes
+	MinIMDbRating         sql.NullFloat64 `db:"min_imdb_rating"`        // Minimum IMDb rating on OMDb (0-10)
+	MinMetacritic         sql.NullInt32   `db:"min_metacritic"`         // Minimum Metacritic score on OMDb (0-100)
+	AllowedCertifications sql.NullString  `db:"allowed_certifications"` // Comma-separated certifications a movie must have one of
+	BlockedCertifications sql.NullString  `db:"blocked_certifications"` // Comma-separated certifications to skip
 
 	// Cron fields for individual list scheduling
 	CronAnticipated sql.NullString `db:"cron_job_anticipated"` // Cron expression for the anticipated list
@@ -25,6 +33,17 @@ type MovieSettings struct {
 	CronPopular     sql.NullString `db:"cron_job_popular"`     // Cron expression for the popular list
 	CronTrending    sql.NullString `db:"cron_job_trending"`    // Cron expression for the trending list
 
+	// Most watched, most played and most collected lists over a period
+	Watched         sql.NullInt32  `db:"watched"`            // How many movies will be grabbed from the most watched list
+	WatchedPeriod   string         `db:"watched_period"`     // Period of the most watched list ("weekly", "monthly", "yearly" or "all")
+	CronWatched     sql.NullString `db:"cron_job_watched"`   // Cron expression for the most watched list
+	Played          sql.NullInt32  `db:"played"`             // How many movies will be grabbed from the most played list
+	PlayedPeriod    string         `db:"played_period"`      // Period of the most played list
+	CronPlayed      sql.NullString `db:"cron_job_played"`    // Cron expression for the most played list
+	Collected       sql.NullInt32  `db:"collected"`          // How many movies will be grabbed from the most collected list
+	CollectedPeriod string         `db:"collected_period"`   // Period of the most collected list
+	CronCollected   sql.NullString `db:"cron_job_collected"` // Cron expression for the most collected list
+
 	AllowedCountries         []MovieAllowedCountries    // List of allowed countries
 	AllowedLanguages         []MovieAllowedLanguages    // List of allowed languages
 	BlacklistedGenres        []BlacklistedGenres        // List of blacklisted genres
@@ -54,6 +73,7 @@ type BlacklistedTitleKeywords struct {
 	ID              int    `db:"id"`                // Primary key with auto-increment
 	MovieSettingsID int    `db:"movie_settings_id"` // Foreign key to the movie settings table
 	Keyword         string `db:"keyword"`           // Keyword to blacklist from the title of a movie
+	MatchMode       string `db:"match_mode"`        // How the keyword is matched: "substring", "word" or "regex"
 }
 
 type BlacklistedTMDBIDs struct {
@@ -76,7 +96,10 @@ func (q *Queries) GetMovieSettings(ctx context.Context) (MovieSettings, error) {
 	err = tx.QueryRowContext(ctx, `
 		SELECT id, anticipated, box_office, popular, trending,
 		       max_runtime, min_runtime, min_year, max_year, rotten_tomatoes,
-		       cron_job_anticipated, cron_job_box_office, cron_job_popular, cron_job_trending
+		       cron_job_anticipated, cron_job_box_office, cron_job_popular, cron_job_trending,
+		       watched, watched_period, cron_job_watched, played, played_period, cron_job_played,
+		       collected, collected_period, cron_job_collected,
+		       min_rating, min_votes, min_imdb_rating, min_metacritic, allowed_certifications, blocked_certifications
 		FROM movie_settings
 		LIMIT 1;
 	`).Scan(
@@ -94,6 +117,21 @@ func (q *Queries) GetMovieSettings(ctx context.Context) (MovieSettings, error) {
 		&settings.CronBoxOffice,
 		&settings.CronPopular,
 		&settings.CronTrending,
+		&settings.Watched,
+		&settings.WatchedPeriod,
+		&settings.CronWatched,
+		&settings.Played,
+		&settings.PlayedPeriod,
+		&settings.CronPlayed,
+		&settings.Collected,
+		&settings.CollectedPeriod,
+		&settings.CronCollected,
+		&settings.MinRating,
+		&settings.MinVotes,
+		&settings.MinIMDbRating,
+		&settings.MinMetacritic,
+		&settings.AllowedCertifications,
+		&settings.BlockedCertifications,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -164,7 +202,7 @@ func (q *Queries) GetMovieSettings(ctx context.Context) (MovieSettings, error) {
 
 	// Fetch blacklisted title keywords
 	keywordQuery := `
-		SELECT id, movie_settings_id, keyword
+		SELECT id, movie_settings_id, keyword, match_mode
 		FROM movie_blacklisted_title_keywords
 		WHERE movie_settings_id = ?;
 	`
@@ -176,7 +214,7 @@ func (q *Queries) GetMovieSettings(ctx context.Context) (MovieSettings, error) {
 
 	for keywordRows.Next() {
 		var blacklistedKeyword BlacklistedTitleKeywords
-		if err := keywordRows.Scan(&blacklistedKeyword.ID, &blacklistedKeyword.MovieSettingsID, &blacklistedKeyword.Keyword); err != nil {
+		if err := keywordRows.Scan(&blacklistedKeyword.ID, &blacklistedKeyword.MovieSettingsID, &blacklistedKeyword.Keyword, &blacklistedKeyword.MatchMode); err != nil {
 			return settings, err
 		}
 		settings.BlacklistedTitleKeywords = append(settings.BlacklistedTitleKeywords, blacklistedKeyword)
@@ -210,7 +248,8 @@ func (q *Queries) GetMovieSettings(ctx context.Context) (MovieSettings, error) {
 	return settings, nil
 }
 
-func (q *Queries) UpdateMovieSettings(ctx context.Context, anticipated, boxOffice, popular, trending, maxRuntime, minRuntime, minYear, maxYear sql.NullInt32, rottenTomatoes sql.NullString, cronAnticipated, cronBoxOffice, cronPopular, cronTrending sql.NullString) error {
+// UpdateMovieSettings updates the list and filter columns of the movie settings. The allowed and blacklisted entries are left alone.
+func (q *Queries) UpdateMovieSettings(ctx context.Context, settings MovieSettings) error {
 	tx, err := q.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
@@ -221,9 +260,21 @@ func (q *Queries) UpdateMovieSettings(ctx context.Context, anticipated, boxOffic
 		UPDATE movie_settings
 		SET anticipated = $1, box_office = $2, popular = $3, trending = $4,
 		    max_runtime = $5, min_runtime = $6, min_year = $7, max_year = $8, rotten_tomatoes = $9,
-		    cron_job_anticipated = $10, cron_job_box_office = $11, cron_job_popular = $12, cron_job_trending = $13
+		    cron_job_anticipated = $10, cron_job_box_office = $11, cron_job_popular = $12, cron_job_trending = $13,
+		    watched = $14, watched_period = $15, cron_job_watched = $16,
+		    played = $17, played_period = $18, cron_job_played = $19,
+		    collected = $20, collected_period = $21, cron_job_collected = $22,
+		    min_rating = $23, min_votes = $24, min_imdb_rating = $25, min_metacritic = $26,
+		    allowed_certifications = $27, blocked_certifications = $28
 		WHERE id = 1;
-	`, anticipated, boxOffice, popular, trending, maxRuntime, minRuntime, minYear, maxYear, rottenTomatoes, cronAnticipated, cronBoxOffice, cronPopular, cronTrending)
+	`, settings.Anticipated, settings.BoxOffice, settings.Popular, settings.Trending,
+		settings.MaxRuntime, settings.MinRuntime, settings.MinYear, settings.MaxYear, settings.RottenTomatoes,
+		settings.CronAnticipated, settings.CronBoxOffice, settings.CronPopular, settings.CronTrending,
+		settings.Watched, settings.WatchedPeriod, settings.CronWatched,
+		settings.Played, settings.PlayedPeriod, settings.CronPlayed,
+		settings.Collected, settings.CollectedPeriod, settings.CronCollected,
+		settings.MinRating, settings.MinVotes, settings.MinIMDbRating, settings.MinMetacritic,
+		settings.AllowedCertifications, settings.BlockedCertifications)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,141 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type NotificationWebhook struct {
+	ID           int            `db:"id"`            // Primary key with auto-increment
+	Name         string         `db:"name"`          // Name the webhook is shown with
+	Enabled      bool           `db:"enabled"`       // Whether notifications are sent to the webhook
+	URL          string         `db:"url"`           // URL the request is sent to
+	Method       string         `db:"method"`        // HTTP method of the request (GET, POST, PUT, PATCH)
+	Headers      sql.NullString `db:"headers"`       // JSON object of extra request headers
+	BodyTemplate sql.NullString `db:"body_template"` // Go text/template the request body is rendered from
+	MaxRetries   int            `db:"max_retries"`   // How many times a failed request is retried
+	CreatedAt    time.Time      `db:"created_at"`    // Time the webhook was added
+}
+
+var ErrNoNotificationWebhook = fmt.Errorf("no notification webhook found")
+
+const notificationWebhookColumns = `id, name, enabled, url, method, headers, body_template, max_retries, created_at`
+
+func scanNotificationWebhook(row interface{ Scan(...any) error }) (NotificationWebhook, error) {
+	var webhook NotificationWebhook
+	err := row.Scan(
+		&webhook.ID,
+		&webhook.Name,
+		&webhook.Enabled,
+		&webhook.URL,
+		&webhook.Method,
+		&webhook.Headers,
+		&webhook.BodyTemplate,
+		&webhook.MaxRetries,
+		&webhook.CreatedAt,
+	)
+	return webhook, err
+}
+
+// GetNotificationWebhooks returns every notification webhook ordered by ID
+func (q *Queries) GetNotificationWebhooks(ctx context.Context) ([]NotificationWebhook, error) {
+	rows, err := q.db.QueryContext(ctx, `SELECT `+notificationWebhookColumns+` FROM notification_webhooks ORDER BY id;`)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching notification webhooks: %v", err)
+	}
+	defer rows.Close()
+
+	webhooks := []NotificationWebhook{}
+	for rows.Next() {
+		webhook, err := scanNotificationWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning notification webhook: %v", err)
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	return webhooks, rows.Err()
+}
+
+// GetNotificationWebhook returns the notification webhook with the given ID
+func (q *Queries) GetNotificationWebhook(ctx context.Context, id int) (NotificationWebhook, error) {
+	webhook, err := scanNotificationWebhook(q.db.QueryRowContext(ctx, `SELECT `+notificationWebhookColumns+` FROM notification_webhooks WHERE id = $1;`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return webhook, ErrNoNotificationWebhook
+		}
+		return webhook, fmt.Errorf("error fetching notification webhook: %v", err)
+	}
+
+	return webhook, nil
+}
+
+// CreateNotificationWebhook adds a notification webhook and returns its ID
+func (q *Queries) CreateNotificationWebhook(ctx context.Context, webhook NotificationWebhook) (int, error) {
+	headers, err := q.encryptNullSecret(webhook.Headers)
+	if err != nil {
+		return 0, err
+	}
+
+	query := `
+		INSERT INTO notification_webhooks (name, enabled, url, method, headers, body_template, max_retries)
+		VALUES ($1, $2, $3, $4, $5, $6, $7);
+	`
+
+	result, err := q.db.ExecContext(ctx, query,
+		webhook.Name, webhook.Enabled, webhook.URL, webhook.Method, headers, webhook.BodyTemplate, webhook.MaxRetries,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("error inserting notification webhook: %v", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(id), nil
+}
+
+// UpdateNotificationWebhook replaces the notification webhook with the same ID
+func (q *Queries) UpdateNotificationWebhook(ctx context.Context, webhook NotificationWebhook) error {
+	headers, err := q.encryptNullSecret(webhook.Headers)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE notification_webhooks
+		SET name = $1, enabled = $2, url = $3, method = $4, headers = $5, body_template = $6, max_retries = $7
+		WHERE id = $8;
+	`
+
+	result, err := q.db.ExecContext(ctx, query,
+		webhook.Name, webhook.Enabled, webhook.URL, webhook.Method, headers, webhook.BodyTemplate, webhook.MaxRetries, webhook.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("error updating notification webhook: %v", err)
+	}
+
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return ErrNoNotificationWebhook
+	}
+
+	return nil
+}
+
+// DeleteNotificationWebhook removes the notification webhook with the given ID
+func (q *Queries) DeleteNotificationWebhook(ctx context.Context, id int) error {
+	result, err := q.db.ExecContext(ctx, `DELETE FROM notification_webhooks WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("error deleting notification webhook: %v", err)
+	}
+
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return ErrNoNotificationWebhook
+	}
+
+	return nil
+}
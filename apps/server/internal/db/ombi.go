@@ -52,3 +52,42 @@ func (q *Queries) GetOmbiSettings(ctx context.Context) (OmbiSettings, error) {
 
 	return settings, nil
 }
+
+func (q *Queries) UpdateOmbiSettings(ctx context.Context, apiKey, url, userID, language sql.NullString, movieQuality, movieRootFolder, showQuality, showRootFolder sql.NullInt32) error {
+	apiKey, err := q.encryptNullSecret(apiKey)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE ombi
+		SET api_key = $1, url = $2, user_id = $3, language = $4, movie_quality = $5, movie_root_folder = $6, show_quality = $7, show_root_folder = $8
+		WHERE id = 1;
+	`
+
+	_, err = q.db.ExecContext(ctx, query, apiKey, url, userID, language, movieQuality, movieRootFolder, showQuality, showRootFolder)
+	if err != nil {
+		return fmt.Errorf("error updating ombi settings: %v", err)
+	}
+
+	return nil
+}
+
+func (q *Queries) CreateOmbiSettings(ctx context.Context, apiKey, url, userID, language sql.NullString, movieQuality, movieRootFolder, showQuality, showRootFolder sql.NullInt32) error {
+	apiKey, err := q.encryptNullSecret(apiKey)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO ombi (api_key, url, user_id, language, movie_quality, movie_root_folder, show_quality, show_root_folder)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+	`
+
+	_, err = q.db.ExecContext(ctx, query, apiKey, url, userID, language, movieQuality, movieRootFolder, showQuality, showRootFolder)
+	if err != nil {
+		return fmt.Errorf("error creating ombi settings: %v", err)
+	}
+
+	return nil
+}
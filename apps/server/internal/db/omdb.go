@@ -33,9 +33,14 @@ func (q *Queries) GetOMDbSettings(ctx context.Context) (OMDb, error) {
 }
 
 func (q *Queries) UpdateOMDbSettings(ctx context.Context, apiKey string) error {
+	apiKey, err := q.encryptSecret(apiKey)
+	if err != nil {
+		return err
+	}
+
 	query := `UPDATE omdb SET api_key = $1 WHERE id = 1`
 
-	_, err := q.db.ExecContext(ctx, query, apiKey)
+	_, err = q.db.ExecContext(ctx, query, apiKey)
 	if err != nil {
 		return fmt.Errorf("error updating trakt settings: %v", err)
 	}
@@ -44,9 +49,14 @@ func (q *Queries) UpdateOMDbSettings(ctx context.Context, apiKey string) error {
 }
 
 func (q *Queries) CreateOMDbSettings(ctx context.Context, apiKey string) error {
+	apiKey, err := q.encryptSecret(apiKey)
+	if err != nil {
+		return err
+	}
+
 	query := `INSERT INTO omdb (api_key) VALUES ($1)`
 
-	_, err := q.db.ExecContext(ctx, query, apiKey)
+	_, err = q.db.ExecContext(ctx, query, apiKey)
 	if err != nil {
 		return fmt.Errorf("error creating trakt settings: %v", err)
 	}
@@ -8,6 +8,7 @@ import (
 
 type RadarrSettings struct {
 	ID                  int            `db:"id"`                   // Primary key with auto-increment
+	Name                string         `db:"name"`                 // Name the instance is shown with
 	APIKey              sql.NullString `db:"api_key"`              // API key required to make requests to Radarr
 	URL                 sql.NullString `db:"url"`                  // Base URL for the Radarr server
 	MinimumAvailability sql.NullString `db:"minimum_availability"` // Minimum availability setting ("announced", "in_cinemas", "released")
@@ -15,23 +16,34 @@ type RadarrSettings struct {
 	RootFolder          sql.NullInt32  `db:"root_folder"`          // The root folder to use for Radarr
 }
 
-func (q *Queries) GetRadarrSettings(ctx context.Context) (RadarrSettings, error) {
-	var settings RadarrSettings
-	query := `
-		SELECT id, api_key, url, minimum_availability, quality, root_folder
-		FROM radarr
-		LIMIT 1;
-	`
+var ErrNoRadarrInstance = fmt.Errorf("no radarr instance found")
+
+const radarrColumns = `id, name, api_key, url, minimum_availability, quality, root_folder`
 
-	row := q.db.QueryRowContext(ctx, query)
+func scanRadarrSettings(row interface{ Scan(...any) error }) (RadarrSettings, error) {
+	var settings RadarrSettings
 	err := row.Scan(
 		&settings.ID,
+		&settings.Name,
 		&settings.APIKey,
 		&settings.URL,
 		&settings.MinimumAvailability,
 		&settings.Quality,
 		&settings.RootFolder,
 	)
+	return settings, err
+}
+
+// GetRadarrSettings returns the first Radarr instance
+func (q *Queries) GetRadarrSettings(ctx context.Context) (RadarrSettings, error) {
+	query := `
+		SELECT ` + radarrColumns + `
+		FROM radarr
+		ORDER BY id
+		LIMIT 1;
+	`
+
+	settings, err := scanRadarrSettings(q.db.QueryRowContext(ctx, query))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			// Handle the case where there are no settings
@@ -44,17 +56,123 @@ func (q *Queries) GetRadarrSettings(ctx context.Context) (RadarrSettings, error)
 	return settings, nil
 }
 
+// UpdateRadarrSettings updates the first Radarr instance
 func (q *Queries) UpdateRadarrSettings(ctx context.Context, apiKey, url, minimumAvailability sql.NullString, quality, rootFolder sql.NullInt32) error {
+	apiKey, err := q.encryptNullSecret(apiKey)
+	if err != nil {
+		return err
+	}
+
 	query := `
 		UPDATE radarr
 		SET api_key = $1, url = $2, minimum_availability = $3, quality = $4, root_folder = $5
-		WHERE id = 1;
+		WHERE id = (SELECT MIN(id) FROM radarr);
+	`
+
+	_, err = q.db.ExecContext(ctx, query, apiKey, url, minimumAvailability, quality, rootFolder)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetRadarrInstances returns every Radarr instance ordered by ID
+func (q *Queries) GetRadarrInstances(ctx context.Context) ([]RadarrSettings, error) {
+	query := `
+		SELECT ` + radarrColumns + `
+		FROM radarr
+		ORDER BY id;
+	`
+
+	rows, err := q.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	instances := []RadarrSettings{}
+	for rows.Next() {
+		instance, err := scanRadarrSettings(rows)
+		if err != nil {
+			return nil, err
+		}
+		instances = append(instances, instance)
+	}
+
+	return instances, rows.Err()
+}
+
+// GetRadarrInstance returns the Radarr instance with the given ID
+func (q *Queries) GetRadarrInstance(ctx context.Context, id int) (RadarrSettings, error) {
+	query := `
+		SELECT ` + radarrColumns + `
+		FROM radarr
+		WHERE id = $1;
+	`
+
+	settings, err := scanRadarrSettings(q.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return settings, ErrNoRadarrInstance
+		}
+		return settings, err
+	}
+
+	return settings, nil
+}
+
+// CreateRadarrInstance adds a Radarr instance and returns its ID
+func (q *Queries) CreateRadarrInstance(ctx context.Context, name string, apiKey, url, minimumAvailability sql.NullString, quality, rootFolder sql.NullInt32) (int, error) {
+	apiKey, err := q.encryptNullSecret(apiKey)
+	if err != nil {
+		return 0, err
+	}
+
+	query := `
+		INSERT INTO radarr (name, api_key, url, minimum_availability, quality, root_folder)
+		VALUES ($1, $2, $3, $4, $5, $6);
 	`
 
-	_, err := q.db.ExecContext(ctx, query, apiKey, url, minimumAvailability, quality, rootFolder)
+	result, err := q.db.ExecContext(ctx, query, name, apiKey, url, minimumAvailability, quality, rootFolder)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(id), nil
+}
+
+// UpdateRadarrInstance updates the Radarr instance with the given ID
+func (q *Queries) UpdateRadarrInstance(ctx context.Context, id int, name string, apiKey, url, minimumAvailability sql.NullString, quality, rootFolder sql.NullInt32) error {
+	apiKey, err := q.encryptNullSecret(apiKey)
 	if err != nil {
 		return err
 	}
 
+	query := `
+		UPDATE radarr
+		SET name = $1, api_key = $2, url = $3, minimum_availability = $4, quality = $5, root_folder = $6
+		WHERE id = $7;
+	`
+
+	result, err := q.db.ExecContext(ctx, query, name, apiKey, url, minimumAvailability, quality, rootFolder, id)
+	if err != nil {
+		return err
+	}
+
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return ErrNoRadarrInstance
+	}
+
 	return nil
 }
+
+// DeleteRadarrInstance removes the Radarr instance with the given ID along with its job assignments
+func (q *Queries) DeleteRadarrInstance(ctx context.Context, id int) error {
+	return q.deleteInstance(ctx, "radarr", movieJobPrefix, id, ErrNoRadarrInstance)
+}
@@ -0,0 +1,118 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/mahcks/blockbusterr/internal/secrets"
+)
+
+// secretColumns lists every column that holds a client secret or API key
+var secretColumns = []struct {
+	table  string
+	column string
+}{
+	{"trakt", "client_secret"},
+	{"trakt", "access_token"},
+	{"trakt", "refresh_token"},
+	{"radarr", "api_key"},
+	{"sonarr", "api_key"},
+	{"ombi", "api_key"},
+	{"omdb", "api_key"},
+	{"media_server", "api_key"},
+	{"notification_webhooks", "headers"},
+}
+
+// UseSecrets sets the cipher secrets are encrypted with before they are stored.
+// It must be called during startup, before any settings are written.
+func (q *Queries) UseSecrets(cipher *secrets.Cipher) {
+	q.secrets = cipher
+}
+
+// encryptSecret encrypts a secret before it's written. Values that are already
+// encrypted, like a secret that is kept as-is, are stored unchanged.
+func (q *Queries) encryptSecret(value string) (string, error) {
+	encrypted, err := q.secrets.Encrypt(value)
+	if err != nil {
+		return "", fmt.Errorf("error encrypting secret: %v", err)
+	}
+
+	return encrypted, nil
+}
+
+// encryptNullSecret is encryptSecret for nullable columns
+func (q *Queries) encryptNullSecret(value sql.NullString) (sql.NullString, error) {
+	if !value.Valid {
+		return value, nil
+	}
+
+	encrypted, err := q.encryptSecret(value.String)
+	if err != nil {
+		return value, err
+	}
+
+	return sql.NullString{String: encrypted, Valid: true}, nil
+}
+
+// EncryptExistingSecrets encrypts secrets that were stored in plaintext, either before
+// encryption existed or while no secret key was set. It's a no-op without a cipher.
+func (q *Queries) EncryptExistingSecrets(ctx context.Context) (int, error) {
+	if q.secrets == nil {
+		return 0, nil
+	}
+
+	tx, err := q.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	encrypted := 0
+	for _, secret := range secretColumns {
+		query := fmt.Sprintf(`SELECT id, %s FROM %s WHERE %s IS NOT NULL AND %s != ''`, secret.column, secret.table, secret.column, secret.column)
+
+		rows, err := tx.QueryContext(ctx, query)
+		if err != nil {
+			return 0, fmt.Errorf("error reading %s.%s: %v", secret.table, secret.column, err)
+		}
+
+		plaintext := make(map[int]string)
+		for rows.Next() {
+			var id int
+			var value string
+			if err := rows.Scan(&id, &value); err != nil {
+				rows.Close()
+				return 0, fmt.Errorf("error scanning %s.%s: %v", secret.table, secret.column, err)
+			}
+
+			if !secrets.IsEncrypted(value) {
+				plaintext[id] = value
+			}
+		}
+		rows.Close()
+
+		if err := rows.Err(); err != nil {
+			return 0, fmt.Errorf("error reading %s.%s: %v", secret.table, secret.column, err)
+		}
+
+		update := fmt.Sprintf(`UPDATE %s SET %s = $1 WHERE id = $2`, secret.table, secret.column)
+		for id, value := range plaintext {
+			value, err := q.encryptSecret(value)
+			if err != nil {
+				return 0, err
+			}
+
+			if _, err := tx.ExecContext(ctx, update, value, id); err != nil {
+				return 0, fmt.Errorf("error encrypting %s.%s: %v", secret.table, secret.column, err)
+			}
+			encrypted++
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("error committing transaction: %v", err)
+	}
+
+	return encrypted, nil
+}
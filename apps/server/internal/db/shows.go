@@ -20,6 +20,32 @@ type ShowSettings struct {
 	CronJobPopular     sql.NullString `db:"cron_job_popular"`     // Cron expression for the popular list
 	CronJobTrending    sql.NullString `db:"cron_job_trending"`    // Cron expression for the trending list
 
+	// Most watched, most played and most collected lists over a period
+	Watched          sql.NullInt32  `db:"watched"`            // How many shows will be grabbed from the most watched list
+	WatchedPeriod    string         `db:"watched_period"`     // Period of the most watched list ("weekly", "monthly", "yearly" or "all")
+	CronJobWatched   sql.NullString `db:"cron_job_watched"`   // Cron expression for the most watched list
+	Played           sql.NullInt32  `db:"played"`             // How many shows will be grabbed from the most played list
+	PlayedPeriod     string         `db:"played_period"`      // Period of the most played list
+	CronJobPlayed    sql.NullString `db:"cron_job_played"`    // Cron expression for the most played list
+	Collected        sql.NullInt32  `db:"collected"`          // How many shows will be grabbed from the most collected list
+	CollectedPeriod  string         `db:"collected_period"`   // Period of the most collected list
+	CronJobCollected sql.NullString `db:"cron_job_collected"` // Cron expression for the most collected list
+
+	// Rating, vote and certification filters
+	MinRating             sql.NullFloat64 `db:"min_rating"`             // Minimum Trakt rating (0-10)
+	MinVotes              sql.NullInt32   `db:"min_votes"`              // Minimum number of Trakt votes
+	MinIMDbRating         sql.NullFloat64 `db:"min_imdb_rating"`        // Minimum IMDb rating on OMDb (0-10)
+	RottenTomatoes        sql.NullString  `db:"rotten_tomatoes"`        // Minimum Rotten Tomatoes score on OMDb (0-100)
+	MinMetacritic         sql.NullInt32   `db:"min_metacritic"`         // Minimum Metacritic score on OMDb (0-100)
+	AllowedCertifications sql.NullString  `db:"allowed_certifications"` // Comma-separated certifications a show must have one of
+	BlockedCertifications sql.NullString  `db:"blocked_certifications"` // Comma-separated certifications to skip
+
+	// Options shows are added to Sonarr with
+	SeriesType   string `db:"series_type"`   // "standard", "anime" or "daily"
+	SeasonFolder bool   `db:"season_folder"` // Whether episodes are sorted into season folders
+	Monitor      string `db:"monitor"`       // Episodes to monitor ("all", "future", "firstSeason", "latestSeason", "pilot" or "none")
+	SearchOnAdd  bool   `db:"search_on_add"` // Whether Sonarr searches for the monitored episodes right away
+
 	AllowedCountries         []ShowAllowedCountries         // List of allowed countries
 	AllowedLanguages         []ShowAllowedLanguages         // List of allowed languages
 	BlacklistedGenres        []ShowBlacklistedGenres        // List of blacklisted genres
@@ -56,6 +82,7 @@ type ShowBlacklistedTitleKeywords struct {
 	ID             int    `db:"id"`               // Primary key with auto-increment
 	ShowSettingsID int    `db:"show_settings_id"` // Foreign key to the show settings table
 	Keyword        string `db:"keyword"`          // Keyword to blacklist from the title of a show
+	MatchMode      string `db:"match_mode"`       // How the keyword is matched: "substring", "word" or "regex"
 }
 
 type ShowBlacklistedTVDBIDs struct {
@@ -79,7 +106,12 @@ func (q *Queries) GetShowSettings(ctx context.Context) (ShowSettings, error) {
 	// Query for the main show settings
 	err = tx.QueryRowContext(ctx, `
 		SELECT id, anticipated, cron_job_anticipated, popular, cron_job_popular, trending, cron_job_trending, 
-		       max_runtime, min_runtime, min_year, max_year
+		       max_runtime, min_runtime, min_year, max_year,
+		       watched, watched_period, cron_job_watched, played, played_period, cron_job_played,
+		       collected, collected_period, cron_job_collected,
+		       min_rating, min_votes, min_imdb_rating, rotten_tomatoes, min_metacritic,
+		       allowed_certifications, blocked_certifications,
+		       series_type, season_folder, monitor, search_on_add
 		FROM show_settings
 		LIMIT 1;
 	`).Scan(
@@ -94,6 +126,26 @@ func (q *Queries) GetShowSettings(ctx context.Context) (ShowSettings, error) {
 		&settings.MinRuntime,
 		&settings.MinYear,
 		&settings.MaxYear,
+		&settings.Watched,
+		&settings.WatchedPeriod,
+		&settings.CronJobWatched,
+		&settings.Played,
+		&settings.PlayedPeriod,
+		&settings.CronJobPlayed,
+		&settings.Collected,
+		&settings.CollectedPeriod,
+		&settings.CronJobCollected,
+		&settings.MinRating,
+		&settings.MinVotes,
+		&settings.MinIMDbRating,
+		&settings.RottenTomatoes,
+		&settings.MinMetacritic,
+		&settings.AllowedCertifications,
+		&settings.BlockedCertifications,
+		&settings.SeriesType,
+		&settings.SeasonFolder,
+		&settings.Monitor,
+		&settings.SearchOnAdd,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -184,7 +236,7 @@ func (q *Queries) GetShowSettings(ctx context.Context) (ShowSettings, error) {
 
 	// Fetch blacklisted title keywords
 	keywordQuery := `
-		SELECT id, show_settings_id, keyword
+		SELECT id, show_settings_id, keyword, match_mode
 		FROM show_blacklisted_title_keywords
 		WHERE show_settings_id = ?;
 	`
@@ -196,7 +248,7 @@ func (q *Queries) GetShowSettings(ctx context.Context) (ShowSettings, error) {
 
 	for keywordRows.Next() {
 		var blacklistedKeyword ShowBlacklistedTitleKeywords
-		if err := keywordRows.Scan(&blacklistedKeyword.ID, &blacklistedKeyword.ShowSettingsID, &blacklistedKeyword.Keyword); err != nil {
+		if err := keywordRows.Scan(&blacklistedKeyword.ID, &blacklistedKeyword.ShowSettingsID, &blacklistedKeyword.Keyword, &blacklistedKeyword.MatchMode); err != nil {
 			return settings, err
 		}
 		settings.BlacklistedTitleKeywords = append(settings.BlacklistedTitleKeywords, blacklistedKeyword)
@@ -229,3 +281,43 @@ func (q *Queries) GetShowSettings(ctx context.Context) (ShowSettings, error) {
 
 	return settings, nil
 }
+
+// UpdateShowSettings updates the list and filter columns of the show settings. The allowed and blacklisted entries are left alone.
+func (q *Queries) UpdateShowSettings(ctx context.Context, settings ShowSettings) error {
+	tx, err := q.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE show_settings
+		SET anticipated = $1, popular = $2, trending = $3,
+		    max_runtime = $4, min_runtime = $5, min_year = $6, max_year = $7,
+		    cron_job_anticipated = $8, cron_job_popular = $9, cron_job_trending = $10,
+		    watched = $11, watched_period = $12, cron_job_watched = $13,
+		    played = $14, played_period = $15, cron_job_played = $16,
+		    collected = $17, collected_period = $18, cron_job_collected = $19,
+		    min_rating = $20, min_votes = $21, min_imdb_rating = $22, rotten_tomatoes = $23, min_metacritic = $24,
+		    allowed_certifications = $25, blocked_certifications = $26,
+		    series_type = $27, season_folder = $28, monitor = $29, search_on_add = $30
+		WHERE id = 1;
+	`, settings.Anticipated, settings.Popular, settings.Trending,
+		settings.MaxRuntime, settings.MinRuntime, settings.MinYear, settings.MaxYear,
+		settings.CronJobAnticipated, settings.CronJobPopular, settings.CronJobTrending,
+		settings.Watched, settings.WatchedPeriod, settings.CronJobWatched,
+		settings.Played, settings.PlayedPeriod, settings.CronJobPlayed,
+		settings.Collected, settings.CollectedPeriod, settings.CronJobCollected,
+		settings.MinRating, settings.MinVotes, settings.MinIMDbRating, settings.RottenTomatoes, settings.MinMetacritic,
+		settings.AllowedCertifications, settings.BlockedCertifications,
+		settings.SeriesType, settings.SeasonFolder, settings.Monitor, settings.SearchOnAdd)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
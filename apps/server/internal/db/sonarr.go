@@ -8,6 +8,7 @@ import (
 
 type SonarrSettings struct {
 	ID           int            `db:"id"`            // Primary key with auto-increment
+	Name         string         `db:"name"`          // Name the instance is shown with
 	APIKey       sql.NullString `db:"api_key"`       // API key required to make requests to Sonarr
 	URL          sql.NullString `db:"url"`           // Base URL for the Sonarr server
 	Language     sql.NullString `db:"language"`      // ???
@@ -16,19 +17,18 @@ type SonarrSettings struct {
 	SeasonFolder sql.NullBool   `db:"season_folder"` // Whether to use season folders
 }
 
-var ErrNoSonarrSettings = fmt.Errorf("no sonarr settings found")
+var (
+	ErrNoSonarrSettings = fmt.Errorf("no sonarr settings found")
+	ErrNoSonarrInstance = fmt.Errorf("no sonarr instance found")
+)
 
-func (q *Queries) GetSonarrSettings(ctx context.Context) (SonarrSettings, error) {
-	var settings SonarrSettings
-	query := `
-		SELECT id, api_key, url, language, quality, root_folder, season_folder
-		FROM sonarr
-		LIMIT 1;
-	`
+const sonarrColumns = `id, name, api_key, url, language, quality, root_folder, season_folder`
 
-	row := q.db.QueryRowContext(ctx, query)
+func scanSonarrSettings(row interface{ Scan(...any) error }) (SonarrSettings, error) {
+	var settings SonarrSettings
 	err := row.Scan(
 		&settings.ID,
+		&settings.Name,
 		&settings.APIKey,
 		&settings.URL,
 		&settings.Language,
@@ -36,6 +36,19 @@ func (q *Queries) GetSonarrSettings(ctx context.Context) (SonarrSettings, error)
 		&settings.RootFolder,
 		&settings.SeasonFolder,
 	)
+	return settings, err
+}
+
+// GetSonarrSettings returns the first Sonarr instance
+func (q *Queries) GetSonarrSettings(ctx context.Context) (SonarrSettings, error) {
+	query := `
+		SELECT ` + sonarrColumns + `
+		FROM sonarr
+		ORDER BY id
+		LIMIT 1;
+	`
+
+	settings, err := scanSonarrSettings(q.db.QueryRowContext(ctx, query))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			// Handle the case where there are no settings
@@ -47,14 +60,20 @@ func (q *Queries) GetSonarrSettings(ctx context.Context) (SonarrSettings, error)
 	return settings, nil
 }
 
+// UpdateSonarrSettings updates the first Sonarr instance
 func (q *Queries) UpdateSonarrSettings(ctx context.Context, apiKey, url, language string, quality, rootFolder int32, seasonFolder bool) error {
+	apiKey, err := q.encryptSecret(apiKey)
+	if err != nil {
+		return err
+	}
+
 	query := `
 		UPDATE sonarr
 		SET api_key = $1, url = $2, language = $3, quality = $4, root_folder = $5, season_folder = $6
-		WHERE id = 1;
+		WHERE id = (SELECT MIN(id) FROM sonarr);
 	`
 
-	_, err := q.db.ExecContext(ctx, query, apiKey, url, language, quality, rootFolder, seasonFolder)
+	_, err = q.db.ExecContext(ctx, query, apiKey, url, language, quality, rootFolder, seasonFolder)
 	if err != nil {
 		return err
 	}
@@ -63,19 +82,124 @@ func (q *Queries) UpdateSonarrSettings(ctx context.Context, apiKey, url, languag
 }
 
 func (q *Queries) CreateSonarrSettings(ctx context.Context, apiKey, url, language string, quality, rootFolder int32, seasonFolder bool) error {
+	apiKey, err := q.encryptSecret(apiKey)
+	if err != nil {
+		return err
+	}
+
 	query := `
 		INSERT INTO sonarr (api_key, url, language, quality, root_folder, season_folder)
 		VALUES ($1, $2, $3, $4, $5, $6);
 	`
 
-	_, err := q.db.ExecContext(ctx, query, apiKey, url, language, quality, rootFolder, seasonFolder)
+	_, err = q.db.ExecContext(ctx, query, apiKey, url, language, quality, rootFolder, seasonFolder)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetSonarrInstances returns every Sonarr instance ordered by ID
+func (q *Queries) GetSonarrInstances(ctx context.Context) ([]SonarrSettings, error) {
+	query := `
+		SELECT ` + sonarrColumns + `
+		FROM sonarr
+		ORDER BY id;
+	`
+
+	rows, err := q.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	instances := []SonarrSettings{}
+	for rows.Next() {
+		instance, err := scanSonarrSettings(rows)
+		if err != nil {
+			return nil, err
+		}
+		instances = append(instances, instance)
+	}
+
+	return instances, rows.Err()
+}
+
+// GetSonarrInstance returns the Sonarr instance with the given ID
+func (q *Queries) GetSonarrInstance(ctx context.Context, id int) (SonarrSettings, error) {
+	query := `
+		SELECT ` + sonarrColumns + `
+		FROM sonarr
+		WHERE id = $1;
+	`
+
+	settings, err := scanSonarrSettings(q.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return settings, ErrNoSonarrInstance
+		}
+		return settings, err
+	}
+
+	return settings, nil
+}
+
+// CreateSonarrInstance adds a Sonarr instance and returns its ID
+func (q *Queries) CreateSonarrInstance(ctx context.Context, name string, apiKey, url, language sql.NullString, quality, rootFolder sql.NullInt32, seasonFolder sql.NullBool) (int, error) {
+	apiKey, err := q.encryptNullSecret(apiKey)
+	if err != nil {
+		return 0, err
+	}
+
+	query := `
+		INSERT INTO sonarr (name, api_key, url, language, quality, root_folder, season_folder)
+		VALUES ($1, $2, $3, $4, $5, $6, $7);
+	`
+
+	result, err := q.db.ExecContext(ctx, query, name, apiKey, url, language, quality, rootFolder, seasonFolder)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(id), nil
+}
+
+// UpdateSonarrInstance updates the Sonarr instance with the given ID
+func (q *Queries) UpdateSonarrInstance(ctx context.Context, id int, name string, apiKey, url, language sql.NullString, quality, rootFolder sql.NullInt32, seasonFolder sql.NullBool) error {
+	apiKey, err := q.encryptNullSecret(apiKey)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE sonarr
+		SET name = $1, api_key = $2, url = $3, language = $4, quality = $5, root_folder = $6, season_folder = $7
+		WHERE id = $8;
+	`
+
+	result, err := q.db.ExecContext(ctx, query, name, apiKey, url, language, quality, rootFolder, seasonFolder, id)
 	if err != nil {
 		return err
 	}
 
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return ErrNoSonarrInstance
+	}
+
 	return nil
 }
 
+// DeleteSonarrInstance removes the Sonarr instance with the given ID along with its job assignments
+func (q *Queries) DeleteSonarrInstance(ctx context.Context, id int) error {
+	return q.deleteInstance(ctx, "sonarr", showJobPrefix, id, ErrNoSonarrInstance)
+}
+
 func (q *Queries) GetShowInterval(ctx context.Context) (sql.NullInt32, error) {
 	var interval sql.NullInt32
 
@@ -5,12 +5,16 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 )
 
 type TraktSettings struct {
-	ID           int    `db:"id"`
-	ClientID     string `db:"client_id"`
-	ClientSecret string `db:"client_secret"`
+	ID             int            `db:"id"`
+	ClientID       string         `db:"client_id"`
+	ClientSecret   string         `db:"client_secret"`
+	AccessToken    sql.NullString `db:"access_token"`     // OAuth access token of the authorized account
+	RefreshToken   sql.NullString `db:"refresh_token"`    // OAuth refresh token of the authorized account
+	TokenExpiresAt sql.NullTime   `db:"token_expires_at"` // Time the access token expires
 }
 
 var ErrNoTraktSettings = errors.New("no trakt settings found")
@@ -18,12 +22,15 @@ var ErrNoTraktSettings = errors.New("no trakt settings found")
 func (q *Queries) GetTraktSettings(ctx context.Context) (TraktSettings, error) {
 	var settings TraktSettings
 
-	query := `SELECT id, client_id, client_secret FROM trakt`
+	query := `SELECT id, client_id, client_secret, access_token, refresh_token, token_expires_at FROM trakt`
 
 	err := q.db.QueryRowContext(ctx, query).Scan(
 		&settings.ID,
 		&settings.ClientID,
 		&settings.ClientSecret,
+		&settings.AccessToken,
+		&settings.RefreshToken,
+		&settings.TokenExpiresAt,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -37,9 +44,22 @@ func (q *Queries) GetTraktSettings(ctx context.Context) (TraktSettings, error) {
 }
 
 func (q *Queries) UpdateTraktSettings(ctx context.Context, clientID, clientSecret string) error {
-	query := `UPDATE trakt SET client_id = $1, client_secret = $2 WHERE id = 1`
+	clientSecret, err := q.encryptSecret(clientSecret)
+	if err != nil {
+		return err
+	}
 
-	_, err := q.db.ExecContext(ctx, query, clientID, clientSecret)
+	// Tokens belong to the client they were issued to, so they are dropped when the client changes
+	query := `
+		UPDATE trakt
+		SET access_token = CASE WHEN client_id = $1 THEN access_token END,
+			refresh_token = CASE WHEN client_id = $1 THEN refresh_token END,
+			token_expires_at = CASE WHEN client_id = $1 THEN token_expires_at END,
+			client_id = $1, client_secret = $2
+		WHERE id = 1
+	`
+
+	_, err = q.db.ExecContext(ctx, query, clientID, clientSecret)
 	if err != nil {
 		return fmt.Errorf("error updating trakt settings: %v", err)
 	}
@@ -48,11 +68,50 @@ func (q *Queries) UpdateTraktSettings(ctx context.Context, clientID, clientSecre
 }
 
 func (q *Queries) CreateTraktSettings(ctx context.Context, clientID, clientSecret string) error {
+	clientSecret, err := q.encryptSecret(clientSecret)
+	if err != nil {
+		return err
+	}
+
 	query := `INSERT INTO trakt (client_id, client_secret) VALUES ($1, $2)`
-	_, err := q.db.ExecContext(ctx, query, clientID, clientSecret)
+	_, err = q.db.ExecContext(ctx, query, clientID, clientSecret)
 	if err != nil {
 		return fmt.Errorf("error creating trakt settings: %v", err)
 	}
 
 	return nil
 }
+
+// UpdateTraktTokens stores the OAuth tokens of the authorized Trakt account
+func (q *Queries) UpdateTraktTokens(ctx context.Context, accessToken, refreshToken string, expiresAt time.Time) error {
+	accessToken, err := q.encryptSecret(accessToken)
+	if err != nil {
+		return err
+	}
+
+	refreshToken, err = q.encryptSecret(refreshToken)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE trakt SET access_token = $1, refresh_token = $2, token_expires_at = $3 WHERE id = 1`
+
+	_, err = q.db.ExecContext(ctx, query, accessToken, refreshToken, expiresAt.UTC())
+	if err != nil {
+		return fmt.Errorf("error updating trakt tokens: %v", err)
+	}
+
+	return nil
+}
+
+// ClearTraktTokens removes the OAuth tokens, signing the Trakt account out
+func (q *Queries) ClearTraktTokens(ctx context.Context) error {
+	query := `UPDATE trakt SET access_token = NULL, refresh_token = NULL, token_expires_at = NULL WHERE id = 1`
+
+	_, err := q.db.ExecContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("error clearing trakt tokens: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type User struct {
+	ID           int            `db:"id"`            // Primary key with auto-increment
+	Username     string         `db:"username"`      // Name the user signs in with
+	PasswordHash string         `db:"password_hash"` // bcrypt hash of the password
+	APIKeyHash   sql.NullString `db:"api_key_hash"`  // SHA-256 hash of the user's API key
+	CreatedAt    time.Time      `db:"created_at"`    // Time the user was created
+}
+
+var (
+	ErrNoUser      = errors.New("no user found")
+	ErrNoSession   = errors.New("no session found")
+	ErrUsersExists = errors.New("a user already exists")
+)
+
+func (q *Queries) CountUsers(ctx context.Context) (int, error) {
+	var count int
+
+	err := q.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM users`).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("error counting users: %v", err)
+	}
+
+	return count, nil
+}
+
+// CreateFirstUser creates the admin user, but only while there are no users. Checking and
+// inserting in one statement keeps two requests from both creating an admin.
+func (q *Queries) CreateFirstUser(ctx context.Context, username, passwordHash string) (User, error) {
+	query := `
+		INSERT INTO users (username, password_hash, created_at)
+		SELECT $1, $2, $3
+		WHERE NOT EXISTS (SELECT 1 FROM users);
+	`
+
+	createdAt := time.Now().UTC()
+	result, err := q.db.ExecContext(ctx, query, username, passwordHash, createdAt)
+	if err != nil {
+		return User{}, fmt.Errorf("error creating user: %v", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return User{}, fmt.Errorf("error reading created users: %v", err)
+	}
+
+	if rows == 0 {
+		return User{}, ErrUsersExists
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return User{}, fmt.Errorf("error reading new user ID: %v", err)
+	}
+
+	return User{
+		ID:           int(id),
+		Username:     username,
+		PasswordHash: passwordHash,
+		CreatedAt:    createdAt,
+	}, nil
+}
+
+func (q *Queries) GetUserByUsername(ctx context.Context, username string) (User, error) {
+	return q.getUser(ctx, `SELECT id, username, password_hash, api_key_hash, created_at FROM users WHERE username = $1`, username)
+}
+
+func (q *Queries) GetUserByAPIKeyHash(ctx context.Context, apiKeyHash string) (User, error) {
+	return q.getUser(ctx, `SELECT id, username, password_hash, api_key_hash, created_at FROM users WHERE api_key_hash = $1`, apiKeyHash)
+}
+
+func (q *Queries) getUser(ctx context.Context, query string, args ...any) (User, error) {
+	var user User
+
+	err := q.db.QueryRowContext(ctx, query, args...).Scan(
+		&user.ID,
+		&user.Username,
+		&user.PasswordHash,
+		&user.APIKeyHash,
+		&user.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return User{}, ErrNoUser
+		}
+		return User{}, fmt.Errorf("error fetching user: %v", err)
+	}
+
+	return user, nil
+}
+
+// UpdateUserAPIKeyHash replaces the API key of a user, which invalidates the previous one
+func (q *Queries) UpdateUserAPIKeyHash(ctx context.Context, userID int, apiKeyHash string) error {
+	_, err := q.db.ExecContext(ctx, `UPDATE users SET api_key_hash = $1 WHERE id = $2`, apiKeyHash, userID)
+	if err != nil {
+		return fmt.Errorf("error updating user API key: %v", err)
+	}
+
+	return nil
+}
+
+func (q *Queries) CreateSession(ctx context.Context, tokenHash string, userID int, expiresAt time.Time) error {
+	query := `INSERT INTO sessions (token_hash, user_id, created_at, expires_at) VALUES ($1, $2, $3, $4)`
+
+	_, err := q.db.ExecContext(ctx, query, tokenHash, userID, time.Now().UTC(), expiresAt.UTC())
+	if err != nil {
+		return fmt.Errorf("error creating session: %v", err)
+	}
+
+	return nil
+}
+
+// GetSessionUser returns the user a session belongs to, as long as the session hasn't expired
+func (q *Queries) GetSessionUser(ctx context.Context, tokenHash string) (User, error) {
+	query := `
+		SELECT u.id, u.username, u.password_hash, u.api_key_hash, u.created_at, s.expires_at
+		FROM sessions s
+		JOIN users u ON u.id = s.user_id
+		WHERE s.token_hash = $1;
+	`
+
+	var user User
+	var expiresAt time.Time
+	err := q.db.QueryRowContext(ctx, query, tokenHash).Scan(
+		&user.ID,
+		&user.Username,
+		&user.PasswordHash,
+		&user.APIKeyHash,
+		&user.CreatedAt,
+		&expiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return User{}, ErrNoSession
+		}
+		return User{}, fmt.Errorf("error fetching session: %v", err)
+	}
+
+	if time.Now().After(expiresAt) {
+		return User{}, ErrNoSession
+	}
+
+	return user, nil
+}
+
+func (q *Queries) DeleteSession(ctx context.Context, tokenHash string) error {
+	_, err := q.db.ExecContext(ctx, `DELETE FROM sessions WHERE token_hash = $1`, tokenHash)
+	if err != nil {
+		return fmt.Errorf("error deleting session: %v", err)
+	}
+
+	return nil
+}
+
+// DeleteExpiredSessions removes every session that can no longer be used
+func (q *Queries) DeleteExpiredSessions(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, `DELETE FROM sessions WHERE expires_at < $1`, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("error deleting expired sessions: %v", err)
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package helpers
 
 import (
 	"github.com/mahcks/blockbusterr/internal/global"
+	"github.com/mahcks/blockbusterr/internal/helpers/mediaserver"
 	"github.com/mahcks/blockbusterr/internal/helpers/ombi"
 	"github.com/mahcks/blockbusterr/internal/helpers/omdb"
 	"github.com/mahcks/blockbusterr/internal/helpers/radarr"
@@ -10,11 +11,12 @@ import (
 )
 
 type Helpers struct {
-	Trakt  trakt.Service
-	Ombi   ombi.Service
-	Radarr radarr.Service
-	Sonarr sonarr.Service
-	OMDb   omdb.Service
+	Trakt       trakt.Service
+	Ombi        ombi.Service
+	Radarr      radarr.Service
+	Sonarr      sonarr.Service
+	OMDb        omdb.Service
+	MediaServer mediaserver.Service
 }
 
 // Initialize the helpers struct, setting up Trakt service
@@ -44,11 +46,17 @@ func SetupHelpers(gctx global.Context) (*Helpers, error) {
 		return nil, err
 	}
 
+	mediaServerService, err := mediaserver.Setup(gctx)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Helpers{
-		Trakt:  traktService,
-		Ombi:   ombiService,
-		Radarr: radarrService,
-		Sonarr: sonarrService,
-		OMDb:   omdbService,
+		Trakt:       traktService,
+		Ombi:        ombiService,
+		Radarr:      radarrService,
+		Sonarr:      sonarrService,
+		OMDb:        omdbService,
+		MediaServer: mediaServerService,
 	}, nil
 }
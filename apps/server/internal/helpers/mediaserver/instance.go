@@ -0,0 +1,103 @@
+package mediaserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dghubble/sling"
+	"github.com/mahcks/blockbusterr/internal/global"
+)
+
+type Service interface {
+	// GetLibrary returns every movie and show in the library of the configured media server
+	GetLibrary(ctx context.Context) ([]Item, error)
+}
+
+var (
+	ErrNoMediaServer          = errors.New("no media server is configured")
+	ErrUnknownMediaServerType = errors.New("unknown media server type")
+)
+
+// ServerType is the kind of media server the library is read from
+type ServerType string
+
+const (
+	ServerTypePlex     ServerType = "plex"
+	ServerTypeJellyfin ServerType = "jellyfin"
+	ServerTypeEmby     ServerType = "emby"
+)
+
+// IsValidServerType checks if the server type is one blockbusterr can read the library of
+func IsValidServerType(serverType string) bool {
+	switch ServerType(serverType) {
+	case ServerTypePlex, ServerTypeJellyfin, ServerTypeEmby:
+		return true
+	}
+
+	return false
+}
+
+// Item is a movie or show in the media server library, identified by whichever external IDs the server knows
+type Item struct {
+	MediaType string // Type of media (MOVIE, SHOW)
+	Title     string
+	Year      int
+	IMDBID    string
+	TMDBID    int
+	TVDBID    int
+}
+
+type mediaServerService struct {
+	gctx global.Context
+}
+
+// fetchServerFromDB returns the kind of media server along with a client for its base URL and its decrypted API key
+func (m *mediaServerService) fetchServerFromDB(ctx context.Context) (ServerType, *sling.Sling, string, error) {
+	settings, err := m.gctx.Crate().SQL.Queries().GetMediaServerSettings(ctx)
+	if err != nil {
+		return "", nil, "", err
+	}
+
+	if !settings.ServerType.Valid || !settings.URL.Valid || settings.URL.String == "" {
+		return "", nil, "", ErrNoMediaServer
+	}
+
+	apiKey, err := m.gctx.Crate().Secrets.Decrypt(settings.APIKey.String)
+	if err != nil {
+		return "", nil, "", fmt.Errorf("error decrypting media server API key: %w", err)
+	}
+
+	base := sling.New().Base(strings.TrimSuffix(settings.URL.String, "/")+"/").
+		Set("Accept", "application/json")
+
+	return ServerType(settings.ServerType.String), base, apiKey, nil
+}
+
+func (m *mediaServerService) GetLibrary(ctx context.Context) ([]Item, error) {
+	serverType, base, apiKey, err := m.fetchServerFromDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	switch serverType {
+	case ServerTypePlex:
+		return getPlexLibrary(base.Set("X-Plex-Token", apiKey))
+	case ServerTypeJellyfin, ServerTypeEmby:
+		return getJellyfinLibrary(base.Set("X-Emby-Token", apiKey))
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnknownMediaServerType, serverType)
+}
+
+// parseID returns a numeric provider ID, or 0 if it isn't one
+func parseID(value string) int {
+	id, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || id < 0 {
+		return 0
+	}
+
+	return id
+}
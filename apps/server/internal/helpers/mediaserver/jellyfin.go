@@ -0,0 +1,59 @@
+package mediaserver
+
+import (
+	"fmt"
+
+	"github.com/dghubble/sling"
+)
+
+type jellyfinItemsResponse struct {
+	Items []struct {
+		Name           string            `json:"Name"`
+		ProductionYear int               `json:"ProductionYear"`
+		Type           string            `json:"Type"`        // Either "Movie" or "Series"
+		ProviderIds    map[string]string `json:"ProviderIds"` // e.g. {"Imdb": "tt0111161", "Tmdb": "278"}
+	} `json:"Items"`
+}
+
+// getJellyfinLibrary returns every movie and series of a Jellyfin or Emby server, which share the same API
+func getJellyfinLibrary(base *sling.Sling) ([]Item, error) {
+	params := struct {
+		Recursive        bool   `url:"Recursive"`
+		IncludeItemTypes string `url:"IncludeItemTypes"`
+		Fields           string `url:"Fields"`
+	}{
+		Recursive:        true,
+		IncludeItemTypes: "Movie,Series",
+		Fields:           "ProviderIds,ProductionYear",
+	}
+
+	var response jellyfinItemsResponse
+	res, err := base.New().Get("Items").QueryStruct(params).ReceiveSuccess(&response)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, fmt.Errorf("media server returned status %d when listing items", res.StatusCode)
+	}
+
+	items := make([]Item, 0, len(response.Items))
+	for _, entry := range response.Items {
+		item := Item{
+			MediaType: "MOVIE",
+			Title:     entry.Name,
+			Year:      entry.ProductionYear,
+			IMDBID:    entry.ProviderIds["Imdb"],
+			TMDBID:    parseID(entry.ProviderIds["Tmdb"]),
+			TVDBID:    parseID(entry.ProviderIds["Tvdb"]),
+		}
+
+		if entry.Type == "Series" {
+			item.MediaType = "SHOW"
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
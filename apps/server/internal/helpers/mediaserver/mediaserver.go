@@ -0,0 +1,13 @@
+package mediaserver
+
+import (
+	"github.com/mahcks/blockbusterr/internal/global"
+)
+
+func Setup(gctx global.Context) (Service, error) {
+	svc := &mediaServerService{
+		gctx: gctx,
+	}
+
+	return svc, nil
+}
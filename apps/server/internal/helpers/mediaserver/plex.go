@@ -0,0 +1,98 @@
+package mediaserver
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dghubble/sling"
+)
+
+type plexSectionsResponse struct {
+	MediaContainer struct {
+		Directory []struct {
+			Key   string `json:"key"`
+			Type  string `json:"type"` // Either "movie", "show", "artist" or "photo"
+			Title string `json:"title"`
+		} `json:"Directory"`
+	} `json:"MediaContainer"`
+}
+
+type plexItemsResponse struct {
+	MediaContainer struct {
+		Metadata []struct {
+			Title string `json:"title"`
+			Year  int    `json:"year"`
+			Guid  []struct {
+				ID string `json:"id"` // e.g. "imdb://tt0111161", "tmdb://278" or "tvdb://81189"
+			} `json:"Guid"`
+		} `json:"Metadata"`
+	} `json:"MediaContainer"`
+}
+
+// getPlexLibrary returns the items of every movie and show section of a Plex server
+func getPlexLibrary(base *sling.Sling) ([]Item, error) {
+	var sections plexSectionsResponse
+	res, err := base.New().Get("library/sections").ReceiveSuccess(&sections)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, fmt.Errorf("plex returned status %d when listing library sections", res.StatusCode)
+	}
+
+	items := []Item{}
+	for _, section := range sections.MediaContainer.Directory {
+		mediaType := ""
+		switch section.Type {
+		case "movie":
+			mediaType = "MOVIE"
+		case "show":
+			mediaType = "SHOW"
+		default:
+			continue
+		}
+
+		var response plexItemsResponse
+		params := struct {
+			IncludeGuids int `url:"includeGuids"`
+		}{IncludeGuids: 1}
+
+		res, err := base.New().Get(fmt.Sprintf("library/sections/%s/all", section.Key)).QueryStruct(params).ReceiveSuccess(&response)
+		if err != nil {
+			return nil, err
+		}
+
+		if res.StatusCode < 200 || res.StatusCode >= 300 {
+			return nil, fmt.Errorf("plex returned status %d when listing library section %q", res.StatusCode, section.Title)
+		}
+
+		for _, metadata := range response.MediaContainer.Metadata {
+			item := Item{
+				MediaType: mediaType,
+				Title:     metadata.Title,
+				Year:      metadata.Year,
+			}
+
+			for _, guid := range metadata.Guid {
+				provider, id, ok := strings.Cut(guid.ID, "://")
+				if !ok {
+					continue
+				}
+
+				switch provider {
+				case "imdb":
+					item.IMDBID = id
+				case "tmdb":
+					item.TMDBID = parseID(id)
+				case "tvdb":
+					item.TVDBID = parseID(id)
+				}
+			}
+
+			items = append(items, item)
+		}
+	}
+
+	return items, nil
+}
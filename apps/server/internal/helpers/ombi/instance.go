@@ -47,9 +47,14 @@ func (o *ombiService) FetchOmbiURLFromDB() (*sling.Sling, error) {
 		return nil, errors.ErrInternalServerError().SetDetail("Ombi API Key is set but empty")
 	}
 
+	apiKey, err := o.gctx.Crate().Secrets.Decrypt(ombiSettings.APIKey.String)
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting Ombi API key: %w", err)
+	}
+
 	base := sling.New().Base(ombiSettings.URL.String).
 		Set("Content-Type", "application/json").
-		Set("ApiKey", ombiSettings.APIKey.String)
+		Set("ApiKey", apiKey)
 
 	// Return the Ombi URL
 	return base, nil
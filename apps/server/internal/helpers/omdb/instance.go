@@ -27,7 +27,12 @@ func (o *omdbService) FetchAPIKeyFromDB(ctx context.Context) (string, error) {
 		return "", fmt.Errorf("no OMDb API key found")
 	}
 
-	return omdb.APIKey.String, nil
+	apiKey, err := o.gctx.Crate().Secrets.Decrypt(omdb.APIKey.String)
+	if err != nil {
+		return "", fmt.Errorf("error decrypting OMDb API key: %w", err)
+	}
+
+	return apiKey, nil
 }
 
 type OMDbParams struct {
@@ -0,0 +1,64 @@
+package radarr
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/mahcks/blockbusterr/pkg/errors"
+)
+
+// Exclusion is a movie on the Radarr exclusion list, which import lists never add again
+type Exclusion struct {
+	ID         int    `json:"id,omitempty"`
+	TmdbID     int    `json:"tmdbId"`
+	MovieTitle string `json:"movieTitle"`
+	MovieYear  int    `json:"movieYear"`
+}
+
+// GetExclusions returns every movie on the Radarr exclusion list
+func (r *radarrService) GetExclusions(url, apiKey *string) ([]Exclusion, error) {
+	baseURL, err := r.FetchRadarrURLFromDB(url, apiKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var response []Exclusion
+	res, err := baseURL.New().Get("/api/v3/exclusions").Receive(&response, nil)
+	if err != nil {
+		return nil, errors.ErrInternalServerError().SetDetail("Failed to get Radarr exclusions")
+	}
+
+	if res.StatusCode == fiber.ErrUnauthorized.Code {
+		return nil, ErrUnauthorizedRadarrRequest
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, fmt.Errorf("radarr api returned status %d when listing exclusions", res.StatusCode)
+	}
+
+	return response, nil
+}
+
+// AddExclusion puts a movie on the Radarr exclusion list
+func (r *radarrService) AddExclusion(url, apiKey *string, exclusion Exclusion) (Exclusion, error) {
+	baseURL, err := r.FetchRadarrURLFromDB(url, apiKey)
+	if err != nil {
+		return Exclusion{}, err
+	}
+
+	var response Exclusion
+	res, err := baseURL.New().Post("/api/v3/exclusions").BodyJSON(exclusion).Receive(&response, nil)
+	if err != nil {
+		return Exclusion{}, errors.ErrInternalServerError().SetDetail("Failed to add Radarr exclusion")
+	}
+
+	if res.StatusCode == fiber.ErrUnauthorized.Code {
+		return Exclusion{}, ErrUnauthorizedRadarrRequest
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return Exclusion{}, fmt.Errorf("radarr api returned status %d when adding exclusion for TMDb ID %d", res.StatusCode, exclusion.TmdbID)
+	}
+
+	return response, nil
+}
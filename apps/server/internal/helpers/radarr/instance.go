@@ -7,6 +7,7 @@ import (
 
 	"github.com/dghubble/sling"
 	"github.com/gofiber/fiber/v2"
+	"github.com/mahcks/blockbusterr/internal/db"
 	"github.com/mahcks/blockbusterr/internal/global"
 	"github.com/mahcks/blockbusterr/pkg/errors"
 )
@@ -15,10 +16,27 @@ type Service interface {
 	GetRootFolders(url, apiKey *string) (GetRootFoldersResponse, error)
 	GetQualityProfiles(url, apiKey *string) (GetQualityProfilesResponse, error)
 	RequestMovie(url *string, apiKey *string, body RequestMovieBody) (RequestMovieResponse, error)
+	GetMovies(url, apiKey *string) (GetMoviesResponse, error)
+	UnmonitorMovie(url, apiKey *string, id int) error
+	DeleteMovie(url, apiKey *string, id int, deleteFiles bool) error
+	GetTags(url, apiKey *string) ([]Tag, error)
+	CreateTag(url, apiKey *string, label string) (Tag, error)
+	GetExclusions(url, apiKey *string) ([]Exclusion, error)
+	AddExclusion(url, apiKey *string, exclusion Exclusion) (Exclusion, error)
+	// Instance returns a service that talks to the Radarr instance with the given ID instead of the first one
+	Instance(id int) Service
 }
 
 type radarrService struct {
-	gctx global.Context
+	gctx       global.Context
+	instanceID int // Radarr instance requests go to, 0 for the first instance
+}
+
+func (r *radarrService) Instance(id int) Service {
+	return &radarrService{
+		gctx:       r.gctx,
+		instanceID: id,
+	}
 }
 
 var ErrUnauthorizedRadarrRequest = errors.ErrUnauthorized().SetDetail("Unauthorized access to Radarr")
@@ -36,7 +54,13 @@ func (r *radarrService) FetchRadarrURLFromDB(url, apiKey *string) (*sling.Sling,
 	}
 
 	// Fetch Radarr settings from the database if URL or API key is not provided
-	radarrSettings, err := r.gctx.Crate().SQL.Queries().GetRadarrSettings(r.gctx)
+	var radarrSettings db.RadarrSettings
+	var err error
+	if r.instanceID != 0 {
+		radarrSettings, err = r.gctx.Crate().SQL.Queries().GetRadarrInstance(r.gctx, r.instanceID)
+	} else {
+		radarrSettings, err = r.gctx.Crate().SQL.Queries().GetRadarrSettings(r.gctx)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -53,7 +77,10 @@ func (r *radarrService) FetchRadarrURLFromDB(url, apiKey *string) (*sling.Sling,
 
 	// Use the database values for URL and API key if they are not provided
 	realURL := radarrSettings.URL.String
-	realAPIKey := radarrSettings.APIKey.String
+	realAPIKey, err := r.gctx.Crate().Secrets.Decrypt(radarrSettings.APIKey.String)
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting Radarr API key: %w", err)
+	}
 
 	base := sling.New().Base(realURL).
 		Set("Content-Type", "application/json").
@@ -149,6 +176,7 @@ func (r *radarrService) GetQualityProfiles(url, apiKey *string) (GetQualityProfi
 }
 
 type Movie struct {
+	ID                    int                        `json:"id"`
 	Title                 string                     `json:"title"`
 	OriginalTitle         string                     `json:"originalTitle"`
 	OriginalLanguage      Language                   `json:"originalLanguage"`
@@ -247,7 +275,9 @@ type RequestMovieBody struct {
 	Monitored bool `json:"monitored"`
 	// The minimum availability setting for the film. The user can choose from "announced", "in_cinemas", or "released".
 	MinimumAvailability string `json:"minimumAvailability"`
-	AddOptions          struct {
+	// IDs of the tags the film is added with
+	Tags       []int `json:"tags,omitempty"`
+	AddOptions struct {
 		// Whether to search for the film when added to Radarr
 		SearchForMovie bool `json:"searchForMovie"`
 	} `json:"addOptions"`
@@ -310,3 +340,84 @@ func (r *radarrService) RequestMovie(url *string, apiKey *string, body RequestMo
 	// If no specific errors were captured, return a generic error
 	return RequestMovieResponse{}, fmt.Errorf("radarr api returned an unexpected error")
 }
+
+type GetMoviesResponse []Movie
+
+// GetMovies returns every movie in the Radarr library
+func (r *radarrService) GetMovies(url, apiKey *string) (GetMoviesResponse, error) {
+	baseURL, err := r.FetchRadarrURLFromDB(url, apiKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var response GetMoviesResponse
+	res, err := baseURL.New().Get("/api/v3/movie").Receive(&response, nil)
+	if err != nil {
+		return nil, errors.ErrInternalServerError().SetDetail("Failed to get Radarr movies")
+	}
+
+	if res.StatusCode == fiber.ErrUnauthorized.Code {
+		return nil, ErrUnauthorizedRadarrRequest
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, fmt.Errorf("radarr api returned status %d when listing movies", res.StatusCode)
+	}
+
+	return response, nil
+}
+
+// UnmonitorMovie stops Radarr from monitoring the movie with the given Radarr ID
+func (r *radarrService) UnmonitorMovie(url, apiKey *string, id int) error {
+	baseURL, err := r.FetchRadarrURLFromDB(url, apiKey)
+	if err != nil {
+		return err
+	}
+
+	body := struct {
+		MovieIDs  []int `json:"movieIds"`
+		Monitored bool  `json:"monitored"`
+	}{MovieIDs: []int{id}}
+
+	res, err := baseURL.New().Put("/api/v3/movie/editor").BodyJSON(body).Receive(nil, nil)
+	if err != nil {
+		return errors.ErrInternalServerError().SetDetail("Failed to unmonitor Radarr movie")
+	}
+
+	if res.StatusCode == fiber.ErrUnauthorized.Code {
+		return ErrUnauthorizedRadarrRequest
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("radarr api returned status %d when unmonitoring movie %d", res.StatusCode, id)
+	}
+
+	return nil
+}
+
+// DeleteMovie removes the movie with the given Radarr ID from the library, along with its files if deleteFiles is set
+func (r *radarrService) DeleteMovie(url, apiKey *string, id int, deleteFiles bool) error {
+	baseURL, err := r.FetchRadarrURLFromDB(url, apiKey)
+	if err != nil {
+		return err
+	}
+
+	params := struct {
+		DeleteFiles bool `url:"deleteFiles"`
+	}{DeleteFiles: deleteFiles}
+
+	res, err := baseURL.New().Delete(fmt.Sprintf("/api/v3/movie/%d", id)).QueryStruct(params).Receive(nil, nil)
+	if err != nil {
+		return errors.ErrInternalServerError().SetDetail("Failed to delete Radarr movie")
+	}
+
+	if res.StatusCode == fiber.ErrUnauthorized.Code {
+		return ErrUnauthorizedRadarrRequest
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("radarr api returned status %d when deleting movie %d", res.StatusCode, id)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package radarr
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/mahcks/blockbusterr/pkg/errors"
+)
+
+// Tag is a label Radarr can attach to a movie
+type Tag struct {
+	ID    int    `json:"id"`
+	Label string `json:"label"`
+}
+
+// GetTags returns every tag in Radarr
+func (r *radarrService) GetTags(url, apiKey *string) ([]Tag, error) {
+	baseURL, err := r.FetchRadarrURLFromDB(url, apiKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var response []Tag
+	res, err := baseURL.New().Get("/api/v3/tag").Receive(&response, nil)
+	if err != nil {
+		return nil, errors.ErrInternalServerError().SetDetail("Failed to get Radarr tags")
+	}
+
+	if res.StatusCode == fiber.ErrUnauthorized.Code {
+		return nil, ErrUnauthorizedRadarrRequest
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, fmt.Errorf("radarr api returned status %d when listing tags", res.StatusCode)
+	}
+
+	return response, nil
+}
+
+// CreateTag adds a tag to Radarr. Radarr only accepts lowercase letters, digits and dashes in a label.
+func (r *radarrService) CreateTag(url, apiKey *string, label string) (Tag, error) {
+	baseURL, err := r.FetchRadarrURLFromDB(url, apiKey)
+	if err != nil {
+		return Tag{}, err
+	}
+
+	var response Tag
+	res, err := baseURL.New().Post("/api/v3/tag").BodyJSON(Tag{Label: label}).Receive(&response, nil)
+	if err != nil {
+		return Tag{}, errors.ErrInternalServerError().SetDetail("Failed to create Radarr tag")
+	}
+
+	if res.StatusCode == fiber.ErrUnauthorized.Code {
+		return Tag{}, ErrUnauthorizedRadarrRequest
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return Tag{}, fmt.Errorf("radarr api returned status %d when creating tag %q", res.StatusCode, label)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,63 @@
+package sonarr
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/mahcks/blockbusterr/pkg/errors"
+)
+
+// ImportListExclusion is a series on the Sonarr import list exclusions, which import lists never add again
+type ImportListExclusion struct {
+	ID     int    `json:"id,omitempty"`
+	TvdbID int    `json:"tvdbId"`
+	Title  string `json:"title"`
+}
+
+// GetImportListExclusions returns every series on the Sonarr import list exclusions
+func (r *sonarrService) GetImportListExclusions(url, apiKey *string) ([]ImportListExclusion, error) {
+	baseURL, err := r.FetchSonarrURLFromDB(url, apiKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var response []ImportListExclusion
+	res, err := baseURL.New().Get("/api/v3/importlistexclusion").Receive(&response, nil)
+	if err != nil {
+		return nil, errors.ErrInternalServerError().SetDetail("Failed to get Sonarr import list exclusions")
+	}
+
+	if res.StatusCode == fiber.ErrUnauthorized.Code {
+		return nil, ErrUnauthorizedSonarrRequest
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, fmt.Errorf("sonarr api returned status %d when listing import list exclusions", res.StatusCode)
+	}
+
+	return response, nil
+}
+
+// AddImportListExclusion puts a series on the Sonarr import list exclusions
+func (r *sonarrService) AddImportListExclusion(url, apiKey *string, exclusion ImportListExclusion) (ImportListExclusion, error) {
+	baseURL, err := r.FetchSonarrURLFromDB(url, apiKey)
+	if err != nil {
+		return ImportListExclusion{}, err
+	}
+
+	var response ImportListExclusion
+	res, err := baseURL.New().Post("/api/v3/importlistexclusion").BodyJSON(exclusion).Receive(&response, nil)
+	if err != nil {
+		return ImportListExclusion{}, errors.ErrInternalServerError().SetDetail("Failed to add Sonarr import list exclusion")
+	}
+
+	if res.StatusCode == fiber.ErrUnauthorized.Code {
+		return ImportListExclusion{}, ErrUnauthorizedSonarrRequest
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return ImportListExclusion{}, fmt.Errorf("sonarr api returned status %d when adding import list exclusion for TVDB ID %d", res.StatusCode, exclusion.TvdbID)
+	}
+
+	return response, nil
+}
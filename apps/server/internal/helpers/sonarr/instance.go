@@ -7,6 +7,7 @@ import (
 
 	"github.com/dghubble/sling"
 	"github.com/gofiber/fiber/v2"
+	"github.com/mahcks/blockbusterr/internal/db"
 	"github.com/mahcks/blockbusterr/internal/global"
 	"github.com/mahcks/blockbusterr/pkg/errors"
 )
@@ -15,10 +16,27 @@ type Service interface {
 	GetRootFolders(url, apiKey *string) (GetRootFoldersResponse, error)
 	GetQualityProfiles(url, apiKey *string) (GetQualityProfilesResponse, error)
 	RequestSeries(ctx context.Context, url *string, apiKey *string, body RequestSeriesBody) (RequestSeriesResponse, error)
+	GetSeries(url, apiKey *string) (GetSeriesResponse, error)
+	UnmonitorSeries(url, apiKey *string, id int) error
+	DeleteSeries(url, apiKey *string, id int, deleteFiles bool) error
+	GetTags(url, apiKey *string) ([]Tag, error)
+	CreateTag(url, apiKey *string, label string) (Tag, error)
+	GetImportListExclusions(url, apiKey *string) ([]ImportListExclusion, error)
+	AddImportListExclusion(url, apiKey *string, exclusion ImportListExclusion) (ImportListExclusion, error)
+	// Instance returns a service that talks to the Sonarr instance with the given ID instead of the first one
+	Instance(id int) Service
 }
 
 type sonarrService struct {
-	gctx global.Context
+	gctx       global.Context
+	instanceID int // Sonarr instance requests go to, 0 for the first instance
+}
+
+func (r *sonarrService) Instance(id int) Service {
+	return &sonarrService{
+		gctx:       r.gctx,
+		instanceID: id,
+	}
 }
 
 func (r *sonarrService) FetchSonarrURLFromDB(url, apiKey *string) (*sling.Sling, error) {
@@ -28,7 +46,13 @@ func (r *sonarrService) FetchSonarrURLFromDB(url, apiKey *string) (*sling.Sling,
 			Set("X-Api-Key", *apiKey), nil
 	}
 
-	sonarrSettings, err := r.gctx.Crate().SQL.Queries().GetSonarrSettings(r.gctx)
+	var sonarrSettings db.SonarrSettings
+	var err error
+	if r.instanceID != 0 {
+		sonarrSettings, err = r.gctx.Crate().SQL.Queries().GetSonarrInstance(r.gctx, r.instanceID)
+	} else {
+		sonarrSettings, err = r.gctx.Crate().SQL.Queries().GetSonarrSettings(r.gctx)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -44,7 +68,10 @@ func (r *sonarrService) FetchSonarrURLFromDB(url, apiKey *string) (*sling.Sling,
 	}
 
 	realURL := sonarrSettings.URL.String
-	realAPIKey := sonarrSettings.APIKey.String
+	realAPIKey, err := r.gctx.Crate().Secrets.Decrypt(sonarrSettings.APIKey.String)
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting Sonarr API key: %w", err)
+	}
 
 	base := sling.New().Base(realURL).
 		Set("Content-Type", "application/json").
@@ -170,7 +197,7 @@ type Series struct {
 	RootFolderPath    string           `json:"rootFolderPath"`
 	Certification     string           `json:"certification"`
 	Genres            []string         `json:"genres"`
-	Tags              []string         `json:"tags"`
+	Tags              []int            `json:"tags"`
 	Added             time.Time        `json:"added"`
 	AddOptions        AddOptions       `json:"addOptions"`
 	Ratings           Ratings          `json:"ratings"`
@@ -238,10 +265,14 @@ type RequestSeriesBody struct {
 	QualityProfileID int    `json:"qualityProfileId"`
 	RootFolderPath   string `json:"rootFolderPath"`
 	Monitored        bool   `json:"monitored"`
+	SeriesType       string `json:"seriesType,omitempty"` // "standard", "anime" or "daily"
+	SeasonFolder     bool   `json:"seasonFolder"`
+	Tags             []int  `json:"tags,omitempty"` // IDs of the tags the series is added with
 	AddOptions       struct {
-		SearchForMissingEpisodes     bool `json:"searchForMissingEpisodes"`
-		SearchForCutoffUnmetEpisodes bool `json:"searchForCutoffUnmetEpisodes"`
-	}
+		Monitor                      string `json:"monitor,omitempty"` // Episodes to monitor, e.g. "all", "future" or "pilot"
+		SearchForMissingEpisodes     bool   `json:"searchForMissingEpisodes"`
+		SearchForCutoffUnmetEpisodes bool   `json:"searchForCutoffUnmetEpisodes"`
+	} `json:"addOptions"`
 }
 
 type RequestShowError struct {
@@ -300,3 +331,84 @@ func (r *sonarrService) RequestSeries(ctx context.Context, url *string, apiKey *
 	// If no specific errors were captured, return a generic error
 	return RequestSeriesResponse{}, fmt.Errorf("radarr api returned an unexpected error")
 }
+
+type GetSeriesResponse []Series
+
+// GetSeries returns every series in the Sonarr library
+func (r *sonarrService) GetSeries(url, apiKey *string) (GetSeriesResponse, error) {
+	baseURL, err := r.FetchSonarrURLFromDB(url, apiKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var response GetSeriesResponse
+	res, err := baseURL.New().Get("/api/v3/series").Receive(&response, nil)
+	if err != nil {
+		return nil, errors.ErrInternalServerError().SetDetail("Failed to get Sonarr series")
+	}
+
+	if res.StatusCode == fiber.ErrUnauthorized.Code {
+		return nil, ErrUnauthorizedSonarrRequest
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, fmt.Errorf("sonarr api returned status %d when listing series", res.StatusCode)
+	}
+
+	return response, nil
+}
+
+// UnmonitorSeries stops Sonarr from monitoring the series with the given Sonarr ID
+func (r *sonarrService) UnmonitorSeries(url, apiKey *string, id int) error {
+	baseURL, err := r.FetchSonarrURLFromDB(url, apiKey)
+	if err != nil {
+		return err
+	}
+
+	body := struct {
+		SeriesIDs []int `json:"seriesIds"`
+		Monitored bool  `json:"monitored"`
+	}{SeriesIDs: []int{id}}
+
+	res, err := baseURL.New().Put("/api/v3/series/editor").BodyJSON(body).Receive(nil, nil)
+	if err != nil {
+		return errors.ErrInternalServerError().SetDetail("Failed to unmonitor Sonarr series")
+	}
+
+	if res.StatusCode == fiber.ErrUnauthorized.Code {
+		return ErrUnauthorizedSonarrRequest
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("sonarr api returned status %d when unmonitoring series %d", res.StatusCode, id)
+	}
+
+	return nil
+}
+
+// DeleteSeries removes the series with the given Sonarr ID from the library, along with its files if deleteFiles is set
+func (r *sonarrService) DeleteSeries(url, apiKey *string, id int, deleteFiles bool) error {
+	baseURL, err := r.FetchSonarrURLFromDB(url, apiKey)
+	if err != nil {
+		return err
+	}
+
+	params := struct {
+		DeleteFiles bool `url:"deleteFiles"`
+	}{DeleteFiles: deleteFiles}
+
+	res, err := baseURL.New().Delete(fmt.Sprintf("/api/v3/series/%d", id)).QueryStruct(params).Receive(nil, nil)
+	if err != nil {
+		return errors.ErrInternalServerError().SetDetail("Failed to delete Sonarr series")
+	}
+
+	if res.StatusCode == fiber.ErrUnauthorized.Code {
+		return ErrUnauthorizedSonarrRequest
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("sonarr api returned status %d when deleting series %d", res.StatusCode, id)
+	}
+
+	return nil
+}
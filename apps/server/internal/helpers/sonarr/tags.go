@@ -0,0 +1,62 @@
+package sonarr
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/mahcks/blockbusterr/pkg/errors"
+)
+
+// Tag is a label Sonarr can attach to a series
+type Tag struct {
+	ID    int    `json:"id"`
+	Label string `json:"label"`
+}
+
+// GetTags returns every tag in Sonarr
+func (r *sonarrService) GetTags(url, apiKey *string) ([]Tag, error) {
+	baseURL, err := r.FetchSonarrURLFromDB(url, apiKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var response []Tag
+	res, err := baseURL.New().Get("/api/v3/tag").Receive(&response, nil)
+	if err != nil {
+		return nil, errors.ErrInternalServerError().SetDetail("Failed to get Sonarr tags")
+	}
+
+	if res.StatusCode == fiber.ErrUnauthorized.Code {
+		return nil, ErrUnauthorizedSonarrRequest
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, fmt.Errorf("sonarr api returned status %d when listing tags", res.StatusCode)
+	}
+
+	return response, nil
+}
+
+// CreateTag adds a tag to Sonarr. Sonarr only accepts lowercase letters, digits and dashes in a label.
+func (r *sonarrService) CreateTag(url, apiKey *string, label string) (Tag, error) {
+	baseURL, err := r.FetchSonarrURLFromDB(url, apiKey)
+	if err != nil {
+		return Tag{}, err
+	}
+
+	var response Tag
+	res, err := baseURL.New().Post("/api/v3/tag").BodyJSON(Tag{Label: label}).Receive(&response, nil)
+	if err != nil {
+		return Tag{}, errors.ErrInternalServerError().SetDetail("Failed to create Sonarr tag")
+	}
+
+	if res.StatusCode == fiber.ErrUnauthorized.Code {
+		return Tag{}, ErrUnauthorizedSonarrRequest
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return Tag{}, fmt.Errorf("sonarr api returned status %d when creating tag %q", res.StatusCode, label)
+	}
+
+	return response, nil
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
 
 	"github.com/dghubble/sling"
 	"github.com/mahcks/blockbusterr/internal/db"
@@ -20,17 +23,35 @@ type Service interface {
 	GetBoxOfficeMovies(ctx context.Context, params *TraktMovieParams) ([]TraktBoxOfficeMovie, error)
 	GetMostWatchedMovies(ctx context.Context, params *TraktMovieParams) ([]TraktMostWatchedMovie, error)
 	GetMostPlayedMovies(ctx context.Context, params *TraktMovieParams) (GetMostPlayedMoviesResponse, error)
+	GetMostCollectedMovies(ctx context.Context, params *TraktMovieParams) ([]TraktMostWatchedMovie, error)
 
 	GetAnticipatedShows(ctx context.Context, params *TraktMovieParams) (GetAnticipatedShowsResponse, error)
 	GetPopularShows(ctx context.Context, params *TraktMovieParams) (GetPopularShowsResponse, error)
 	GetTrendingShows(ctx context.Context, params *TraktMovieParams) (GetTrendingShowsResponse, error)
-
-	GetListItems(ctx context.Context, parms *GetListItemsParams) (GetListItemsResponse, error)
+	GetMostWatchedShows(ctx context.Context, params *TraktMovieParams) ([]TraktMostWatchedShow, error)
+	GetMostPlayedShows(ctx context.Context, params *TraktMovieParams) ([]TraktMostWatchedShow, error)
+	GetMostCollectedShows(ctx context.Context, params *TraktMovieParams) ([]TraktMostWatchedShow, error)
+
+	GetListItems(ctx context.Context, params *GetListItemsParams) (GetListItemsResponse, error)
+	GetWatchlist(ctx context.Context, params *GetListItemsParams) (GetListItemsResponse, error)
+	GetMovieRecommendations(ctx context.Context, params *GetRecommendationsParams) ([]Movie, error)
+	GetShowRecommendations(ctx context.Context, params *GetRecommendationsParams) ([]Show, error)
+
+	// OAuth device-code flow, lists, watchlists and recommendations use the authorized account's token when there is one
+	StartDeviceAuth(ctx context.Context) (DeviceCode, error)
+	PollDeviceAuth(ctx context.Context, deviceCode string) error
+	GetAuthStatus(ctx context.Context) (AuthStatus, error)
+	RevokeAuth(ctx context.Context) error
+	RefreshAuth(ctx context.Context) error
+
+	GetWatchedMovies(ctx context.Context) ([]WatchedMovie, error)
+	GetWatchedShows(ctx context.Context) ([]WatchedShow, error)
 }
 
 type traktService struct {
-	gctx global.Context
-	base *sling.Sling
+	gctx    global.Context
+	base    *sling.Sling
+	tokenMu sync.Mutex // Keeps concurrent requests from refreshing the access token twice
 }
 
 // Movie params for every Trakt API movie request
@@ -52,6 +73,19 @@ type TraktMovieParams struct {
 	// Pagination
 	Page  int `url:"page,omitempty"`
 	Limit int `url:"limit,omitempty"`
+
+	// Time period of the watched, played and collected lists, either `daily`, `weekly`, `monthly`, `yearly` or `all`.
+	// Trakt defaults to `weekly` when it's empty.
+	Period string `url:"-"`
+}
+
+// periodPath appends the period to the path of a watched, played or collected list
+func periodPath(path string, params *TraktMovieParams) string {
+	if params == nil || params.Period == "" {
+		return path
+	}
+
+	return path + "/" + url.PathEscape(params.Period)
 }
 
 // Movie is a struct that represents a movie from the Trakt API
@@ -146,7 +180,11 @@ type ShowAirs struct {
 	Timezone string `json:"timezone"`
 }
 
-var ErrNoTraktSettings = errors.New("no trakt settings found")
+var (
+	ErrNoTraktSettings   = errors.New("no trakt settings found")
+	ErrTraktUnauthorized = errors.New("trakt denied access, the list may be private or need an authorized account")
+	ErrTraktListNotFound = errors.New("trakt list not found")
+)
 
 func (t *traktService) FetchClientIDFromDB(ctx context.Context) (string, error) {
 	// Use parameterized query with context to prevent SQL injection
@@ -243,7 +281,7 @@ func (t *traktService) GetBoxOfficeMovies(ctx context.Context, params *TraktMovi
 type TraktMostWatchedMovie struct {
 	WatcherCount   int   `json:"watcher_count"`
 	PlayCount      int   `json:"play_count"`
-	CollectedCount int   `json:"collected"`
+	CollectedCount int   `json:"collected_count"`
 	Movie          Movie `json:"movie"`
 }
 
@@ -254,7 +292,7 @@ func (t *traktService) GetMostWatchedMovies(ctx context.Context, params *TraktMo
 	}
 
 	var movies []TraktMostWatchedMovie
-	_, err = t.base.New().Set("trakt-api-key", clientID).QueryStruct(params).Get("/movies/watched").ReceiveSuccess(&movies)
+	_, err = t.base.New().Set("trakt-api-key", clientID).QueryStruct(params).Get(periodPath("/movies/watched", params)).ReceiveSuccess(&movies)
 	if err != nil {
 		return nil, err
 	}
@@ -282,7 +320,7 @@ func (t *traktService) GetMostPlayedMovies(ctx context.Context, params *TraktMov
 	var response GetMostPlayedMoviesResponse
 
 	var movies []MostPlayedMovie
-	_, err = t.base.New().Set("trakt-api-key", clientID).QueryStruct(params).Get("/movies/played").ReceiveSuccess(&movies)
+	_, err = t.base.New().Set("trakt-api-key", clientID).QueryStruct(params).Get(periodPath("/movies/played", params)).ReceiveSuccess(&movies)
 	if err != nil {
 		return GetMostPlayedMoviesResponse{}, err
 	}
@@ -292,6 +330,26 @@ func (t *traktService) GetMostPlayedMovies(ctx context.Context, params *TraktMov
 	return response, err
 }
 
+// GetMostCollectedMovies returns the movies collected by the most users in the period
+func (t *traktService) GetMostCollectedMovies(ctx context.Context, params *TraktMovieParams) ([]TraktMostWatchedMovie, error) {
+	clientID, err := t.FetchClientIDFromDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var movies []TraktMostWatchedMovie
+	res, err := t.base.New().Set("trakt-api-key", clientID).QueryStruct(params).Get(periodPath("/movies/collected", params)).ReceiveSuccess(&movies)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != 200 {
+		return nil, fmt.Errorf("failed to get most collected movies: %v", res.Status)
+	}
+
+	return movies, err
+}
+
 type GetAnticipatedShowsResponse []AnticipatedShow
 
 type AnticipatedShow struct {
@@ -361,39 +419,179 @@ func (t *traktService) GetTrendingShows(ctx context.Context, params *TraktMovieP
 	return response, err
 }
 
+// TraktMostWatchedShow is an entry of the most watched, played and collected show lists
+type TraktMostWatchedShow struct {
+	WatcherCount   int  `json:"watcher_count"`
+	PlayCount      int  `json:"play_count"`
+	CollectedCount int  `json:"collected_count"`
+	CollectorCount int  `json:"collector_count"`
+	Show           Show `json:"show"`
+}
+
+// GetMostWatchedShows returns the shows watched by the most users in the period
+func (t *traktService) GetMostWatchedShows(ctx context.Context, params *TraktMovieParams) ([]TraktMostWatchedShow, error) {
+	return t.getMostWatchedShows(ctx, "/shows/watched", params)
+}
+
+// GetMostPlayedShows returns the shows with the most plays in the period
+func (t *traktService) GetMostPlayedShows(ctx context.Context, params *TraktMovieParams) ([]TraktMostWatchedShow, error) {
+	return t.getMostWatchedShows(ctx, "/shows/played", params)
+}
+
+// GetMostCollectedShows returns the shows collected by the most users in the period
+func (t *traktService) GetMostCollectedShows(ctx context.Context, params *TraktMovieParams) ([]TraktMostWatchedShow, error) {
+	return t.getMostWatchedShows(ctx, "/shows/collected", params)
+}
+
+func (t *traktService) getMostWatchedShows(ctx context.Context, path string, params *TraktMovieParams) ([]TraktMostWatchedShow, error) {
+	clientID, err := t.FetchClientIDFromDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var shows []TraktMostWatchedShow
+	res, err := t.base.New().Set("trakt-api-key", clientID).QueryStruct(params).Get(periodPath(path, params)).ReceiveSuccess(&shows)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != 200 {
+		return nil, fmt.Errorf("failed to get shows from %s: %v", path, res.Status)
+	}
+
+	return shows, nil
+}
+
 type GetListItemsParams struct {
-	ListID    int    `url:"id"`
-	MediaType string `url:"type"`
+	// Trakt username or slug of the user the list belongs to
+	User string `url:"-"`
+	// Slug or Trakt ID of the list
+	List string `url:"-"`
+	// Either `movies` or `shows`
+	MediaType string `url:"-"`
+	// Either `full` or `metadata`
+	Extended string `url:"extended,omitempty"`
 }
 
 type GetListItemsResponse []ListItem
 
 type ListItem struct {
-	Rake     int     `json:"rank"`
+	Rank     int     `json:"rank"`
 	ID       int     `json:"id"`
 	ListedAt string  `json:"listed_at"`
 	Notes    *string `json:"notes"`
 	Type     string  `json:"type"`
-	Movie    Movie   `json:"movie"`
+	Movie    *Movie  `json:"movie,omitempty"`
+	Show     *Show   `json:"show,omitempty"`
 }
 
+// listItemType maps the media type used in the list endpoints to the type of the items they return
+var listItemType = map[string]string{
+	"movies": "movie",
+	"shows":  "show",
+}
+
+// GetListItems returns the movies or shows on a user's list
 func (t *traktService) GetListItems(ctx context.Context, params *GetListItemsParams) (GetListItemsResponse, error) {
+	itemType, ok := listItemType[params.MediaType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported list media type %q", params.MediaType)
+	}
+
+	path := fmt.Sprintf("/users/%s/lists/%s/items/%s", url.PathEscape(params.User), url.PathEscape(params.List), itemType)
+	return t.getListItems(ctx, path, params)
+}
+
+// GetWatchlist returns the movies or shows on a user's watchlist
+func (t *traktService) GetWatchlist(ctx context.Context, params *GetListItemsParams) (GetListItemsResponse, error) {
+	if _, ok := listItemType[params.MediaType]; !ok {
+		return nil, fmt.Errorf("unsupported watchlist media type %q", params.MediaType)
+	}
+
+	path := fmt.Sprintf("/users/%s/watchlist/%s", url.PathEscape(params.User), params.MediaType)
+	return t.getListItems(ctx, path, params)
+}
+
+func (t *traktService) getListItems(ctx context.Context, path string, params *GetListItemsParams) (GetListItemsResponse, error) {
 	clientID, err := t.FetchClientIDFromDB(ctx)
 	if err != nil {
 		return GetListItemsResponse{}, err
 	}
 
+	// Private lists and watchlists are only visible to their owner
+	req, err := t.authorize(ctx, t.base.New().Set("trakt-api-key", clientID))
+	if err != nil {
+		return GetListItemsResponse{}, err
+	}
+
 	var response GetListItemsResponse
-	res, err := t.base.New().Set("trakt-api-key", clientID).QueryStruct(params).Get("/shows/trending").ReceiveSuccess(&response)
+	res, err := req.QueryStruct(params).Get(path).ReceiveSuccess(&response)
 	if err != nil {
 		return GetListItemsResponse{}, err
 	}
 
-	if res.StatusCode != 200 {
-		return GetListItemsResponse{}, fmt.Errorf("failed to get trending shows: %v", res.Status)
+	switch res.StatusCode {
+	case http.StatusOK:
+		return response, nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return GetListItemsResponse{}, fmt.Errorf("%w: %s", ErrTraktUnauthorized, path)
+	case http.StatusNotFound:
+		return GetListItemsResponse{}, fmt.Errorf("%w: %s", ErrTraktListNotFound, path)
+	default:
+		return GetListItemsResponse{}, fmt.Errorf("failed to get list items from %s: %v", path, res.Status)
+	}
+}
+
+type GetRecommendationsParams struct {
+	// Either `movies` or `shows`
+	MediaType string `url:"-"`
+	// Either `full` or `metadata`
+	Extended string `url:"extended,omitempty"`
+	// Maximum number of recommendations, Trakt returns at most 100
+	Limit int `url:"limit,omitempty"`
+	// Leave out titles already in a collection
+	IgnoreCollected bool `url:"ignore_collected,omitempty"`
+}
+
+// GetMovieRecommendations returns the movies Trakt recommends to the authorized user
+func (t *traktService) GetMovieRecommendations(ctx context.Context, params *GetRecommendationsParams) ([]Movie, error) {
+	var movies []Movie
+	err := t.getRecommendations(ctx, "/recommendations/movies", params, &movies)
+	return movies, err
+}
+
+// GetShowRecommendations returns the shows Trakt recommends to the authorized user
+func (t *traktService) GetShowRecommendations(ctx context.Context, params *GetRecommendationsParams) ([]Show, error) {
+	var shows []Show
+	err := t.getRecommendations(ctx, "/recommendations/shows", params, &shows)
+	return shows, err
+}
+
+func (t *traktService) getRecommendations(ctx context.Context, path string, params *GetRecommendationsParams, response interface{}) error {
+	clientID, err := t.FetchClientIDFromDB(ctx)
+	if err != nil {
+		return err
+	}
+
+	req, err := t.authorize(ctx, t.base.New().Set("trakt-api-key", clientID))
+	if err != nil {
+		return err
 	}
 
-	return response, nil
+	res, err := req.QueryStruct(params).Get(path).ReceiveSuccess(response)
+	if err != nil {
+		return err
+	}
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		// Recommendations are personal, so they need an authorized Trakt account
+		return fmt.Errorf("%w: %s", ErrTraktUnauthorized, path)
+	default:
+		return fmt.Errorf("failed to get recommendations from %s: %v", path, res.Status)
+	}
 }
 
 func (t *traktService) Ping(ctx context.Context) error {
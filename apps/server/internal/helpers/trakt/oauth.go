@@ -0,0 +1,344 @@
+package trakt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/dghubble/sling"
+	"github.com/mahcks/blockbusterr/internal/db"
+)
+
+// tokenRefreshWindow is how long before it expires the access token is refreshed
+const tokenRefreshWindow = 24 * time.Hour
+
+// Redirect URI Trakt expects for apps that authorize through the device-code flow
+const deviceRedirectURI = "urn:ietf:wg:oauth:2.0:oob"
+
+var (
+	ErrTraktNotAuthorized = errors.New("no trakt account is authorized")
+	ErrAuthPending        = errors.New("waiting for the user to authorize the device code")
+	ErrAuthSlowDown       = errors.New("polling too quickly, wait for the interval before polling again")
+	ErrAuthInvalidCode    = errors.New("invalid device code")
+	ErrAuthCodeUsed       = errors.New("device code was already used")
+	ErrAuthExpired        = errors.New("device code expired, start the authorization again")
+	ErrAuthDenied         = errors.New("the user denied the authorization")
+)
+
+// DeviceCode is returned when the device-code flow is started. The user enters
+// the user code at the verification URL while the device code is polled.
+type DeviceCode struct {
+	DeviceCode      string `json:"device_code"`
+	UserCode        string `json:"user_code"`
+	VerificationURL string `json:"verification_url"`
+	ExpiresIn       int    `json:"expires_in"` // Seconds until the codes expire
+	Interval        int    `json:"interval"`   // Seconds to wait between polls
+}
+
+// AuthStatus describes the Trakt account the OAuth tokens belong to
+type AuthStatus struct {
+	Authorized bool       `json:"authorized"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"` // Time the access token expires, it's refreshed before then
+}
+
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+	Scope        string `json:"scope"`
+	CreatedAt    int64  `json:"created_at"`
+}
+
+// fetchClientCredentials returns the client ID and decrypted client secret
+func (t *traktService) fetchClientCredentials(ctx context.Context) (db.TraktSettings, string, error) {
+	settings, err := t.gctx.Crate().SQL.Queries().GetTraktSettings(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNoTraktSettings) {
+			return settings, "", ErrNoTraktSettings
+		}
+		return settings, "", err
+	}
+
+	clientSecret, err := t.gctx.Crate().Secrets.Decrypt(settings.ClientSecret)
+	if err != nil {
+		return settings, "", fmt.Errorf("error decrypting Trakt client secret: %w", err)
+	}
+
+	return settings, clientSecret, nil
+}
+
+// StartDeviceAuth starts the device-code flow and returns the codes to show the user
+func (t *traktService) StartDeviceAuth(ctx context.Context) (DeviceCode, error) {
+	clientID, err := t.FetchClientIDFromDB(ctx)
+	if err != nil {
+		return DeviceCode{}, err
+	}
+
+	var code DeviceCode
+	res, err := t.base.New().Post("/oauth/device/code").BodyJSON(map[string]string{"client_id": clientID}).ReceiveSuccess(&code)
+	if err != nil {
+		return DeviceCode{}, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return DeviceCode{}, fmt.Errorf("failed to start Trakt device authorization: %v", res.Status)
+	}
+
+	return code, nil
+}
+
+// PollDeviceAuth checks whether the user authorized the device code and stores the tokens once they did.
+// It returns ErrAuthPending until then.
+func (t *traktService) PollDeviceAuth(ctx context.Context, deviceCode string) error {
+	settings, clientSecret, err := t.fetchClientCredentials(ctx)
+	if err != nil {
+		return err
+	}
+
+	body := map[string]string{
+		"code":          deviceCode,
+		"client_id":     settings.ClientID,
+		"client_secret": clientSecret,
+	}
+
+	var token tokenResponse
+	res, err := t.base.New().Post("/oauth/device/token").BodyJSON(body).ReceiveSuccess(&token)
+	if err != nil {
+		return err
+	}
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		return t.storeToken(ctx, token)
+	case http.StatusBadRequest:
+		return ErrAuthPending
+	case http.StatusNotFound:
+		return ErrAuthInvalidCode
+	case http.StatusConflict:
+		return ErrAuthCodeUsed
+	case http.StatusGone:
+		return ErrAuthExpired
+	case http.StatusTeapot:
+		return ErrAuthDenied
+	case http.StatusTooManyRequests:
+		return ErrAuthSlowDown
+	default:
+		return fmt.Errorf("failed to poll Trakt device authorization: %v", res.Status)
+	}
+}
+
+// GetAuthStatus returns whether a Trakt account is authorized
+func (t *traktService) GetAuthStatus(ctx context.Context) (AuthStatus, error) {
+	settings, err := t.gctx.Crate().SQL.Queries().GetTraktSettings(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNoTraktSettings) {
+			return AuthStatus{}, nil
+		}
+		return AuthStatus{}, err
+	}
+
+	status := AuthStatus{Authorized: settings.AccessToken.Valid && settings.AccessToken.String != ""}
+	if status.Authorized && settings.TokenExpiresAt.Valid {
+		status.ExpiresAt = &settings.TokenExpiresAt.Time
+	}
+
+	return status, nil
+}
+
+// RevokeAuth revokes the access token with Trakt and removes the stored tokens
+func (t *traktService) RevokeAuth(ctx context.Context) error {
+	settings, clientSecret, err := t.fetchClientCredentials(ctx)
+	if err != nil {
+		return err
+	}
+
+	if settings.AccessToken.Valid && settings.AccessToken.String != "" {
+		accessToken, err := t.gctx.Crate().Secrets.Decrypt(settings.AccessToken.String)
+		if err != nil {
+			return fmt.Errorf("error decrypting Trakt access token: %w", err)
+		}
+
+		body := map[string]string{
+			"token":         accessToken,
+			"client_id":     settings.ClientID,
+			"client_secret": clientSecret,
+		}
+
+		// The tokens are removed either way, a token Trakt already forgot about can't be used anymore
+		res, err := t.base.New().Post("/oauth/revoke").BodyJSON(body).ReceiveSuccess(nil)
+		if err != nil {
+			log.Warn("[Trakt] Failed to revoke the access token.", "error", err)
+		} else if res.StatusCode != http.StatusOK {
+			log.Warn("[Trakt] Failed to revoke the access token.", "status", res.Status)
+		}
+	}
+
+	return t.gctx.Crate().SQL.Queries().ClearTraktTokens(ctx)
+}
+
+// RefreshAuth refreshes the access token if it expires within the refresh window.
+// Nothing happens when no account is authorized.
+func (t *traktService) RefreshAuth(ctx context.Context) error {
+	_, err := t.accessToken(ctx)
+	if errors.Is(err, ErrTraktNotAuthorized) {
+		return nil
+	}
+
+	return err
+}
+
+// accessToken returns the access token of the authorized account, refreshing it first if it's about to expire
+func (t *traktService) accessToken(ctx context.Context) (string, error) {
+	t.tokenMu.Lock()
+	defer t.tokenMu.Unlock()
+
+	settings, clientSecret, err := t.fetchClientCredentials(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if !settings.AccessToken.Valid || settings.AccessToken.String == "" {
+		return "", ErrTraktNotAuthorized
+	}
+
+	accessToken, err := t.gctx.Crate().Secrets.Decrypt(settings.AccessToken.String)
+	if err != nil {
+		return "", fmt.Errorf("error decrypting Trakt access token: %w", err)
+	}
+
+	if !settings.TokenExpiresAt.Valid || time.Until(settings.TokenExpiresAt.Time) > tokenRefreshWindow {
+		return accessToken, nil
+	}
+
+	refreshed, err := t.refreshToken(ctx, settings, clientSecret)
+	if err != nil {
+		// The current token still works until it expires
+		if time.Now().Before(settings.TokenExpiresAt.Time) {
+			log.Warn("[Trakt] Failed to refresh the access token, using the current one until it expires.", "error", err)
+			return accessToken, nil
+		}
+
+		return "", fmt.Errorf("trakt access token expired and could not be refreshed: %w", err)
+	}
+
+	return refreshed, nil
+}
+
+// refreshToken exchanges the refresh token for a new access token and stores it. The caller must hold tokenMu.
+func (t *traktService) refreshToken(ctx context.Context, settings db.TraktSettings, clientSecret string) (string, error) {
+	refreshToken, err := t.gctx.Crate().Secrets.Decrypt(settings.RefreshToken.String)
+	if err != nil {
+		return "", fmt.Errorf("error decrypting Trakt refresh token: %w", err)
+	}
+
+	body := map[string]string{
+		"refresh_token": refreshToken,
+		"client_id":     settings.ClientID,
+		"client_secret": clientSecret,
+		"redirect_uri":  deviceRedirectURI,
+		"grant_type":    "refresh_token",
+	}
+
+	var token tokenResponse
+	res, err := t.base.New().Post("/oauth/token").BodyJSON(body).ReceiveSuccess(&token)
+	if err != nil {
+		return "", err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to refresh Trakt access token: %v", res.Status)
+	}
+
+	if err := t.storeToken(ctx, token); err != nil {
+		return "", err
+	}
+
+	log.Info("[Trakt] Refreshed the access token.")
+	return token.AccessToken, nil
+}
+
+// storeToken saves the tokens returned by Trakt
+func (t *traktService) storeToken(ctx context.Context, token tokenResponse) error {
+	issuedAt := time.Now()
+	if token.CreatedAt > 0 {
+		issuedAt = time.Unix(token.CreatedAt, 0)
+	}
+
+	expiresAt := issuedAt.Add(time.Duration(token.ExpiresIn) * time.Second)
+	return t.gctx.Crate().SQL.Queries().UpdateTraktTokens(ctx, token.AccessToken, token.RefreshToken, expiresAt)
+}
+
+// authorize adds the access token of the authorized account to a request, if there is one
+func (t *traktService) authorize(ctx context.Context, req *sling.Sling) (*sling.Sling, error) {
+	accessToken, err := t.accessToken(ctx)
+	if err != nil {
+		if errors.Is(err, ErrTraktNotAuthorized) {
+			return req, nil
+		}
+		return nil, err
+	}
+
+	return req.Set("Authorization", "Bearer "+accessToken), nil
+}
+
+type WatchedMovie struct {
+	Plays         int    `json:"plays"`
+	LastWatchedAt string `json:"last_watched_at"`
+	Movie         Movie  `json:"movie"`
+}
+
+type WatchedShow struct {
+	Plays         int    `json:"plays"`
+	LastWatchedAt string `json:"last_watched_at"`
+	Show          Show   `json:"show"`
+}
+
+// GetWatchedMovies returns every movie the authorized account watched
+func (t *traktService) GetWatchedMovies(ctx context.Context) ([]WatchedMovie, error) {
+	var movies []WatchedMovie
+	err := t.getWatched(ctx, "/sync/watched/movies", &movies)
+	return movies, err
+}
+
+// GetWatchedShows returns every show the authorized account watched
+func (t *traktService) GetWatchedShows(ctx context.Context) ([]WatchedShow, error) {
+	var shows []WatchedShow
+	err := t.getWatched(ctx, "/sync/watched/shows", &shows)
+	return shows, err
+}
+
+func (t *traktService) getWatched(ctx context.Context, path string, response interface{}) error {
+	clientID, err := t.FetchClientIDFromDB(ctx)
+	if err != nil {
+		return err
+	}
+
+	accessToken, err := t.accessToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	params := struct {
+		Extended string `url:"extended"`
+	}{Extended: "noseasons"}
+
+	res, err := t.base.New().
+		Set("trakt-api-key", clientID).
+		Set("Authorization", "Bearer "+accessToken).
+		QueryStruct(params).
+		Get(path).
+		ReceiveSuccess(response)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to get watched history from %s: %v", path, res.Status)
+	}
+
+	return nil
+}
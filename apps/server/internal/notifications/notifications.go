@@ -3,7 +3,10 @@ package notifications
 import (
 	"encoding/json"
 	"fmt"
+	"sync"
 
+	"github.com/charmbracelet/log"
+	"github.com/mahcks/blockbusterr/internal/db"
 	"github.com/mahcks/blockbusterr/internal/global"
 	"github.com/mahcks/blockbusterr/internal/helpers"
 	"github.com/mahcks/blockbusterr/pkg/structures"
@@ -14,37 +17,110 @@ type NotificationProvider interface {
 }
 
 type NotificationManager struct {
+	gctx      global.Context
+	helpers   *helpers.Helpers
+	mu        sync.RWMutex // Guards providers
 	providers []NotificationProvider
 }
 
 // NewNotificationManager loads the notification settings from the database and initializes the providers
 func NewNotificationManager(gctx global.Context, helpers *helpers.Helpers) (*NotificationManager, error) {
+	manager := &NotificationManager{
+		gctx:    gctx,
+		helpers: helpers,
+	}
+
+	if err := manager.Reload(); err != nil {
+		return nil, err
+	}
+
+	return manager, nil
+}
+
+// Reload initializes the providers again from the notification settings in the database
+func (m *NotificationManager) Reload() error {
 	// Fetch notification settings from the database
-	settings, err := gctx.Crate().SQL.Queries().GetNotificationSettings(gctx)
+	settings, err := m.gctx.Crate().SQL.Queries().GetNotificationSettings(m.gctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load notification settings: %w", err)
+		return fmt.Errorf("failed to load notification settings: %w", err)
 	}
 
-	// Initialize the NotificationManager
 	var providers []NotificationProvider
 
 	// Check if Discord notifications are enabled and add the provider
 	if settings.Platform == "discord" && settings.Enabled {
-		discordNotifier := NewDiscordNotification(helpers, settings.WebhookURL, "Blockbusterr")
+		discordNotifier := NewDiscordNotification(m.helpers, settings.WebhookURL, "Blockbusterr")
 		providers = append(providers, discordNotifier)
 	}
 
-	// Add other providers based on settings.Platform (Slack, etc.) as needed
+	webhooks, err := m.gctx.Crate().SQL.Queries().GetNotificationWebhooks(m.gctx)
+	if err != nil {
+		return fmt.Errorf("failed to load notification webhooks: %w", err)
+	}
+
+	for _, webhook := range webhooks {
+		if !webhook.Enabled {
+			continue
+		}
+
+		// A broken webhook shouldn't keep the other providers from being notified
+		webhookNotifier, err := NewWebhookNotificationFromDB(m.gctx, webhook)
+		if err != nil {
+			log.Errorf("[notifications] Skipping webhook '%s': %v", webhook.Name, err)
+			continue
+		}
+		providers = append(providers, webhookNotifier)
+	}
+
+	m.mu.Lock()
+	m.providers = providers
+	m.mu.Unlock()
 
-	return &NotificationManager{providers: providers}, nil
+	return nil
 }
 
-// SendNotification sends notifications to all enabled providers
+// NewWebhookNotificationFromDB creates a webhook provider from a stored webhook, decrypting its headers
+func NewWebhookNotificationFromDB(gctx global.Context, webhook db.NotificationWebhook) (*WebhookNotification, error) {
+	headers, err := DecryptWebhookHeaders(gctx, webhook.Headers.String)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewWebhookNotification(gctx, webhook.Name, webhook.URL, webhook.Method, headers, webhook.BodyTemplate.String, webhook.MaxRetries)
+}
+
+// DecryptWebhookHeaders decrypts the stored JSON object of webhook headers
+func DecryptWebhookHeaders(gctx global.Context, stored string) (map[string]string, error) {
+	headers := map[string]string{}
+	if stored == "" {
+		return headers, nil
+	}
+
+	decrypted, err := gctx.Crate().Secrets.Decrypt(stored)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt webhook headers: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(decrypted), &headers); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal webhook headers: %w", err)
+	}
+
+	return headers, nil
+}
+
+// SendNotification sends notifications to all enabled providers.
+// Each provider is notified in the background, so a slow or retrying webhook doesn't hold up the job.
 func (m *NotificationManager) SendNotification(notificationType structures.NotificationType, payload json.RawMessage) error {
-	for _, provider := range m.providers {
-		if err := provider.SendNotification(notificationType, payload); err != nil {
-			fmt.Printf("Failed to send notification via provider: %v\n", err)
-		}
+	m.mu.RLock()
+	providers := m.providers
+	m.mu.RUnlock()
+
+	for _, provider := range providers {
+		go func(provider NotificationProvider) {
+			if err := provider.SendNotification(notificationType, payload); err != nil {
+				log.Errorf("[notifications] Failed to send notification via provider: %v", err)
+			}
+		}(provider)
 	}
 	return nil
 }
@@ -0,0 +1,216 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/mahcks/blockbusterr/pkg/structures"
+)
+
+// DefaultWebhookTemplate is the body sent when a webhook has no template of its own
+const DefaultWebhookTemplate = `{"type": {{json .Type}}, "title": {{json .Title}}, "year": {{.Year}}, "overview": {{json .Overview}}, "imdb_id": {{json .IMDBID}}, "tmdb_id": {{.TMDBID}}, "tvdb_id": {{.TVDBID}}}`
+
+// webhookFuncs are the functions available in webhook body templates
+var webhookFuncs = template.FuncMap{
+	// json encodes a value, so strings can be put in a JSON body without breaking it
+	"json": func(value any) (string, error) {
+		encoded, err := json.Marshal(value)
+		return string(encoded), err
+	},
+	"join": strings.Join,
+}
+
+// IsValidWebhookMethod checks if the HTTP method can be used to call a webhook
+func IsValidWebhookMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch:
+		return true
+	}
+
+	return false
+}
+
+// ParseWebhookTemplate parses a webhook body template, an empty template falls back to DefaultWebhookTemplate
+func ParseWebhookTemplate(body string) (*template.Template, error) {
+	if strings.TrimSpace(body) == "" {
+		body = DefaultWebhookTemplate
+	}
+
+	return template.New("webhook").Funcs(webhookFuncs).Option("missingkey=zero").Parse(body)
+}
+
+// WebhookTemplateData is what a webhook body template is rendered with
+type WebhookTemplateData struct {
+	Type      structures.NotificationType // Either MOVIE_ADDED or SHOW_ADDED
+	MediaType string                      // Either MOVIE or SHOW
+	Title     string
+	Year      int
+	Overview  string
+	Genres    []string
+	Rating    float64
+	IMDBID    string
+	TMDBID    int
+	TVDBID    int
+	Media     map[string]any // The full Trakt movie or show
+}
+
+// newWebhookTemplateData picks the common fields out of a notification payload
+func newWebhookTemplateData(notificationType structures.NotificationType, payload json.RawMessage) (WebhookTemplateData, error) {
+	var media struct {
+		Title    string   `json:"title"`
+		Year     int      `json:"year"`
+		Overview string   `json:"overview"`
+		Genres   []string `json:"genres"`
+		Rating   float64  `json:"rating"`
+		IDs      struct {
+			IMDB string `json:"imdb"`
+			TMDB int    `json:"tmdb"`
+			TVDB int    `json:"tvdb"`
+		} `json:"ids"`
+	}
+	if err := json.Unmarshal(payload, &media); err != nil {
+		return WebhookTemplateData{}, fmt.Errorf("failed to unmarshal notification payload: %w", err)
+	}
+
+	data := WebhookTemplateData{
+		Type:     notificationType,
+		Title:    media.Title,
+		Year:     media.Year,
+		Overview: media.Overview,
+		Genres:   media.Genres,
+		Rating:   media.Rating,
+		IMDBID:   media.IDs.IMDB,
+		TMDBID:   media.IDs.TMDB,
+		TVDBID:   media.IDs.TVDB,
+	}
+
+	switch notificationType {
+	case structures.MOVIEADDEDALERT:
+		data.MediaType = "MOVIE"
+	case structures.SHOWADDEDALERT:
+		data.MediaType = "SHOW"
+	}
+
+	if err := json.Unmarshal(payload, &data.Media); err != nil {
+		return WebhookTemplateData{}, fmt.Errorf("failed to unmarshal notification payload: %w", err)
+	}
+
+	return data, nil
+}
+
+type WebhookNotification struct {
+	Context    context.Context // Cancels the requests and retries, e.g. on shutdown
+	Name       string
+	URL        string
+	Method     string
+	Headers    map[string]string
+	Body       *template.Template
+	MaxRetries int           // How many times a failed request is retried
+	Backoff    time.Duration // Delay before the first retry, doubled after every attempt
+	Client     *http.Client
+}
+
+// NewWebhookNotification creates a webhook provider, failing if the body template can't be parsed
+func NewWebhookNotification(ctx context.Context, name, url, method string, headers map[string]string, bodyTemplate string, maxRetries int) (*WebhookNotification, error) {
+	body, err := ParseWebhookTemplate(bodyTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse body template of webhook '%s': %w", name, err)
+	}
+
+	return &WebhookNotification{
+		Context:    ctx,
+		Name:       name,
+		URL:        url,
+		Method:     method,
+		Headers:    headers,
+		Body:       body,
+		MaxRetries: maxRetries,
+		Backoff:    2 * time.Second,
+		Client:     &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// RenderBody renders the body template for a notification
+func (w *WebhookNotification) RenderBody(notificationType structures.NotificationType, payload json.RawMessage) ([]byte, error) {
+	data, err := newWebhookTemplateData(notificationType, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var body bytes.Buffer
+	if err := w.Body.Execute(&body, data); err != nil {
+		return nil, fmt.Errorf("failed to render body template of webhook '%s': %w", w.Name, err)
+	}
+
+	return body.Bytes(), nil
+}
+
+// SendNotification renders the body template and sends it to the webhook, retrying with backoff when the request fails
+func (w *WebhookNotification) SendNotification(notificationType structures.NotificationType, payload json.RawMessage) error {
+	body, err := w.RenderBody(notificationType, payload)
+	if err != nil {
+		return err
+	}
+
+	delay := w.Backoff
+	for attempt := 0; ; attempt++ {
+		retry, err := w.send(body)
+		if err == nil {
+			return nil
+		}
+
+		if !retry || attempt >= w.MaxRetries {
+			return err
+		}
+
+		log.Warnf("[notifications] Webhook '%s' failed, retrying in %s: %v", w.Name, delay, err)
+		select {
+		case <-w.Context.Done():
+			return fmt.Errorf("webhook '%s' was cancelled before retrying: %w", w.Name, w.Context.Err())
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// send makes a single request to the webhook and reports whether a failure is worth retrying
+func (w *WebhookNotification) send(body []byte) (bool, error) {
+	var reader io.Reader
+	if w.Method != http.MethodGet {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(w.Context, w.Method, w.URL, reader)
+	if err != nil {
+		return false, fmt.Errorf("failed to create http request: %w", err)
+	}
+
+	if w.Method != http.MethodGet {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for key, value := range w.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := w.Client.Do(req)
+	if err != nil {
+		return true, fmt.Errorf("failed to send webhook notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		// Only server errors and rate limits can succeed on a later attempt
+		retry := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+		return retry, fmt.Errorf("webhook '%s' returned status code %d", w.Name, resp.StatusCode)
+	}
+
+	return false, nil
+}
@@ -0,0 +1,183 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mahcks/blockbusterr/pkg/structures"
+)
+
+var testPayload = json.RawMessage(`{"title": "Heat", "year": 1995, "overview": "A \"group\" of robbers", "ids": {"imdb": "tt0113277", "tmdb": 949}}`)
+
+// newTestWebhook creates a webhook that retries almost immediately
+func newTestWebhook(t *testing.T, ctx context.Context, url, method string, headers map[string]string, body string, maxRetries int) *WebhookNotification {
+	t.Helper()
+
+	webhook, err := NewWebhookNotification(ctx, "test", url, method, headers, body, maxRetries)
+	if err != nil {
+		t.Fatalf("failed to create webhook: %v", err)
+	}
+	webhook.Backoff = time.Millisecond
+
+	return webhook
+}
+
+func TestWebhookSendsRenderedBody(t *testing.T) {
+	var (
+		method      string
+		contentType string
+		token       string
+		body        []byte
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		contentType = r.Header.Get("Content-Type")
+		token = r.Header.Get("X-Token")
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	headers := map[string]string{"X-Token": "secret"}
+	template := `{"title": {{json .Title}}, "overview": {{json .Overview}}, "type": {{json .MediaType}}, "tmdb_id": {{.TMDBID}}}`
+	webhook := newTestWebhook(t, context.Background(), server.URL, http.MethodPut, headers, template, 0)
+
+	if err := webhook.SendNotification(structures.MOVIEADDEDALERT, testPayload); err != nil {
+		t.Fatalf("expected the webhook to succeed, got %v", err)
+	}
+
+	if method != http.MethodPut {
+		t.Errorf("expected method %s, got %s", http.MethodPut, method)
+	}
+	if contentType != "application/json" {
+		t.Errorf("expected content type application/json, got %q", contentType)
+	}
+	if token != "secret" {
+		t.Errorf("expected header X-Token to be sent, got %q", token)
+	}
+
+	var rendered struct {
+		Title    string `json:"title"`
+		Overview string `json:"overview"`
+		Type     string `json:"type"`
+		TMDBID   int    `json:"tmdb_id"`
+	}
+	if err := json.Unmarshal(body, &rendered); err != nil {
+		t.Fatalf("expected a JSON body, got %q: %v", body, err)
+	}
+	if rendered.Title != "Heat" || rendered.Overview != `A "group" of robbers` || rendered.Type != "MOVIE" || rendered.TMDBID != 949 {
+		t.Errorf("unexpected rendered body %q", body)
+	}
+}
+
+func TestWebhookGetHasNoBody(t *testing.T) {
+	var length int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		length = r.ContentLength
+	}))
+	defer server.Close()
+
+	webhook := newTestWebhook(t, context.Background(), server.URL, http.MethodGet, nil, "", 0)
+	if err := webhook.SendNotification(structures.MOVIEADDEDALERT, testPayload); err != nil {
+		t.Fatalf("expected the webhook to succeed, got %v", err)
+	}
+
+	if length != 0 {
+		t.Errorf("expected a GET request without a body, got %d bytes", length)
+	}
+}
+
+func TestWebhookRetriesServerErrorsAndRateLimits(t *testing.T) {
+	statuses := []int{http.StatusInternalServerError, http.StatusTooManyRequests, http.StatusOK}
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(statuses[calls.Add(1)-1])
+	}))
+	defer server.Close()
+
+	webhook := newTestWebhook(t, context.Background(), server.URL, http.MethodPost, nil, "", 2)
+	if err := webhook.SendNotification(structures.MOVIEADDEDALERT, testPayload); err != nil {
+		t.Fatalf("expected the webhook to succeed after retrying, got %v", err)
+	}
+
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 requests, got %d", calls.Load())
+	}
+}
+
+func TestWebhookGivesUpAfterMaxRetries(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	webhook := newTestWebhook(t, context.Background(), server.URL, http.MethodPost, nil, "", 2)
+	if err := webhook.SendNotification(structures.MOVIEADDEDALERT, testPayload); err == nil {
+		t.Fatal("expected the webhook to fail")
+	}
+
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 requests, got %d", calls.Load())
+	}
+}
+
+func TestWebhookDoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	webhook := newTestWebhook(t, context.Background(), server.URL, http.MethodPost, nil, "", 3)
+	if err := webhook.SendNotification(structures.MOVIEADDEDALERT, testPayload); err == nil {
+		t.Fatal("expected the webhook to fail")
+	}
+
+	if calls.Load() != 1 {
+		t.Errorf("expected a single request, got %d", calls.Load())
+	}
+}
+
+func TestWebhookStopsRetryingWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		cancel()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	webhook := newTestWebhook(t, ctx, server.URL, http.MethodPost, nil, "", 3)
+	webhook.Backoff = time.Hour
+
+	done := make(chan error, 1)
+	go func() {
+		done <- webhook.SendNotification(structures.MOVIEADDEDALERT, testPayload)
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected the webhook to be cancelled, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the webhook kept waiting to retry after it was cancelled")
+	}
+
+	if calls.Load() != 1 {
+		t.Errorf("expected a single request, got %d", calls.Load())
+	}
+}
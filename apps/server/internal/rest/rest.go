@@ -2,7 +2,6 @@ package rest
 
 import (
 	"errors"
-	"fmt"
 	"strings"
 	"time"
 
@@ -10,8 +9,10 @@ import (
 	"github.com/gofiber/fiber/v2/log"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/logger"
+	"github.com/mahcks/blockbusterr/internal/config"
 	"github.com/mahcks/blockbusterr/internal/global"
 	"github.com/mahcks/blockbusterr/internal/helpers"
+	"github.com/mahcks/blockbusterr/internal/notifications"
 	v1 "github.com/mahcks/blockbusterr/internal/rest/v1"
 	"github.com/mahcks/blockbusterr/internal/scheduler"
 	"github.com/mahcks/blockbusterr/internal/websocket"
@@ -41,7 +42,7 @@ type APIError struct {
 	Details    map[string]interface{} `json:"details,omitempty"`
 }
 
-func New(gctx global.Context, hub *websocket.Hub, helpers *helpers.Helpers, scheduler *scheduler.Scheduler) error {
+func New(gctx global.Context, hub *websocket.Hub, helpers *helpers.Helpers, scheduler *scheduler.Scheduler, notifications *notifications.NotificationManager, opts config.Server) error {
 	if helpers == nil {
 		return errors.New("helpers is nil")
 	}
@@ -86,19 +87,28 @@ func New(gctx global.Context, hub *websocket.Hub, helpers *helpers.Helpers, sche
 	}))
 
 	app.Use(cors.New(cors.Config{
-		AllowOrigins: "http://localhost:5555,http://localhost:5173",
+		AllowOrigins: opts.CORSOrigins,
 		AllowMethods: "GET,POST,PUT,PATCH,DELETE",
 		AllowHeaders: strings.Join(allowedHeaders, ", "),
+		// The UI sends its session cookie, which browsers refuse to do for wildcard origins
+		AllowCredentials: !opts.AllowsAnyOrigin(),
 	}))
 
-	v1Group := app.Group("/v1")
-	v1.New(gctx, hub, helpers, scheduler, v1Group)
+	v1Group := app.Group(opts.BasePath + "/v1")
+	v1.New(gctx, hub, helpers, scheduler, notifications, opts.BootstrapToken, v1Group)
 
 	errCh := make(chan error)
 	// Listen for connections in a separate goroutine.
 	// When Listen returns, send the error (or nil if none) on errCh.
 	go func() {
-		if err := app.Listen(fmt.Sprintf("%v:%v", "0.0.0.0", "3000")); err != nil {
+		var err error
+		if opts.TLSEnabled() {
+			err = app.ListenTLS(opts.ListenAddr(), opts.TLSCert, opts.TLSKey)
+		} else {
+			err = app.Listen(opts.ListenAddr())
+		}
+
+		if err != nil {
 			errCh <- err
 		} else {
 			errCh <- nil
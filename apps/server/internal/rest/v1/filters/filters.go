@@ -0,0 +1,123 @@
+package filters
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mahcks/blockbusterr/internal/db"
+	"github.com/mahcks/blockbusterr/internal/scheduler"
+	"github.com/mahcks/blockbusterr/pkg/errors"
+	"github.com/mahcks/blockbusterr/pkg/structures"
+	"github.com/mahcks/blockbusterr/pkg/utils"
+)
+
+// List is a child table of the movie or show settings that can be edited through the filter routes
+type List struct {
+	Table     db.FilterList
+	Normalize func(entry structures.FilterEntry) (structures.FilterEntry, error)
+}
+
+// Lists are the filter lists of a media type by the name used in the route
+type Lists map[string]List
+
+// Get looks up a filter list by its route name
+func (l Lists) Get(name string) (List, error) {
+	list, ok := l[name]
+	if !ok {
+		names := make([]string, 0, len(l))
+		for key := range l {
+			names = append(names, key)
+		}
+		sort.Strings(names)
+
+		return list, errors.ErrNotFound().SetDetail("Unknown filter list '%s', expected one of %s", name, strings.Join(names, ", "))
+	}
+
+	return list, nil
+}
+
+// NormalizeEntries validates the entries of a payload and converts them to database rows
+func (l List) NormalizeEntries(entries []structures.FilterEntry) ([]db.FilterEntry, error) {
+	rows := make([]db.FilterEntry, 0, len(entries))
+	for _, entry := range entries {
+		normalized, err := l.Normalize(entry)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, db.FilterEntry{Value: normalized.Value, MatchMode: normalized.MatchMode.String()})
+	}
+
+	return rows, nil
+}
+
+func NormalizeCountryEntry(entry structures.FilterEntry) (structures.FilterEntry, error) {
+	entry.Value = strings.ToLower(strings.TrimSpace(entry.Value))
+	if !utils.IsValidCountryCode(entry.Value) {
+		return entry, errors.ErrValidationRejected().SetDetail("'%s' is not an ISO 3166-1 alpha-2 country code", entry.Value)
+	}
+
+	return structures.FilterEntry{Value: entry.Value}, nil
+}
+
+func NormalizeLanguageEntry(entry structures.FilterEntry) (structures.FilterEntry, error) {
+	entry.Value = strings.ToLower(strings.TrimSpace(entry.Value))
+	if !utils.IsValidLanguageCode(entry.Value) {
+		return entry, errors.ErrValidationRejected().SetDetail("'%s' is not an ISO 639-1 language code", entry.Value)
+	}
+
+	return structures.FilterEntry{Value: entry.Value}, nil
+}
+
+func NormalizeTextEntry(entry structures.FilterEntry) (structures.FilterEntry, error) {
+	entry.Value = strings.ToLower(strings.TrimSpace(entry.Value))
+	if entry.Value == "" {
+		return entry, errors.ErrValidationRejected().SetDetail("value can't be empty")
+	}
+
+	return structures.FilterEntry{Value: entry.Value}, nil
+}
+
+func NormalizeKeywordEntry(entry structures.FilterEntry) (structures.FilterEntry, error) {
+	mode, err := ValidateTitleKeyword(entry.Value, entry.MatchMode)
+	if err != nil {
+		return entry, err
+	}
+
+	return structures.FilterEntry{Value: entry.Value, MatchMode: mode}, nil
+}
+
+func NormalizeIDEntry(entry structures.FilterEntry) (structures.FilterEntry, error) {
+	id, err := strconv.Atoi(strings.TrimSpace(entry.Value))
+	if err != nil || id <= 0 {
+		return entry, errors.ErrValidationRejected().SetDetail("'%s' is not a valid ID", entry.Value)
+	}
+
+	return structures.FilterEntry{Value: strconv.Itoa(id)}, nil
+}
+
+// ValidateTitleKeyword checks a blacklisted title keyword compiles with its match mode, which defaults to substring
+func ValidateTitleKeyword(keyword string, mode structures.KeywordMatchMode) (structures.KeywordMatchMode, error) {
+	if mode == "" {
+		mode = structures.KeywordMatchSubstring
+	}
+
+	if err := scheduler.ValidateKeywordRule(keyword, mode); err != nil {
+		return mode, errors.ErrValidationRejected().SetDetail("blacklisted title keyword %q: %v", keyword, err)
+	}
+
+	return mode, nil
+}
+
+// MapEntries maps the database filter entries to the JSON response struct
+func MapEntries(dbEntries []db.FilterEntry) []structures.FilterEntry {
+	entries := make([]structures.FilterEntry, len(dbEntries))
+	for i, e := range dbEntries {
+		entries[i] = structures.FilterEntry{
+			ID:        e.ID,
+			Value:     e.Value,
+			MatchMode: structures.KeywordMatchMode(e.MatchMode),
+		}
+	}
+	return entries
+}
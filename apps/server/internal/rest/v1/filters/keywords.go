@@ -0,0 +1,68 @@
+package filters
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mahcks/blockbusterr/internal/db"
+	"github.com/mahcks/blockbusterr/pkg/structures"
+)
+
+// TitleKeyword is a blacklisted title keyword in a movie or show settings payload
+type TitleKeyword struct {
+	Keyword   string                      `json:"keyword"`
+	MatchMode structures.KeywordMatchMode `json:"match_mode"` // Keeps the stored match mode when left empty
+}
+
+// TitleKeywords are the blacklisted title keywords of a settings payload. Besides a list of keywords
+// with their match modes, the comma-separated string older clients send is accepted.
+type TitleKeywords []TitleKeyword
+
+func (k *TitleKeywords) UnmarshalJSON(data []byte) error {
+	var keywords []TitleKeyword
+	if err := json.Unmarshal(data, &keywords); err == nil {
+		*k = keywords
+		return nil
+	}
+
+	var joined string
+	if err := json.Unmarshal(data, &joined); err != nil {
+		return fmt.Errorf("blacklisted_title_keywords must be a list of keywords or a comma-separated string")
+	}
+
+	keywords = []TitleKeyword{}
+	for _, keyword := range strings.Split(joined, ",") {
+		if keyword = strings.TrimSpace(keyword); keyword != "" {
+			keywords = append(keywords, TitleKeyword{Keyword: keyword})
+		}
+	}
+	*k = keywords
+
+	return nil
+}
+
+// Entries validates the keywords and converts them to rows of a keyword filter list. Keywords without a match mode
+// keep the one they're stored with, so saving the comma-separated string doesn't reset regex or whole-word rules.
+func (k TitleKeywords) Entries(stored []db.FilterEntry) ([]db.FilterEntry, error) {
+	modes := make(map[string]string, len(stored))
+	for _, entry := range stored {
+		modes[entry.Value] = entry.MatchMode
+	}
+
+	entries := make([]db.FilterEntry, 0, len(k))
+	for _, keyword := range k {
+		mode := keyword.MatchMode
+		if mode == "" {
+			mode = structures.KeywordMatchMode(modes[keyword.Keyword])
+		}
+
+		mode, err := ValidateTitleKeyword(keyword.Keyword, mode)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, db.FilterEntry{Value: keyword.Keyword, MatchMode: mode.String()})
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/limiter"
+	commonErrors "github.com/mahcks/blockbusterr/pkg/errors"
+)
+
+const (
+	// signInAttempts is how many sign in attempts an IP gets per signInWindow
+	signInAttempts = 10
+	signInWindow   = time.Minute
+)
+
+// SignInLimiter limits how often an IP can try to sign in or register, to slow down
+// guessing passwords and bootstrap tokens
+func SignInLimiter() fiber.Handler {
+	return limiter.New(limiter.Config{
+		Max:        signInAttempts,
+		Expiration: signInWindow,
+		LimitReached: func(c *fiber.Ctx) error {
+			return commonErrors.ErrTooManyRequests().SetDetail("Too many sign in attempts, try again in a minute")
+		},
+	})
+}
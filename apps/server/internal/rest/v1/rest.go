@@ -5,11 +5,18 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/mahcks/blockbusterr/internal/global"
 	"github.com/mahcks/blockbusterr/internal/helpers"
+	"github.com/mahcks/blockbusterr/internal/notifications"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/middleware"
 	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
 	"github.com/mahcks/blockbusterr/internal/rest/v1/routes"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/routes/auth"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/routes/cleanup"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/routes/history"
 	"github.com/mahcks/blockbusterr/internal/rest/v1/routes/jobs"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/routes/lists"
 	"github.com/mahcks/blockbusterr/internal/rest/v1/routes/logs"
 	"github.com/mahcks/blockbusterr/internal/rest/v1/routes/media"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/routes/mediaserver"
 	"github.com/mahcks/blockbusterr/internal/rest/v1/routes/movies"
 	"github.com/mahcks/blockbusterr/internal/rest/v1/routes/omdb"
 	"github.com/mahcks/blockbusterr/internal/rest/v1/routes/radarr"
@@ -17,6 +24,7 @@ import (
 	"github.com/mahcks/blockbusterr/internal/rest/v1/routes/shows"
 	"github.com/mahcks/blockbusterr/internal/rest/v1/routes/sonarr"
 	"github.com/mahcks/blockbusterr/internal/rest/v1/routes/trakt"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/routes/webhooks"
 	"github.com/mahcks/blockbusterr/internal/scheduler"
 	ws "github.com/mahcks/blockbusterr/internal/websocket"
 )
@@ -28,43 +36,85 @@ func ctx(fn func(*respond.Ctx) error) fiber.Handler {
 	}
 }
 
-func New(gctx global.Context, hub *ws.Hub, helpers *helpers.Helpers, scheduler *scheduler.Scheduler, router fiber.Router) {
+func New(gctx global.Context, hub *ws.Hub, helpers *helpers.Helpers, scheduler *scheduler.Scheduler, notificationManager *notifications.NotificationManager, bootstrapToken string, router fiber.Router) {
+	authMiddleware := middleware.NewAuth(gctx)
+
 	indexRoute := routes.NewRouteGroup(gctx, helpers)
 	router.Get("/", indexRoute.Index)
 
+	signInLimiter := middleware.SignInLimiter()
+
+	auth := auth.NewRouteGroup(gctx, helpers, bootstrapToken)
+	router.Get("/auth/status", ctx(auth.GetStatus))
+	router.Post("/auth/login", signInLimiter, ctx(auth.PostLogin))
+	router.Post("/auth/logout", ctx(auth.PostLogout))
+	router.Post("/auth/register", signInLimiter, ctx(auth.PostRegister))
+
+	// The setup wizard runs before the admin user exists, so these are open on new installs until then
+	settings := settings.NewRouteGroup(gctx, helpers)
+	router.Get("/settings", authMiddleware.AllowDuringSetup, ctx(settings.GetSetting))
+	router.Post("/settings", authMiddleware.AllowDuringSetup, ctx(settings.PostSetting))
+	router.Post("/settings/setup", authMiddleware.AllowDuringSetup, ctx(settings.PostSettingSetup))
+
+	radarr := radarr.NewRouteGroup(gctx, helpers)
+	router.Get("/radarr/profiles", authMiddleware.AllowDuringSetup, ctx(radarr.GetRadarrProfiles))
+	router.Get("/radarr/rootfolders", authMiddleware.AllowDuringSetup, ctx(radarr.GetRadarrRootFolders))
+
+	sonarr := sonarr.NewRouteGroup(gctx, helpers)
+	router.Get("/sonarr/profiles", authMiddleware.AllowDuringSetup, ctx(sonarr.GetSonarrProfiles))
+	router.Get("/sonarr/rootfolders", authMiddleware.AllowDuringSetup, ctx(sonarr.GetSonarrRootFolders))
+
+	// Every route below requires a session or an API key
+	router.Use(authMiddleware.Required)
+
+	router.Get("/auth/me", ctx(auth.GetMe))
+	router.Post("/auth/api-key", ctx(auth.PostAPIKey))
+
 	router.Get("/ws", websocket.New(func(c *websocket.Conn) {
 		hub.ServeWs(c)
 	}))
 
-	settings := settings.NewRouteGroup(gctx, helpers)
-	router.Post("/settings", ctx(settings.PostSetting))
-	router.Get("/settings", ctx(settings.GetSetting))
 	router.Delete("/settings", ctx(settings.DeleteSetting))
 	router.Put("/settings", ctx(settings.PutSetting))
 
-	router.Post("/settings/setup", ctx(settings.PostSettingSetup))
-
-	movies := movies.NewRouteGroup(gctx, helpers)
+	movies := movies.NewRouteGroup(gctx, helpers, scheduler)
 	router.Get("/movie/settings", ctx(movies.GetMovieSettings))
 	router.Put("/movie/settings", ctx(movies.UpdateMovieSettings))
+	router.Get("/movie/filters/:list", ctx(movies.GetMovieFilter))
+	router.Post("/movie/filters/:list", ctx(movies.AddMovieFilterEntries))
+	router.Put("/movie/filters/:list", ctx(movies.ReplaceMovieFilterEntries))
+	router.Delete("/movie/filters/:list/:id", ctx(movies.DeleteMovieFilterEntry))
 
-	shows := shows.NewRouteGroup(gctx, helpers)
+	shows := shows.NewRouteGroup(gctx, helpers, scheduler)
 	router.Get("/show/settings", ctx(shows.GetShowSettings))
+	router.Put("/show/settings", ctx(shows.UpdateShowSettings))
+	router.Get("/show/filters/:list", ctx(shows.GetShowFilter))
+	router.Post("/show/filters/:list", ctx(shows.AddShowFilterEntries))
+	router.Put("/show/filters/:list", ctx(shows.ReplaceShowFilterEntries))
+	router.Delete("/show/filters/:list/:id", ctx(shows.DeleteShowFilterEntry))
 
-	radarr := radarr.NewRouteGroup(gctx, helpers)
 	router.Get("/radarr/settings", ctx(radarr.GetRadarrSettings))
 	router.Put("/radarr/settings", ctx(radarr.UpdateRadarrSettings))
-	router.Get("/radarr/profiles", ctx(radarr.GetRadarrProfiles))
-	router.Get("/radarr/rootfolders", ctx(radarr.GetRadarrRootFolders))
+	router.Get("/radarr/instances", ctx(radarr.GetRadarrInstances))
+	router.Post("/radarr/instances", ctx(radarr.CreateRadarrInstance))
+	router.Get("/radarr/instances/:id", ctx(radarr.GetRadarrInstance))
+	router.Put("/radarr/instances/:id", ctx(radarr.UpdateRadarrInstance))
+	router.Delete("/radarr/instances/:id", ctx(radarr.DeleteRadarrInstance))
 
-	sonarr := sonarr.NewRouteGroup(gctx, helpers)
 	router.Get("/sonarr/settings", ctx(sonarr.GetSonarrSettings))
-	router.Get("/sonarr/profiles", ctx(sonarr.GetSonarrProfiles))
-	router.Get("/sonarr/rootfolders", ctx(sonarr.GetSonarrRootFolders))
+	router.Get("/sonarr/instances", ctx(sonarr.GetSonarrInstances))
+	router.Post("/sonarr/instances", ctx(sonarr.CreateSonarrInstance))
+	router.Get("/sonarr/instances/:id", ctx(sonarr.GetSonarrInstance))
+	router.Put("/sonarr/instances/:id", ctx(sonarr.UpdateSonarrInstance))
+	router.Delete("/sonarr/instances/:id", ctx(sonarr.DeleteSonarrInstance))
 
 	trakt := trakt.NewRouteGroup(gctx, helpers)
 	router.Get("/trakt/settings", ctx(trakt.GetTraktSettings))
 	router.Put("/trakt/settings", ctx(trakt.UpdateTraktSettings))
+	router.Get("/trakt/auth", ctx(trakt.GetTraktAuth))
+	router.Post("/trakt/auth/device", ctx(trakt.StartTraktAuth))
+	router.Post("/trakt/auth/poll", ctx(trakt.PollTraktAuth))
+	router.Delete("/trakt/auth", ctx(trakt.DeleteTraktAuth))
 
 	omdb := omdb.NewRouteGroup(gctx, helpers)
 	router.Get("/omdb/settings", ctx(omdb.GetOMDbSettings))
@@ -72,10 +122,50 @@ func New(gctx global.Context, hub *ws.Hub, helpers *helpers.Helpers, scheduler *
 
 	jobs := jobs.NewRouteGroup(gctx, helpers, scheduler)
 	router.Get("/jobs/status", ctx(jobs.GetJobStatus))
+	router.Post("/jobs/:type/preview", ctx(jobs.PostJobPreview))
+	router.Post("/jobs/:listType/run", ctx(jobs.PostJobRun))
+	router.Get("/jobs/:listType/instances", ctx(jobs.GetJobInstances))
+	router.Put("/jobs/:listType/instances", ctx(jobs.UpdateJobInstances))
+	router.Get("/jobs/:listType/monitor", ctx(jobs.GetJobMonitor))
+	router.Put("/jobs/:listType/monitor", ctx(jobs.UpdateJobMonitor))
+	router.Get("/jobs/:listType/tags", ctx(jobs.GetJobTags))
+	router.Put("/jobs/:listType/tags", ctx(jobs.UpdateJobTags))
+	router.Get("/jobs/runs/:id", ctx(jobs.GetJobRun))
+
+	lists := lists.NewRouteGroup(gctx, helpers, scheduler)
+	router.Get("/lists", ctx(lists.GetListSources))
+	router.Post("/lists", ctx(lists.CreateListSource))
+	router.Get("/lists/:id", ctx(lists.GetListSource))
+	router.Put("/lists/:id", ctx(lists.UpdateListSource))
+	router.Delete("/lists/:id", ctx(lists.DeleteListSource))
+
+	cleanup := cleanup.NewRouteGroup(gctx, helpers, scheduler)
+	router.Get("/cleanup/settings", ctx(cleanup.GetCleanupSettings))
+	router.Put("/cleanup/settings", ctx(cleanup.UpdateCleanupSettings))
+	router.Get("/cleanup/preview", ctx(cleanup.GetCleanupPreview))
+	router.Post("/cleanup/run", ctx(cleanup.PostCleanupRun))
+	router.Get("/cleanup/exclusions", ctx(cleanup.GetCleanupExclusions))
+	router.Post("/cleanup/exclusions", ctx(cleanup.CreateCleanupExclusion))
+	router.Delete("/cleanup/exclusions/:id", ctx(cleanup.DeleteCleanupExclusion))
+
+	mediaServer := mediaserver.NewRouteGroup(gctx, helpers, scheduler)
+	router.Get("/mediaserver/settings", ctx(mediaServer.GetMediaServerSettings))
+	router.Put("/mediaserver/settings", ctx(mediaServer.UpdateMediaServerSettings))
+	router.Post("/mediaserver/sync", ctx(mediaServer.PostMediaServerSync))
+
+	webhooks := webhooks.NewRouteGroup(gctx, helpers, notificationManager)
+	router.Get("/notifications/webhooks", ctx(webhooks.GetNotificationWebhooks))
+	router.Post("/notifications/webhooks", ctx(webhooks.CreateNotificationWebhook))
+	router.Put("/notifications/webhooks/:id", ctx(webhooks.UpdateNotificationWebhook))
+	router.Delete("/notifications/webhooks/:id", ctx(webhooks.DeleteNotificationWebhook))
+	router.Post("/notifications/webhooks/:id/test", ctx(webhooks.PostNotificationWebhookTest))
 
 	media := media.NewRouteGroup(gctx, helpers)
 	router.Get("/media/recentlyadded", ctx(media.GetMediaRecentlyAdded))
 
 	logs := logs.NewRouteGroup(gctx, helpers)
 	router.Get("/logs", ctx(logs.GetLogs))
+
+	history := history.NewRouteGroup(gctx, helpers)
+	router.Get("/history", ctx(history.GetHistory))
 }
@@ -0,0 +1,17 @@
+package auth
+
+import (
+	"github.com/mahcks/blockbusterr/internal/rest/v1/middleware"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	commonErrors "github.com/mahcks/blockbusterr/pkg/errors"
+)
+
+// GetMe returns the user that made the request
+func (rg *RouteGroup) GetMe(ctx *respond.Ctx) error {
+	user, ok := middleware.User(ctx.Ctx)
+	if !ok {
+		return commonErrors.ErrUnauthorized()
+	}
+
+	return ctx.JSON(toUser(user))
+}
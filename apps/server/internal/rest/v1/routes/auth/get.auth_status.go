@@ -0,0 +1,24 @@
+package auth
+
+import (
+	"github.com/charmbracelet/log"
+	commonAuth "github.com/mahcks/blockbusterr/internal/auth"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	commonErrors "github.com/mahcks/blockbusterr/pkg/errors"
+	"github.com/mahcks/blockbusterr/pkg/structures"
+)
+
+// GetStatus tells the UI whether to run the setup wizard, create the admin user or sign in
+func (rg *RouteGroup) GetStatus(ctx *respond.Ctx) error {
+	status, err := commonAuth.GetSetupStatus(ctx.Context(), rg.gctx.Crate().SQL.Queries())
+	if err != nil {
+		log.Error("error fetching setup status", "error", err)
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to check setup status")
+	}
+
+	return ctx.JSON(structures.AuthStatus{
+		SetupComplete:          status.SetupComplete,
+		HasUsers:               status.HasUsers,
+		BootstrapTokenRequired: status.NeedsBootstrapToken(),
+	})
+}
@@ -0,0 +1,32 @@
+package auth
+
+import (
+	"github.com/charmbracelet/log"
+	"github.com/gofiber/fiber/v2"
+	commonAuth "github.com/mahcks/blockbusterr/internal/auth"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/middleware"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	commonErrors "github.com/mahcks/blockbusterr/pkg/errors"
+)
+
+// PostAPIKey generates a new API key for the user and returns it. Only a hash is
+// stored, so this is the only time the key can be read.
+func (rg *RouteGroup) PostAPIKey(ctx *respond.Ctx) error {
+	user, ok := middleware.User(ctx.Ctx)
+	if !ok {
+		return commonErrors.ErrUnauthorized()
+	}
+
+	apiKey, err := commonAuth.NewToken()
+	if err != nil {
+		log.Error("error generating API key", "error", err)
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to generate API key")
+	}
+
+	if err := rg.gctx.Crate().SQL.Queries().UpdateUserAPIKeyHash(ctx.Context(), user.ID, commonAuth.HashToken(apiKey)); err != nil {
+		log.Error("error storing API key", "error", err)
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to generate API key")
+	}
+
+	return ctx.JSON(fiber.Map{"api_key": apiKey})
+}
@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/charmbracelet/log"
+	commonAuth "github.com/mahcks/blockbusterr/internal/auth"
+	"github.com/mahcks/blockbusterr/internal/db"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/middleware"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	commonErrors "github.com/mahcks/blockbusterr/pkg/errors"
+)
+
+// PostLogin checks the username and password and starts a session for the UI
+func (rg *RouteGroup) PostLogin(ctx *respond.Ctx) error {
+	var payload CredentialsPayload
+	if err := json.Unmarshal(ctx.Body(), &payload); err != nil {
+		return commonErrors.ErrBadRequest().SetDetail("Invalid JSON payload")
+	}
+
+	user, err := rg.gctx.Crate().SQL.Queries().GetUserByUsername(ctx.Context(), payload.Username)
+	if err != nil && !errors.Is(err, db.ErrNoUser) {
+		log.Error("error fetching user", "error", err)
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to sign in")
+	}
+
+	// Unknown users and wrong passwords get the same response
+	if err != nil || !commonAuth.CheckPassword(user.PasswordHash, payload.Password) {
+		return commonErrors.ErrUnauthorized().SetDetail("Invalid username or password")
+	}
+
+	// Clean up old sessions whenever a new one is created
+	if err := rg.gctx.Crate().SQL.Queries().DeleteExpiredSessions(ctx.Context()); err != nil {
+		log.Warn("error deleting expired sessions", "error", err)
+	}
+
+	token, expiresAt, err := commonAuth.CreateSession(ctx.Context(), rg.gctx.Crate().SQL.Queries(), user.ID)
+	if err != nil {
+		log.Error("error creating session", "error", err)
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to sign in")
+	}
+
+	middleware.SetSessionCookie(ctx.Ctx, token, expiresAt)
+
+	return ctx.JSON(toUser(user))
+}
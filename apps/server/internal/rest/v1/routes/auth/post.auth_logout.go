@@ -0,0 +1,24 @@
+package auth
+
+import (
+	"github.com/charmbracelet/log"
+	"github.com/gofiber/fiber/v2"
+	commonAuth "github.com/mahcks/blockbusterr/internal/auth"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/middleware"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	commonErrors "github.com/mahcks/blockbusterr/pkg/errors"
+)
+
+// PostLogout ends the current session
+func (rg *RouteGroup) PostLogout(ctx *respond.Ctx) error {
+	if token := ctx.Cookies(commonAuth.SessionCookieName); token != "" {
+		if err := rg.gctx.Crate().SQL.Queries().DeleteSession(ctx.Context(), commonAuth.HashToken(token)); err != nil {
+			log.Error("error deleting session", "error", err)
+			return commonErrors.ErrInternalServerError().SetDetail("Failed to sign out")
+		}
+	}
+
+	middleware.ClearSessionCookie(ctx.Ctx)
+
+	return ctx.JSON(fiber.Map{"success": true})
+}
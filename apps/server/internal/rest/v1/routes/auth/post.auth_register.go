@@ -0,0 +1,71 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/charmbracelet/log"
+	commonAuth "github.com/mahcks/blockbusterr/internal/auth"
+	"github.com/mahcks/blockbusterr/internal/db"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/middleware"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	commonErrors "github.com/mahcks/blockbusterr/pkg/errors"
+)
+
+// PostRegister creates the admin user for installs that finished the setup before
+// authentication existed. It's only available while there are no users, and needs the
+// bootstrap token logged at startup so whoever reaches the API first can't claim the install.
+func (rg *RouteGroup) PostRegister(ctx *respond.Ctx) error {
+	var payload RegisterPayload
+	if err := json.Unmarshal(ctx.Body(), &payload); err != nil {
+		return commonErrors.ErrBadRequest().SetDetail("Invalid JSON payload")
+	}
+
+	status, err := commonAuth.GetSetupStatus(ctx.Context(), rg.gctx.Crate().SQL.Queries())
+	if err != nil {
+		log.Error("error fetching setup status", "error", err)
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to check setup status")
+	}
+
+	if status.HasUsers {
+		return commonErrors.ErrConflict().SetDetail("An admin user already exists")
+	}
+
+	// New installs create their admin user in the setup wizard
+	if !status.NeedsBootstrapToken() {
+		return commonErrors.ErrBadRequest().SetDetail("Finish the setup to create the admin user")
+	}
+
+	if !commonAuth.CheckBootstrapToken(rg.bootstrapToken, payload.BootstrapToken) {
+		return commonErrors.ErrUnauthorized().SetDetail("Invalid bootstrap token")
+	}
+
+	if err := commonAuth.ValidateCredentials(payload.Username, payload.Password); err != nil {
+		return commonErrors.ErrValidationRejected().SetDetail(err.Error())
+	}
+
+	passwordHash, err := commonAuth.HashPassword(payload.Password)
+	if err != nil {
+		log.Error("error hashing password", "error", err)
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to create admin user")
+	}
+
+	user, err := rg.gctx.Crate().SQL.Queries().CreateFirstUser(ctx.Context(), payload.Username, passwordHash)
+	if errors.Is(err, db.ErrUsersExists) {
+		return commonErrors.ErrConflict().SetDetail("An admin user already exists")
+	}
+	if err != nil {
+		log.Error("error creating user", "error", err)
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to create admin user")
+	}
+
+	token, expiresAt, err := commonAuth.CreateSession(ctx.Context(), rg.gctx.Crate().SQL.Queries(), user.ID)
+	if err != nil {
+		log.Error("error creating session", "error", err)
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to sign in")
+	}
+
+	middleware.SetSessionCookie(ctx.Ctx, token, expiresAt)
+
+	return ctx.JSON(toUser(user))
+}
@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"github.com/mahcks/blockbusterr/internal/db"
+	"github.com/mahcks/blockbusterr/internal/global"
+	"github.com/mahcks/blockbusterr/internal/helpers"
+	"github.com/mahcks/blockbusterr/pkg/structures"
+)
+
+type CredentialsPayload struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// RegisterPayload is sent to create the admin user of an install that finished the setup
+// before authentication existed
+type RegisterPayload struct {
+	CredentialsPayload
+	BootstrapToken string `json:"bootstrap_token"`
+}
+
+type RouteGroup struct {
+	gctx           global.Context
+	helpers        *helpers.Helpers
+	bootstrapToken string
+}
+
+func NewRouteGroup(gctx global.Context, helpers *helpers.Helpers, bootstrapToken string) *RouteGroup {
+	return &RouteGroup{
+		gctx:           gctx,
+		helpers:        helpers,
+		bootstrapToken: bootstrapToken,
+	}
+}
+
+func toUser(user db.User) structures.User {
+	return structures.User{
+		ID:        user.ID,
+		Username:  user.Username,
+		HasAPIKey: user.APIKeyHash.Valid,
+		CreatedAt: user.CreatedAt,
+	}
+}
@@ -0,0 +1,32 @@
+package cleanup
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/charmbracelet/log"
+	"github.com/gofiber/fiber/v2"
+	"github.com/mahcks/blockbusterr/internal/db"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	commonErrors "github.com/mahcks/blockbusterr/pkg/errors"
+)
+
+// DeleteCleanupExclusion lets the cleanup job touch a title again
+func (rg *RouteGroup) DeleteCleanupExclusion(ctx *respond.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return commonErrors.ErrBadRequest().SetDetail("Invalid cleanup exclusion ID")
+	}
+
+	err = rg.gctx.Crate().SQL.Queries().DeleteCleanupExclusion(ctx.Context(), id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoCleanupExclusion) {
+			return commonErrors.ErrNotFound().SetDetail("No cleanup exclusion found with ID %d", id)
+		}
+
+		log.Error("error deleting cleanup exclusion", "id", id, "error", err)
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to delete cleanup exclusion")
+	}
+
+	return ctx.JSON(fiber.Map{"success": true})
+}
@@ -0,0 +1,24 @@
+package cleanup
+
+import (
+	"github.com/charmbracelet/log"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/pkg/errors"
+	"github.com/mahcks/blockbusterr/pkg/structures"
+)
+
+// GetCleanupExclusions returns every title the cleanup job never touches
+func (rg *RouteGroup) GetCleanupExclusions(ctx *respond.Ctx) error {
+	exclusions, err := rg.gctx.Crate().SQL.Queries().GetCleanupExclusions(ctx.Context())
+	if err != nil {
+		log.Errorf("error fetching cleanup exclusions: %v", err)
+		return errors.ErrInternalServerError().SetDetail("Failed to retrieve cleanup exclusions")
+	}
+
+	response := make([]structures.CleanupExclusion, len(exclusions))
+	for i, exclusion := range exclusions {
+		response[i] = toCleanupExclusionResponse(exclusion)
+	}
+
+	return ctx.JSON(response)
+}
@@ -0,0 +1,18 @@
+package cleanup
+
+import (
+	"github.com/charmbracelet/log"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/pkg/errors"
+)
+
+// GetCleanupPreview returns the titles the cleanup job would clean up right now without touching them
+func (rg *RouteGroup) GetCleanupPreview(ctx *respond.Ctx) error {
+	report, err := rg.scheduler.PreviewCleanup()
+	if err != nil {
+		log.Errorf("error previewing cleanup job: %v", err)
+		return errors.ErrInternalServerError().SetDetail("Failed to preview cleanup")
+	}
+
+	return ctx.JSON(report)
+}
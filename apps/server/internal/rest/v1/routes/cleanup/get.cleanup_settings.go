@@ -0,0 +1,25 @@
+package cleanup
+
+import (
+	"github.com/charmbracelet/log"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/pkg/errors"
+	"github.com/mahcks/blockbusterr/pkg/structures"
+	"github.com/mahcks/blockbusterr/pkg/utils"
+)
+
+// GetCleanupSettings returns the settings of the cleanup job
+func (rg *RouteGroup) GetCleanupSettings(ctx *respond.Ctx) error {
+	settings, err := rg.gctx.Crate().SQL.Queries().GetCleanupSettings(ctx.Context())
+	if err != nil {
+		log.Errorf("error fetching cleanup settings: %v", err)
+		return errors.ErrInternalServerError().SetDetail("Failed to retrieve cleanup settings")
+	}
+
+	return ctx.JSON(structures.CleanupSettings{
+		Cron:        utils.NullStringToPointer(settings.Cron),
+		MinAgeDays:  settings.MinAgeDays,
+		Action:      structures.CleanupAction(settings.Action),
+		DeleteFiles: settings.DeleteFiles,
+	})
+}
@@ -0,0 +1,52 @@
+package cleanup
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/gofiber/fiber/v2"
+	"github.com/mahcks/blockbusterr/internal/db"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	commonErrors "github.com/mahcks/blockbusterr/pkg/errors"
+	"github.com/mahcks/blockbusterr/pkg/utils"
+)
+
+type CleanupExclusionPayload struct {
+	MediaType *string `json:"media_type"` // Either "MOVIE" or "SHOW"
+	MediaID   *int    `json:"media_id"`   // TMDb ID of a movie or TVDB ID of a show
+	Title     *string `json:"title"`      // Title of the media, only used for display
+}
+
+// CreateCleanupExclusion excludes a title from the cleanup job
+func (rg *RouteGroup) CreateCleanupExclusion(ctx *respond.Ctx) error {
+	var payload CleanupExclusionPayload
+	if err := json.Unmarshal(ctx.Body(), &payload); err != nil {
+		return commonErrors.ErrBadRequest().SetDetail("Invalid JSON payload")
+	}
+
+	if payload.MediaType == nil {
+		return commonErrors.ErrValidationRejected().SetDetail("media_type is required")
+	}
+	mediaType := strings.ToUpper(*payload.MediaType)
+	if mediaType != "MOVIE" && mediaType != "SHOW" {
+		return commonErrors.ErrValidationRejected().SetDetail("media_type must be either MOVIE or SHOW")
+	}
+
+	if payload.MediaID == nil || *payload.MediaID <= 0 {
+		return commonErrors.ErrValidationRejected().SetDetail("media_id must be a TMDb ID for movies or a TVDB ID for shows")
+	}
+
+	id, err := rg.gctx.Crate().SQL.Queries().AddCleanupExclusion(ctx.Context(), mediaType, *payload.MediaID, utils.PointerToNullString(payload.Title))
+	if err != nil {
+		if errors.Is(err, db.ErrCleanupExclusionExists) {
+			return commonErrors.ErrConflict().SetDetail("%s %d is already excluded from cleanup", mediaType, *payload.MediaID)
+		}
+
+		log.Errorf("error adding cleanup exclusion: %v", err)
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to add cleanup exclusion")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{"id": id})
+}
@@ -0,0 +1,26 @@
+package cleanup
+
+import (
+	"errors"
+
+	"github.com/charmbracelet/log"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/internal/scheduler"
+	commonErrors "github.com/mahcks/blockbusterr/pkg/errors"
+)
+
+// PostCleanupRun runs the cleanup job right away and returns what it cleaned up.
+// In dry-run mode the titles are only reported.
+func (rg *RouteGroup) PostCleanupRun(ctx *respond.Ctx) error {
+	report, err := rg.scheduler.RunCleanup()
+	if err != nil {
+		if errors.Is(err, scheduler.ErrCleanupRunning) {
+			return commonErrors.ErrConflict().SetDetail("The cleanup job is already running")
+		}
+
+		log.Errorf("error running cleanup job: %v", err)
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to run cleanup")
+	}
+
+	return ctx.JSON(report)
+}
@@ -0,0 +1,71 @@
+package cleanup
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/gofiber/fiber/v2"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/pkg/errors"
+	"github.com/mahcks/blockbusterr/pkg/structures"
+	"github.com/mahcks/blockbusterr/pkg/utils"
+)
+
+type CleanupSettingsPayload struct {
+	Cron        *string `json:"cron"`         // Cron expression the cleanup job runs on, empty to not schedule it
+	MinAgeDays  *int    `json:"min_age_days"` // Titles are only cleaned up once they were added at least this many days ago
+	Action      *string `json:"action"`       // Either "unmonitor" or "delete"
+	DeleteFiles *bool   `json:"delete_files"` // Whether deleting a title also deletes its files
+}
+
+// UpdateCleanupSettings updates the settings of the cleanup job and reschedules it
+func (rg *RouteGroup) UpdateCleanupSettings(ctx *respond.Ctx) error {
+	var payload CleanupSettingsPayload
+	if err := json.Unmarshal(ctx.Body(), &payload); err != nil {
+		return errors.ErrBadRequest().SetDetail("Invalid JSON payload")
+	}
+
+	settings, err := rg.gctx.Crate().SQL.Queries().GetCleanupSettings(ctx.Context())
+	if err != nil {
+		log.Errorf("error fetching cleanup settings: %v", err)
+		return errors.ErrInternalServerError().SetDetail("Failed to retrieve cleanup settings")
+	}
+
+	if payload.Cron != nil {
+		cron := strings.TrimSpace(*payload.Cron)
+		if cron != "" {
+			if err := utils.ValidateCronExpression(cron); err != nil {
+				return errors.ErrValidationRejected().SetDetail("Invalid cron expression: %v", err)
+			}
+		}
+		settings.Cron = utils.StringToNullString(cron)
+	}
+
+	if payload.MinAgeDays != nil {
+		if *payload.MinAgeDays < 1 {
+			return errors.ErrValidationRejected().SetDetail("min_age_days must be at least 1")
+		}
+		settings.MinAgeDays = *payload.MinAgeDays
+	}
+
+	if payload.Action != nil {
+		if !structures.IsValidCleanupAction(*payload.Action) {
+			return errors.ErrValidationRejected().SetDetail("action must be either unmonitor or delete")
+		}
+		settings.Action = *payload.Action
+	}
+
+	if payload.DeleteFiles != nil {
+		settings.DeleteFiles = *payload.DeleteFiles
+	}
+
+	if err := rg.gctx.Crate().SQL.Queries().UpdateCleanupSettings(ctx.Context(), settings); err != nil {
+		log.Errorf("error updating cleanup settings: %v", err)
+		return errors.ErrInternalServerError().SetDetail("Failed to update cleanup settings")
+	}
+
+	rg.scheduler.ReloadCleanupJob(settings)
+
+	return ctx.JSON(fiber.Map{"success": true})
+}
@@ -0,0 +1,35 @@
+package cleanup
+
+import (
+	"github.com/mahcks/blockbusterr/internal/db"
+	"github.com/mahcks/blockbusterr/internal/global"
+	"github.com/mahcks/blockbusterr/internal/helpers"
+	"github.com/mahcks/blockbusterr/internal/scheduler"
+	"github.com/mahcks/blockbusterr/pkg/structures"
+	"github.com/mahcks/blockbusterr/pkg/utils"
+)
+
+type RouteGroup struct {
+	gctx      global.Context
+	helpers   *helpers.Helpers
+	scheduler *scheduler.Scheduler
+}
+
+func NewRouteGroup(gctx global.Context, helpers *helpers.Helpers, scheduler *scheduler.Scheduler) *RouteGroup {
+	return &RouteGroup{
+		gctx:      gctx,
+		helpers:   helpers,
+		scheduler: scheduler,
+	}
+}
+
+// toCleanupExclusionResponse converts a cleanup exclusion to its JSON representation
+func toCleanupExclusionResponse(exclusion db.CleanupExclusion) structures.CleanupExclusion {
+	return structures.CleanupExclusion{
+		ID:        exclusion.ID,
+		MediaType: exclusion.MediaType,
+		MediaID:   exclusion.MediaID,
+		Title:     utils.NullStringToPointer(exclusion.Title),
+		CreatedAt: exclusion.CreatedAt,
+	}
+}
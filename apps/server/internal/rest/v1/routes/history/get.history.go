@@ -0,0 +1,70 @@
+package history
+
+import (
+	"github.com/charmbracelet/log"
+	"github.com/mahcks/blockbusterr/internal/db"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/pkg/errors"
+	"github.com/mahcks/blockbusterr/pkg/structures"
+	"github.com/mahcks/blockbusterr/pkg/utils"
+)
+
+const (
+	// defaultTake is how many entries are returned when take isn't set
+	defaultTake = 30
+	// maxTake caps take, so a single request can't load the whole history
+	maxTake = 100
+)
+
+func (rg *RouteGroup) GetHistory(ctx *respond.Ctx) error {
+	// Default values for pagination
+	take := ctx.QueryInt("take", defaultTake)
+	skip := ctx.QueryInt("skip", 0)
+
+	if take <= 0 {
+		take = defaultTake
+	}
+	if take > maxTake {
+		take = maxTake
+	}
+	if skip < 0 {
+		skip = 0
+	}
+
+	filter := db.RequestHistoryFilter{
+		RunID:     ctx.Query("run_id"),
+		MediaType: ctx.Query("media_type"),
+		Source:    ctx.Query("source"),
+		Backend:   ctx.Query("backend"),
+		Outcome:   ctx.Query("outcome"),
+		Search:    ctx.Query("search"),
+	}
+
+	history, err := rg.gctx.Crate().SQL.Queries().GetRequestHistory(ctx.Context(), take, skip, filter)
+	if err != nil {
+		log.Error("Error getting request history", "error", err)
+		return errors.ErrInternalServerError().SetDetail("Failed to get request history")
+	}
+
+	response := make([]structures.RequestHistory, len(history))
+	for i, entry := range history {
+		response[i] = structures.RequestHistory{
+			ID:        entry.ID,
+			RunID:     entry.RunID,
+			MediaType: entry.MediaType,
+			Title:     entry.Title,
+			Year:      utils.NullIntToPointer(entry.Year),
+			TMDBID:    utils.NullIntToPointer(entry.TMDBID),
+			TVDBID:    utils.NullIntToPointer(entry.TVDBID),
+			IMDBID:    utils.NullStringToPointer(entry.IMDBID),
+			Source:    entry.Source,
+			Backend:   structures.RequestBackend(entry.Backend),
+			Outcome:   structures.RequestOutcome(entry.Outcome),
+			Error:     utils.NullStringToPointer(entry.Error),
+			Reason:    utils.NullStringToPointer(entry.Reason),
+			CreatedAt: entry.CreatedAt,
+		}
+	}
+
+	return ctx.JSON(response)
+}
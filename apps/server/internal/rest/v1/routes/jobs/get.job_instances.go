@@ -0,0 +1,29 @@
+package jobs
+
+import (
+	"errors"
+
+	"github.com/charmbracelet/log"
+	"github.com/gofiber/fiber/v2"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/internal/scheduler"
+	commonErrors "github.com/mahcks/blockbusterr/pkg/errors"
+)
+
+// GetJobInstances returns the Radarr or Sonarr instances a list job sends to.
+// An empty list means the job sends to every instance.
+func (rg *RouteGroup) GetJobInstances(ctx *respond.Ctx) error {
+	listType := ctx.Params("listType")
+
+	ids, err := rg.scheduler.GetJobInstances(listType)
+	if err != nil {
+		if errors.Is(err, scheduler.ErrUnknownJobType) {
+			return commonErrors.ErrNotFound().SetDetail("Unknown job type '%s'", listType)
+		}
+
+		log.Errorf("error fetching instances for %s job: %v", listType, err)
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to retrieve job instances")
+	}
+
+	return ctx.JSON(fiber.Map{"job_type": listType, "instance_ids": ids})
+}
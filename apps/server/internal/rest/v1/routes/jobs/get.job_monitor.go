@@ -0,0 +1,38 @@
+package jobs
+
+import (
+	"errors"
+
+	"github.com/charmbracelet/log"
+	"github.com/gofiber/fiber/v2"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/internal/scheduler"
+	commonErrors "github.com/mahcks/blockbusterr/pkg/errors"
+)
+
+// GetJobMonitor returns the Sonarr monitor strategy of a show list job.
+// A null monitor means the job uses the monitor option of the show settings.
+func (rg *RouteGroup) GetJobMonitor(ctx *respond.Ctx) error {
+	listType := ctx.Params("listType")
+
+	monitor, err := rg.scheduler.GetJobMonitor(listType)
+	if err != nil {
+		if errors.Is(err, scheduler.ErrUnknownJobType) {
+			return commonErrors.ErrNotFound().SetDetail("Unknown job type '%s'", listType)
+		}
+
+		if errors.Is(err, scheduler.ErrNotShowJob) {
+			return commonErrors.ErrBadRequest().SetDetail("The %s job doesn't request shows", listType)
+		}
+
+		log.Errorf("error fetching monitor strategy for %s job: %v", listType, err)
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to retrieve job monitor strategy")
+	}
+
+	response := fiber.Map{"job_type": listType, "monitor": nil}
+	if monitor != "" {
+		response["monitor"] = monitor
+	}
+
+	return ctx.JSON(response)
+}
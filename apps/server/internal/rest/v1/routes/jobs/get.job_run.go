@@ -0,0 +1,27 @@
+package jobs
+
+import (
+	"errors"
+
+	"github.com/charmbracelet/log"
+	"github.com/mahcks/blockbusterr/internal/db"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	commonErrors "github.com/mahcks/blockbusterr/pkg/errors"
+)
+
+// GetJobRun returns the status and counts of a single job run
+func (rg *RouteGroup) GetJobRun(ctx *respond.Ctx) error {
+	runID := ctx.Params("id")
+
+	run, err := rg.scheduler.GetJobRun(runID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoJobRun) {
+			return commonErrors.ErrNotFound().SetDetail("No job run found with ID '%s'", runID)
+		}
+
+		log.Errorf("error fetching job run %s: %v", runID, err)
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to retrieve job run")
+	}
+
+	return ctx.JSON(run)
+}
@@ -0,0 +1,29 @@
+package jobs
+
+import (
+	"errors"
+
+	"github.com/charmbracelet/log"
+	"github.com/gofiber/fiber/v2"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/internal/scheduler"
+	commonErrors "github.com/mahcks/blockbusterr/pkg/errors"
+)
+
+// GetJobTags returns the tags a list job adds titles with.
+// No tags means the job uses the tag of its source.
+func (rg *RouteGroup) GetJobTags(ctx *respond.Ctx) error {
+	listType := ctx.Params("listType")
+
+	tags, err := rg.scheduler.GetJobTags(listType)
+	if err != nil {
+		if errors.Is(err, scheduler.ErrUnknownJobType) {
+			return commonErrors.ErrNotFound().SetDetail("Unknown job type '%s'", listType)
+		}
+
+		log.Errorf("error fetching tags for %s job: %v", listType, err)
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to retrieve job tags")
+	}
+
+	return ctx.JSON(fiber.Map{"job_type": listType, "tags": tags})
+}
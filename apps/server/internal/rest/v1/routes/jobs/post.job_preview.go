@@ -0,0 +1,32 @@
+package jobs
+
+import (
+	"errors"
+
+	"github.com/charmbracelet/log"
+	"github.com/mahcks/blockbusterr/internal/helpers/trakt"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/internal/scheduler"
+	commonErrors "github.com/mahcks/blockbusterr/pkg/errors"
+)
+
+// PostJobPreview returns what a list job would request right now without requesting anything
+func (rg *RouteGroup) PostJobPreview(ctx *respond.Ctx) error {
+	jobType := ctx.Params("type")
+
+	preview, err := rg.scheduler.PreviewJob(jobType)
+	if err != nil {
+		if errors.Is(err, scheduler.ErrUnknownJobType) {
+			return commonErrors.ErrNotFound().SetDetail("Unknown job type '%s'", jobType)
+		}
+
+		if errors.Is(err, trakt.ErrNoTraktSettings) {
+			return commonErrors.ErrBadRequest().SetDetail("Trakt client ID is not set")
+		}
+
+		log.Errorf("error previewing %s job: %v", jobType, err)
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to preview job")
+	}
+
+	return ctx.JSON(preview)
+}
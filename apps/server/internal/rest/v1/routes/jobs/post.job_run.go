@@ -0,0 +1,32 @@
+package jobs
+
+import (
+	"errors"
+
+	"github.com/charmbracelet/log"
+	"github.com/gofiber/fiber/v2"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/internal/scheduler"
+	commonErrors "github.com/mahcks/blockbusterr/pkg/errors"
+)
+
+// PostJobRun starts a list job in the background and returns the ID of the run
+func (rg *RouteGroup) PostJobRun(ctx *respond.Ctx) error {
+	listType := ctx.Params("listType")
+
+	runID, err := rg.scheduler.StartJob(listType)
+	if err != nil {
+		if errors.Is(err, scheduler.ErrUnknownJobType) {
+			return commonErrors.ErrNotFound().SetDetail("Unknown job type '%s'", listType)
+		}
+
+		if errors.Is(err, scheduler.ErrJobAlreadyRunning) {
+			return commonErrors.ErrConflict().SetDetail("The %s job is already running", listType)
+		}
+
+		log.Errorf("error starting %s job: %v", listType, err)
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to start job")
+	}
+
+	return ctx.Status(fiber.StatusAccepted).JSON(fiber.Map{"run_id": runID})
+}
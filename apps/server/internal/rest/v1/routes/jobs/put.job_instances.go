@@ -0,0 +1,42 @@
+package jobs
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/charmbracelet/log"
+	"github.com/gofiber/fiber/v2"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/internal/scheduler"
+	commonErrors "github.com/mahcks/blockbusterr/pkg/errors"
+)
+
+type JobInstancesPayload struct {
+	InstanceIDs []int `json:"instance_ids"` // Radarr instances for movie jobs, Sonarr instances for show jobs. Empty sends to every instance.
+}
+
+// UpdateJobInstances sets the Radarr or Sonarr instances a list job sends to
+func (rg *RouteGroup) UpdateJobInstances(ctx *respond.Ctx) error {
+	listType := ctx.Params("listType")
+
+	var payload JobInstancesPayload
+	if err := json.Unmarshal(ctx.Body(), &payload); err != nil {
+		return commonErrors.ErrBadRequest().SetDetail("Invalid JSON payload")
+	}
+
+	err := rg.scheduler.SetJobInstances(listType, payload.InstanceIDs)
+	if err != nil {
+		if errors.Is(err, scheduler.ErrUnknownJobType) {
+			return commonErrors.ErrNotFound().SetDetail("Unknown job type '%s'", listType)
+		}
+
+		if errors.Is(err, scheduler.ErrUnknownInstance) {
+			return commonErrors.ErrBadRequest().SetDetail("Invalid instance for %s job: %v", listType, err)
+		}
+
+		log.Errorf("error updating instances for %s job: %v", listType, err)
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to update job instances")
+	}
+
+	return ctx.JSON(fiber.Map{"success": true})
+}
@@ -0,0 +1,51 @@
+package jobs
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/charmbracelet/log"
+	"github.com/gofiber/fiber/v2"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/internal/scheduler"
+	commonErrors "github.com/mahcks/blockbusterr/pkg/errors"
+	"github.com/mahcks/blockbusterr/pkg/structures"
+)
+
+type JobMonitorPayload struct {
+	Monitor *string `json:"monitor"` // Sonarr monitor strategy, null to use the monitor option of the show settings
+}
+
+// UpdateJobMonitor sets the Sonarr monitor strategy of a show list job
+func (rg *RouteGroup) UpdateJobMonitor(ctx *respond.Ctx) error {
+	listType := ctx.Params("listType")
+
+	var payload JobMonitorPayload
+	if err := json.Unmarshal(ctx.Body(), &payload); err != nil {
+		return commonErrors.ErrBadRequest().SetDetail("Invalid JSON payload")
+	}
+
+	var monitor structures.SonarrMonitor
+	if payload.Monitor != nil && *payload.Monitor != "" {
+		if !structures.IsValidSonarrMonitor(*payload.Monitor) {
+			return commonErrors.ErrValidationRejected().SetDetail("monitor must be one of all, future, firstSeason, latestSeason, pilot or none")
+		}
+		monitor = structures.SonarrMonitor(*payload.Monitor)
+	}
+
+	err := rg.scheduler.SetJobMonitor(listType, monitor)
+	if err != nil {
+		if errors.Is(err, scheduler.ErrUnknownJobType) {
+			return commonErrors.ErrNotFound().SetDetail("Unknown job type '%s'", listType)
+		}
+
+		if errors.Is(err, scheduler.ErrNotShowJob) {
+			return commonErrors.ErrBadRequest().SetDetail("The %s job doesn't request shows", listType)
+		}
+
+		log.Errorf("error updating monitor strategy for %s job: %v", listType, err)
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to update job monitor strategy")
+	}
+
+	return ctx.JSON(fiber.Map{"success": true})
+}
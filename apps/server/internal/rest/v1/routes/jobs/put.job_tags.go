@@ -0,0 +1,47 @@
+package jobs
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/charmbracelet/log"
+	"github.com/gofiber/fiber/v2"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/internal/scheduler"
+	commonErrors "github.com/mahcks/blockbusterr/pkg/errors"
+)
+
+type JobTagsPayload struct {
+	Tags []string `json:"tags"` // Radarr/Sonarr tag labels, empty to use the tag of the source
+}
+
+// UpdateJobTags replaces the tags a list job adds titles with
+func (rg *RouteGroup) UpdateJobTags(ctx *respond.Ctx) error {
+	listType := ctx.Params("listType")
+
+	var payload JobTagsPayload
+	if err := json.Unmarshal(ctx.Body(), &payload); err != nil {
+		return commonErrors.ErrBadRequest().SetDetail("Invalid JSON payload")
+	}
+
+	tags := make([]string, 0, len(payload.Tags))
+	for _, label := range payload.Tags {
+		tag := scheduler.NormalizeTag(label)
+		if tag == "" {
+			return commonErrors.ErrValidationRejected().SetDetail("Tag '%s' must contain a letter or digit", label)
+		}
+		tags = append(tags, tag)
+	}
+
+	err := rg.scheduler.SetJobTags(listType, tags)
+	if err != nil {
+		if errors.Is(err, scheduler.ErrUnknownJobType) {
+			return commonErrors.ErrNotFound().SetDetail("Unknown job type '%s'", listType)
+		}
+
+		log.Errorf("error updating tags for %s job: %v", listType, err)
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to update job tags")
+	}
+
+	return ctx.JSON(fiber.Map{"success": true})
+}
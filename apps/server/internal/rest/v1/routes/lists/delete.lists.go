@@ -0,0 +1,35 @@
+package lists
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/charmbracelet/log"
+	"github.com/gofiber/fiber/v2"
+	"github.com/mahcks/blockbusterr/internal/db"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/internal/scheduler"
+	commonErrors "github.com/mahcks/blockbusterr/pkg/errors"
+)
+
+// DeleteListSource removes a list source and its scheduled job
+func (rg *RouteGroup) DeleteListSource(ctx *respond.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return commonErrors.ErrBadRequest().SetDetail("Invalid list source ID")
+	}
+
+	err = rg.gctx.Crate().SQL.Queries().DeleteListSource(ctx.Context(), id, scheduler.ListSourceJobType(id))
+	if err != nil {
+		if errors.Is(err, db.ErrNoListSource) {
+			return commonErrors.ErrNotFound().SetDetail("No list source found with ID %d", id)
+		}
+
+		log.Error("error deleting list source", "id", id, "error", err)
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to delete list source")
+	}
+
+	rg.scheduler.ReloadListSourceJobs()
+
+	return ctx.JSON(fiber.Map{"success": true})
+}
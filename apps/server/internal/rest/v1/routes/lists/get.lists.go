@@ -0,0 +1,48 @@
+package lists
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/charmbracelet/log"
+	"github.com/mahcks/blockbusterr/internal/db"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	commonErrors "github.com/mahcks/blockbusterr/pkg/errors"
+	"github.com/mahcks/blockbusterr/pkg/structures"
+)
+
+// GetListSources returns every list source
+func (rg *RouteGroup) GetListSources(ctx *respond.Ctx) error {
+	sources, err := rg.gctx.Crate().SQL.Queries().GetListSources(ctx.Context())
+	if err != nil {
+		log.Error("error fetching list sources", "error", err)
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to retrieve list sources")
+	}
+
+	response := make([]structures.ListSource, 0, len(sources))
+	for _, source := range sources {
+		response = append(response, toListSourceResponse(source))
+	}
+
+	return ctx.JSON(response)
+}
+
+// GetListSource returns a single list source
+func (rg *RouteGroup) GetListSource(ctx *respond.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return commonErrors.ErrBadRequest().SetDetail("Invalid list source ID")
+	}
+
+	source, err := rg.gctx.Crate().SQL.Queries().GetListSource(ctx.Context(), id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoListSource) {
+			return commonErrors.ErrNotFound().SetDetail("No list source found with ID %d", id)
+		}
+
+		log.Error("error fetching list source", "id", id, "error", err)
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to retrieve list source")
+	}
+
+	return ctx.JSON(toListSourceResponse(source))
+}
@@ -0,0 +1,38 @@
+package lists
+
+import (
+	"encoding/json"
+
+	"github.com/charmbracelet/log"
+	"github.com/gofiber/fiber/v2"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/pkg/errors"
+)
+
+// CreateListSource adds a list source and schedules it
+func (rg *RouteGroup) CreateListSource(ctx *respond.Ctx) error {
+	var payload ListSourcePayload
+	if err := json.Unmarshal(ctx.Body(), &payload); err != nil {
+		return errors.ErrBadRequest().SetDetail("Invalid JSON payload")
+	}
+
+	source, err := payload.toListSource()
+	if err != nil {
+		return err
+	}
+
+	source.ID, err = rg.gctx.Crate().SQL.Queries().CreateListSource(ctx.Context(), source)
+	if err != nil {
+		log.Error("error creating list source", "error", err)
+		return errors.ErrInternalServerError().SetDetail("Failed to create list source")
+	}
+
+	rg.scheduler.ReloadListSourceJobs()
+
+	created, err := rg.gctx.Crate().SQL.Queries().GetListSource(ctx.Context(), source.ID)
+	if err != nil {
+		return ctx.Status(fiber.StatusCreated).JSON(toListSourceResponse(source))
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(toListSourceResponse(created))
+}
@@ -0,0 +1,46 @@
+package lists
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/charmbracelet/log"
+	"github.com/gofiber/fiber/v2"
+	"github.com/mahcks/blockbusterr/internal/db"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	commonErrors "github.com/mahcks/blockbusterr/pkg/errors"
+)
+
+// UpdateListSource replaces a list source and reschedules it
+func (rg *RouteGroup) UpdateListSource(ctx *respond.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return commonErrors.ErrBadRequest().SetDetail("Invalid list source ID")
+	}
+
+	var payload ListSourcePayload
+	if err := json.Unmarshal(ctx.Body(), &payload); err != nil {
+		return commonErrors.ErrBadRequest().SetDetail("Invalid JSON payload")
+	}
+
+	source, err := payload.toListSource()
+	if err != nil {
+		return err
+	}
+	source.ID = id
+
+	err = rg.gctx.Crate().SQL.Queries().UpdateListSource(ctx.Context(), source)
+	if err != nil {
+		if errors.Is(err, db.ErrNoListSource) {
+			return commonErrors.ErrNotFound().SetDetail("No list source found with ID %d", id)
+		}
+
+		log.Error("error updating list source", "id", id, "error", err)
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to update list source")
+	}
+
+	rg.scheduler.ReloadListSourceJobs()
+
+	return ctx.JSON(fiber.Map{"success": true})
+}
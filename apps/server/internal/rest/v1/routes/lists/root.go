@@ -0,0 +1,150 @@
+package lists
+
+import (
+	"strings"
+
+	"github.com/mahcks/blockbusterr/internal/db"
+	"github.com/mahcks/blockbusterr/internal/global"
+	"github.com/mahcks/blockbusterr/internal/helpers"
+	"github.com/mahcks/blockbusterr/internal/scheduler"
+	"github.com/mahcks/blockbusterr/pkg/errors"
+	"github.com/mahcks/blockbusterr/pkg/structures"
+	"github.com/mahcks/blockbusterr/pkg/utils"
+)
+
+type RouteGroup struct {
+	gctx      global.Context
+	helpers   *helpers.Helpers
+	scheduler *scheduler.Scheduler
+}
+
+func NewRouteGroup(gctx global.Context, helpers *helpers.Helpers, scheduler *scheduler.Scheduler) *RouteGroup {
+	return &RouteGroup{
+		gctx:      gctx,
+		helpers:   helpers,
+		scheduler: scheduler,
+	}
+}
+
+type ListSourcePayload struct {
+	Name       *string `json:"name"`
+	MediaType  *string `json:"media_type"`  // Either "movie" or "show"
+	SourceType *string `json:"source_type"` // Either "list", "watchlist" or "recommendations"
+	Username   *string `json:"username"`
+	ListSlug   *string `json:"list_slug"`
+	Cron       *string `json:"cron"`
+	Limit      *int    `json:"limit"`
+	MinYear    *int    `json:"min_year"`
+	MaxYear    *int    `json:"max_year"`
+	MinRuntime *int    `json:"min_runtime"`
+	MaxRuntime *int    `json:"max_runtime"`
+	Enabled    *bool   `json:"enabled"`
+}
+
+// toListSource validates the payload and converts it to a list source
+func (p ListSourcePayload) toListSource() (db.ListSource, error) {
+	source := db.ListSource{
+		Limit:      10,
+		Enabled:    true,
+		MinYear:    utils.PointerToNullInt32(p.MinYear),
+		MaxYear:    utils.PointerToNullInt32(p.MaxYear),
+		MinRuntime: utils.PointerToNullInt32(p.MinRuntime),
+		MaxRuntime: utils.PointerToNullInt32(p.MaxRuntime),
+	}
+
+	if p.Name == nil || strings.TrimSpace(*p.Name) == "" {
+		return source, errors.ErrBadRequest().SetDetail("Name is required")
+	}
+	source.Name = strings.TrimSpace(*p.Name)
+
+	if p.MediaType == nil {
+		return source, errors.ErrBadRequest().SetDetail("Media type is required")
+	}
+	switch structures.ListSourceMediaType(*p.MediaType) {
+	case structures.ListSourceMediaTypeMovie, structures.ListSourceMediaTypeShow:
+		source.MediaType = *p.MediaType
+	default:
+		return source, errors.ErrBadRequest().SetDetail("Invalid media type '%s', expected 'movie' or 'show'", *p.MediaType)
+	}
+
+	if p.SourceType == nil {
+		return source, errors.ErrBadRequest().SetDetail("Source type is required")
+	}
+	source.SourceType = *p.SourceType
+
+	username := ""
+	if p.Username != nil {
+		username = strings.TrimSpace(*p.Username)
+	}
+	listSlug := ""
+	if p.ListSlug != nil {
+		listSlug = strings.TrimSpace(*p.ListSlug)
+	}
+
+	switch structures.ListSourceType(*p.SourceType) {
+	case structures.ListSourceTypeList:
+		if username == "" || listSlug == "" {
+			return source, errors.ErrBadRequest().SetDetail("A username and list slug are required for a list")
+		}
+		source.Username = utils.StringToNullString(username)
+		source.ListSlug = utils.StringToNullString(listSlug)
+	case structures.ListSourceTypeWatchlist:
+		if username == "" {
+			return source, errors.ErrBadRequest().SetDetail("A username is required for a watchlist")
+		}
+		source.Username = utils.StringToNullString(username)
+	case structures.ListSourceTypeRecommendations:
+		// Recommendations always belong to the authorized Trakt account
+	default:
+		return source, errors.ErrBadRequest().SetDetail("Invalid source type '%s', expected 'list', 'watchlist' or 'recommendations'", *p.SourceType)
+	}
+
+	if p.Cron != nil && strings.TrimSpace(*p.Cron) != "" {
+		if err := utils.ValidateCronExpression(*p.Cron); err != nil {
+			return source, errors.ErrBadRequest().SetDetail("Invalid cron expression: %v", err)
+		}
+		source.Cron = utils.StringToNullString(strings.TrimSpace(*p.Cron))
+	}
+
+	if p.Limit != nil {
+		if *p.Limit < 1 {
+			return source, errors.ErrBadRequest().SetDetail("Limit must be at least 1")
+		}
+		source.Limit = *p.Limit
+	}
+
+	if p.MinYear != nil && p.MaxYear != nil && *p.MinYear > *p.MaxYear {
+		return source, errors.ErrBadRequest().SetDetail("Minimum year can't be after the maximum year")
+	}
+
+	if p.MinRuntime != nil && p.MaxRuntime != nil && *p.MinRuntime > *p.MaxRuntime {
+		return source, errors.ErrBadRequest().SetDetail("Minimum runtime can't be longer than the maximum runtime")
+	}
+
+	if p.Enabled != nil {
+		source.Enabled = *p.Enabled
+	}
+
+	return source, nil
+}
+
+// toListSourceResponse converts a list source to its JSON representation
+func toListSourceResponse(source db.ListSource) structures.ListSource {
+	return structures.ListSource{
+		ID:         source.ID,
+		JobType:    scheduler.ListSourceJobType(source.ID),
+		Name:       source.Name,
+		MediaType:  structures.ListSourceMediaType(source.MediaType),
+		SourceType: structures.ListSourceType(source.SourceType),
+		Username:   utils.NullStringToPointer(source.Username),
+		ListSlug:   utils.NullStringToPointer(source.ListSlug),
+		Cron:       utils.NullStringToPointer(source.Cron),
+		Limit:      source.Limit,
+		MinYear:    utils.NullIntToPointer(source.MinYear),
+		MaxYear:    utils.NullIntToPointer(source.MaxYear),
+		MinRuntime: utils.NullIntToPointer(source.MinRuntime),
+		MaxRuntime: utils.NullIntToPointer(source.MaxRuntime),
+		Enabled:    source.Enabled,
+		CreatedAt:  source.CreatedAt,
+	}
+}
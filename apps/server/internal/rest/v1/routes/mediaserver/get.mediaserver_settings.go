@@ -0,0 +1,41 @@
+package mediaserver
+
+import (
+	"github.com/charmbracelet/log"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/internal/secrets"
+	"github.com/mahcks/blockbusterr/pkg/errors"
+	"github.com/mahcks/blockbusterr/pkg/structures"
+	"github.com/mahcks/blockbusterr/pkg/utils"
+)
+
+// GetMediaServerSettings returns the settings of the media server along with the size of the library cache
+func (rg *RouteGroup) GetMediaServerSettings(ctx *respond.Ctx) error {
+	settings, err := rg.gctx.Crate().SQL.Queries().GetMediaServerSettings(ctx.Context())
+	if err != nil {
+		log.Errorf("error fetching media server settings: %v", err)
+		return errors.ErrInternalServerError().SetDetail("Failed to retrieve media server settings")
+	}
+
+	movies, shows, err := rg.gctx.Crate().SQL.Queries().CountMediaServerLibrary(ctx.Context())
+	if err != nil {
+		log.Errorf("error counting media server library: %v", err)
+		return errors.ErrInternalServerError().SetDetail("Failed to retrieve media server library")
+	}
+
+	response := structures.MediaServerSettings{
+		Enabled:    settings.Enabled,
+		ServerType: utils.NullStringToPointer(settings.ServerType),
+		URL:        utils.NullStringToPointer(settings.URL),
+		APIKey:     secrets.MaskNullString(settings.APIKey),
+		Cron:       utils.NullStringToPointer(settings.Cron),
+		Movies:     movies,
+		Shows:      shows,
+	}
+
+	if settings.SyncedAt.Valid {
+		response.SyncedAt = &settings.SyncedAt.Time
+	}
+
+	return ctx.JSON(response)
+}
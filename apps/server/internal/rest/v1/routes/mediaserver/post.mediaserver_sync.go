@@ -0,0 +1,30 @@
+package mediaserver
+
+import (
+	"errors"
+
+	"github.com/charmbracelet/log"
+	"github.com/gofiber/fiber/v2"
+	"github.com/mahcks/blockbusterr/internal/helpers/mediaserver"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/internal/scheduler"
+	commonErrors "github.com/mahcks/blockbusterr/pkg/errors"
+)
+
+// PostMediaServerSync refreshes the media server library cache right away and returns the number of cached items
+func (rg *RouteGroup) PostMediaServerSync(ctx *respond.Ctx) error {
+	count, err := rg.scheduler.SyncMediaServerLibrary()
+	if err != nil {
+		switch {
+		case errors.Is(err, scheduler.ErrMediaServerSyncRunning):
+			return commonErrors.ErrConflict().SetDetail("The media server library is already being refreshed")
+		case errors.Is(err, mediaserver.ErrNoMediaServer), errors.Is(err, mediaserver.ErrUnknownMediaServerType):
+			return commonErrors.ErrValidationRejected().SetDetail("%v", err)
+		}
+
+		log.Errorf("error refreshing media server library: %v", err)
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to refresh the media server library")
+	}
+
+	return ctx.JSON(fiber.Map{"items": count})
+}
@@ -0,0 +1,80 @@
+package mediaserver
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/gofiber/fiber/v2"
+	"github.com/mahcks/blockbusterr/internal/helpers/mediaserver"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/internal/secrets"
+	"github.com/mahcks/blockbusterr/pkg/errors"
+	"github.com/mahcks/blockbusterr/pkg/utils"
+)
+
+type MediaServerSettingsPayload struct {
+	Enabled    *bool   `json:"enabled"`     // Whether titles already in the media server library are skipped
+	ServerType *string `json:"server_type"` // Either "plex", "jellyfin" or "emby"
+	URL        *string `json:"url"`         // Base URL of the media server
+	APIKey     *string `json:"api_key"`     // Plex token or Jellyfin/Emby API key, the placeholder keeps the stored key
+	Cron       *string `json:"cron"`        // Cron expression the library cache is refreshed on, empty to not schedule it
+}
+
+// UpdateMediaServerSettings updates the settings of the media server and reschedules the library refresh
+func (rg *RouteGroup) UpdateMediaServerSettings(ctx *respond.Ctx) error {
+	var payload MediaServerSettingsPayload
+	if err := json.Unmarshal(ctx.Body(), &payload); err != nil {
+		return errors.ErrBadRequest().SetDetail("Invalid JSON payload")
+	}
+
+	settings, err := rg.gctx.Crate().SQL.Queries().GetMediaServerSettings(ctx.Context())
+	if err != nil {
+		log.Errorf("error fetching media server settings: %v", err)
+		return errors.ErrInternalServerError().SetDetail("Failed to retrieve media server settings")
+	}
+
+	if payload.Enabled != nil {
+		settings.Enabled = *payload.Enabled
+	}
+
+	if payload.ServerType != nil {
+		serverType := strings.ToLower(strings.TrimSpace(*payload.ServerType))
+		if !mediaserver.IsValidServerType(serverType) {
+			return errors.ErrValidationRejected().SetDetail("server_type must be either plex, jellyfin or emby")
+		}
+		settings.ServerType = utils.StringToNullString(serverType)
+	}
+
+	if payload.URL != nil {
+		settings.URL = utils.StringToNullString(strings.TrimSpace(*payload.URL))
+	}
+
+	// The placeholder from the GET response means the stored key should be kept
+	if payload.APIKey != nil && *payload.APIKey != secrets.Placeholder {
+		settings.APIKey = utils.StringToNullString(*payload.APIKey)
+	}
+
+	if payload.Cron != nil {
+		cron := strings.TrimSpace(*payload.Cron)
+		if cron != "" {
+			if err := utils.ValidateCronExpression(cron); err != nil {
+				return errors.ErrValidationRejected().SetDetail("Invalid cron expression: %v", err)
+			}
+		}
+		settings.Cron = utils.StringToNullString(cron)
+	}
+
+	if settings.Enabled && (!settings.ServerType.Valid || !settings.URL.Valid || !settings.APIKey.Valid) {
+		return errors.ErrValidationRejected().SetDetail("server_type, url and api_key are required to enable the media server")
+	}
+
+	if err := rg.gctx.Crate().SQL.Queries().UpdateMediaServerSettings(ctx.Context(), settings); err != nil {
+		log.Errorf("error updating media server settings: %v", err)
+		return errors.ErrInternalServerError().SetDetail("Failed to update media server settings")
+	}
+
+	rg.scheduler.ReloadMediaServerJob(settings)
+
+	return ctx.JSON(fiber.Map{"success": true})
+}
@@ -0,0 +1,37 @@
+package movies
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/charmbracelet/log"
+	"github.com/gofiber/fiber/v2"
+	"github.com/mahcks/blockbusterr/internal/db"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	commonErrors "github.com/mahcks/blockbusterr/pkg/errors"
+)
+
+// DeleteMovieFilterEntry removes an entry from one of the movie filter lists
+func (rg *RouteGroup) DeleteMovieFilterEntry(ctx *respond.Ctx) error {
+	list, err := movieFilterLists.Get(ctx.Params("list"))
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return commonErrors.ErrBadRequest().SetDetail("Invalid filter entry ID")
+	}
+
+	err = rg.gctx.Crate().SQL.Queries().DeleteFilterEntry(ctx.Context(), list.Table, id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoFilterEntry) {
+			return commonErrors.ErrNotFound().SetDetail("No entry found with ID %d", id)
+		}
+
+		log.Error("error deleting movie filter entry", "list", ctx.Params("list"), "id", id, "error", err)
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to delete movie filter entry")
+	}
+
+	return ctx.JSON(fiber.Map{"success": true})
+}
@@ -0,0 +1,15 @@
+package movies
+
+import (
+	"github.com/mahcks/blockbusterr/internal/db"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/filters"
+)
+
+// movieFilterLists are the movie filter lists by the name used in the route
+var movieFilterLists = filters.Lists{
+	"countries": {Table: db.MovieAllowedCountriesList, Normalize: filters.NormalizeCountryEntry},
+	"languages": {Table: db.MovieAllowedLanguagesList, Normalize: filters.NormalizeLanguageEntry},
+	"genres":    {Table: db.MovieBlacklistedGenresList, Normalize: filters.NormalizeTextEntry},
+	"keywords":  {Table: db.MovieBlacklistedKeywordsList, Normalize: filters.NormalizeKeywordEntry},
+	"tmdb_ids":  {Table: db.MovieBlacklistedTMDBIDsList, Normalize: filters.NormalizeIDEntry},
+}
@@ -0,0 +1,24 @@
+package movies
+
+import (
+	"github.com/charmbracelet/log"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/filters"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/pkg/errors"
+)
+
+// GetMovieFilter returns the entries of one of the movie filter lists
+func (rg *RouteGroup) GetMovieFilter(ctx *respond.Ctx) error {
+	list, err := movieFilterLists.Get(ctx.Params("list"))
+	if err != nil {
+		return err
+	}
+
+	entries, err := rg.gctx.Crate().SQL.Queries().GetFilterEntries(ctx.Context(), list.Table)
+	if err != nil {
+		log.Error("error fetching movie filter", "list", ctx.Params("list"), "error", err)
+		return errors.ErrInternalServerError().SetDetail("Failed to retrieve movie filter")
+	}
+
+	return ctx.JSON(filters.MapEntries(entries))
+}
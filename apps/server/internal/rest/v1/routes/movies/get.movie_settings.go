@@ -4,6 +4,7 @@ import (
 	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
 	"github.com/mahcks/blockbusterr/pkg/errors"
 	"github.com/mahcks/blockbusterr/pkg/structures"
+	"github.com/mahcks/blockbusterr/pkg/utils"
 )
 
 func (rg *RouteGroup) GetMovieSettings(ctx *respond.Ctx) error {
@@ -24,11 +25,26 @@ func (rg *RouteGroup) GetMovieSettings(ctx *respond.Ctx) error {
 		CronJobPopular:           nullStringToPointer(settings.CronPopular),
 		Trending:                 nullIntToPointer(settings.Trending),
 		CronJobTrending:          nullStringToPointer(settings.CronTrending),
+		Watched:                  nullIntToPointer(settings.Watched),
+		WatchedPeriod:            settings.WatchedPeriod,
+		CronJobWatched:           nullStringToPointer(settings.CronWatched),
+		Played:                   nullIntToPointer(settings.Played),
+		PlayedPeriod:             settings.PlayedPeriod,
+		CronJobPlayed:            nullStringToPointer(settings.CronPlayed),
+		Collected:                nullIntToPointer(settings.Collected),
+		CollectedPeriod:          settings.CollectedPeriod,
+		CronJobCollected:         nullStringToPointer(settings.CronCollected),
 		MaxRuntime:               nullIntToPointer(settings.MaxRuntime),
 		MinRuntime:               nullIntToPointer(settings.MinRuntime),
 		MinYear:                  nullIntToPointer(settings.MinYear),
 		MaxYear:                  nullIntToPointer(settings.MaxYear),
 		RottenTomatoes:           nullStringToPointer(settings.RottenTomatoes),
+		MinRating:                utils.NullFloatToPointer(settings.MinRating),
+		MinVotes:                 nullIntToPointer(settings.MinVotes),
+		MinIMDbRating:            utils.NullFloatToPointer(settings.MinIMDbRating),
+		MinMetacritic:            nullIntToPointer(settings.MinMetacritic),
+		AllowedCertifications:    mapCertifications(settings.AllowedCertifications),
+		BlockedCertifications:    mapCertifications(settings.BlockedCertifications),
 		AllowedCountries:         mapAllowedCountries(settings.AllowedCountries),
 		AllowedLanguages:         mapAllowedLanguages(settings.AllowedLanguages),
 		BlacklistedGenres:        mapBlacklistedGenres(settings.BlacklistedGenres),
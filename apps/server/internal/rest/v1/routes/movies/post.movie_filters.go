@@ -0,0 +1,37 @@
+package movies
+
+import (
+	"encoding/json"
+
+	"github.com/charmbracelet/log"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/filters"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/pkg/errors"
+	"github.com/mahcks/blockbusterr/pkg/structures"
+)
+
+// AddMovieFilterEntries adds entries to one of the movie filter lists, values already on the list are skipped
+func (rg *RouteGroup) AddMovieFilterEntries(ctx *respond.Ctx) error {
+	list, err := movieFilterLists.Get(ctx.Params("list"))
+	if err != nil {
+		return err
+	}
+
+	var payload []structures.FilterEntry
+	if err := json.Unmarshal(ctx.Body(), &payload); err != nil {
+		return errors.ErrBadRequest().SetDetail("Invalid JSON payload")
+	}
+
+	entries, err := list.NormalizeEntries(payload)
+	if err != nil {
+		return err
+	}
+
+	updated, err := rg.gctx.Crate().SQL.Queries().AddFilterEntries(ctx.Context(), list.Table, entries)
+	if err != nil {
+		log.Error("error adding movie filter entries", "list", ctx.Params("list"), "error", err)
+		return errors.ErrInternalServerError().SetDetail("Failed to update movie filter")
+	}
+
+	return ctx.JSON(filters.MapEntries(updated))
+}
@@ -1,33 +1,47 @@
 package movies
 
 import (
-	"database/sql"
 	"encoding/json"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/mahcks/blockbusterr/internal/db"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/filters"
 	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
 	"github.com/mahcks/blockbusterr/pkg/errors"
+	"github.com/mahcks/blockbusterr/pkg/structures"
 	"github.com/mahcks/blockbusterr/pkg/utils"
 )
 
 type MovieSettingPaylod struct {
-	Anticipated              *int    `json:"anticipated"`
-	CronJobAnticipated       *string `json:"cron_job_anticipated"`
-	BoxOffice                *int    `json:"box_office"`
-	CronJobBoxOffice         *string `json:"cron_job_box_office"`
-	Popular                  *int    `json:"popular"`
-	CronJobPopular           *string `json:"cron_job_popular"`
-	Trending                 *int    `json:"trending"`
-	CronJobTrending          *string `json:"cron_job_trending"`
-	MaxRuntime               *int    `json:"max_runtime"`
-	MinRuntime               *int    `json:"min_runtime"`
-	MinYear                  *int    `json:"min_year"`
-	MaxYear                  *int    `json:"max_year"`
-	AllowedCountries         *string `json:"allowed_countries"`
-	AllowedLanguages         *string `json:"allowed_languages"`
-	BlacklistedGenres        *string `json:"blacklisted_genres"`
-	BlacklistedTitleKeywords *string `json:"blacklisted_title_keywords"`
-	BlacklistedTMDBIDs       *string `json:"blacklisted_tmdb_ids"`
+	Anticipated              *int                   `json:"anticipated"`
+	CronJobAnticipated       *string                `json:"cron_job_anticipated"`
+	BoxOffice                *int                   `json:"box_office"`
+	CronJobBoxOffice         *string                `json:"cron_job_box_office"`
+	Popular                  *int                   `json:"popular"`
+	CronJobPopular           *string                `json:"cron_job_popular"`
+	Trending                 *int                   `json:"trending"`
+	CronJobTrending          *string                `json:"cron_job_trending"`
+	Watched                  *int                   `json:"watched"`
+	WatchedPeriod            *string                `json:"watched_period"`
+	CronJobWatched           *string                `json:"cron_job_watched"`
+	Played                   *int                   `json:"played"`
+	PlayedPeriod             *string                `json:"played_period"`
+	CronJobPlayed            *string                `json:"cron_job_played"`
+	Collected                *int                   `json:"collected"`
+	CollectedPeriod          *string                `json:"collected_period"`
+	CronJobCollected         *string                `json:"cron_job_collected"`
+	MaxRuntime               *int                   `json:"max_runtime"`
+	MinRuntime               *int                   `json:"min_runtime"`
+	MinYear                  *int                   `json:"min_year"`
+	MaxYear                  *int                   `json:"max_year"`
+	MinRating                *float64               `json:"min_rating"`
+	MinVotes                 *int                   `json:"min_votes"`
+	MinIMDbRating            *float64               `json:"min_imdb_rating"`
+	RottenTomatoes           *string                `json:"rotten_tomatoes"`
+	MinMetacritic            *int                   `json:"min_metacritic"`
+	AllowedCertifications    []string               `json:"allowed_certifications"`
+	BlockedCertifications    []string               `json:"blocked_certifications"`
+	BlacklistedTitleKeywords *filters.TitleKeywords `json:"blacklisted_title_keywords"` // A list or the comma-separated string of older clients
 }
 
 func (rg *RouteGroup) UpdateMovieSettings(ctx *respond.Ctx) error {
@@ -36,26 +50,116 @@ func (rg *RouteGroup) UpdateMovieSettings(ctx *respond.Ctx) error {
 		return errors.ErrBadRequest().SetDetail("Invalid JSON payload")
 	}
 
+	// Validate the cron expressions up front so a bad one never reaches the scheduler
+	cronExpressions := []struct {
+		field string
+		value *string
+	}{
+		{"cron_job_anticipated", payload.CronJobAnticipated},
+		{"cron_job_box_office", payload.CronJobBoxOffice},
+		{"cron_job_popular", payload.CronJobPopular},
+		{"cron_job_trending", payload.CronJobTrending},
+		{"cron_job_watched", payload.CronJobWatched},
+		{"cron_job_played", payload.CronJobPlayed},
+		{"cron_job_collected", payload.CronJobCollected},
+	}
+	for _, expr := range cronExpressions {
+		if expr.value == nil || *expr.value == "" {
+			continue
+		}
+
+		if err := utils.ValidateCronExpression(*expr.value); err != nil {
+			return errors.ErrBadRequest().SetDetail("Invalid cron expression for %s: %v", expr.field, err)
+		}
+	}
+
+	periods := []struct {
+		field string
+		value *string
+	}{
+		{"watched_period", payload.WatchedPeriod},
+		{"played_period", payload.PlayedPeriod},
+		{"collected_period", payload.CollectedPeriod},
+	}
+	for _, period := range periods {
+		if period.value != nil && !structures.IsValidTraktPeriod(*period.value) {
+			return errors.ErrValidationRejected().SetDetail("%s must be one of weekly, monthly, yearly or all", period.field)
+		}
+	}
+
+	if err := validateRatingFilters(payload.MinRating, payload.MinIMDbRating, payload.MinVotes, payload.MinMetacritic, payload.RottenTomatoes); err != nil {
+		return err
+	}
+
+	var titleKeywords []db.FilterEntry
+	if payload.BlacklistedTitleKeywords != nil {
+		stored, err := rg.gctx.Crate().SQL.Queries().GetFilterEntries(ctx.Context(), db.MovieBlacklistedKeywordsList)
+		if err != nil {
+			return errors.ErrInternalServerError().SetDetail("Failed to retrieve blacklisted title keywords")
+		}
+
+		titleKeywords, err = payload.BlacklistedTitleKeywords.Entries(stored)
+		if err != nil {
+			return err
+		}
+	}
+
 	utils.PrettyPrintStruct(payload)
-	err := rg.gctx.Crate().SQL.Queries().UpdateMovieSettings(
-		ctx.Context(),
-		utils.PointerToNullInt32(payload.Anticipated),
-		utils.PointerToNullInt32(payload.BoxOffice),
-		utils.PointerToNullInt32(payload.Popular),
-		utils.PointerToNullInt32(payload.Trending),
-		utils.PointerToNullInt32(payload.MaxRuntime),
-		utils.PointerToNullInt32(payload.MinRuntime),
-		utils.PointerToNullInt32(payload.MinYear),
-		utils.PointerToNullInt32(payload.MaxYear),
-		sql.NullString{String: "", Valid: false},
-		utils.PointerToNullString(payload.CronJobAnticipated),
-		utils.PointerToNullString(payload.CronJobBoxOffice),
-		utils.PointerToNullString(payload.CronJobPopular),
-		utils.PointerToNullString(payload.CronJobTrending),
-	)
+
+	// Fields left out of the payload keep their stored value, so older clients don't switch off the lists they don't know about
+	settings, err := rg.gctx.Crate().SQL.Queries().GetMovieSettings(ctx.Context())
+	if err != nil {
+		return errors.ErrInternalServerError().SetDetail("Failed to retrieve movie settings")
+	}
+
+	utils.PatchNullInt32(&settings.Anticipated, payload.Anticipated)
+	utils.PatchNullInt32(&settings.BoxOffice, payload.BoxOffice)
+	utils.PatchNullInt32(&settings.Popular, payload.Popular)
+	utils.PatchNullInt32(&settings.Trending, payload.Trending)
+	utils.PatchNullInt32(&settings.MaxRuntime, payload.MaxRuntime)
+	utils.PatchNullInt32(&settings.MinRuntime, payload.MinRuntime)
+	utils.PatchNullInt32(&settings.MinYear, payload.MinYear)
+	utils.PatchNullInt32(&settings.MaxYear, payload.MaxYear)
+	utils.PatchNullString(&settings.RottenTomatoes, payload.RottenTomatoes)
+	utils.PatchNullString(&settings.CronAnticipated, payload.CronJobAnticipated)
+	utils.PatchNullString(&settings.CronBoxOffice, payload.CronJobBoxOffice)
+	utils.PatchNullString(&settings.CronPopular, payload.CronJobPopular)
+	utils.PatchNullString(&settings.CronTrending, payload.CronJobTrending)
+	utils.PatchNullInt32(&settings.Watched, payload.Watched)
+	utils.Patch(&settings.WatchedPeriod, payload.WatchedPeriod)
+	utils.PatchNullString(&settings.CronWatched, payload.CronJobWatched)
+	utils.PatchNullInt32(&settings.Played, payload.Played)
+	utils.Patch(&settings.PlayedPeriod, payload.PlayedPeriod)
+	utils.PatchNullString(&settings.CronPlayed, payload.CronJobPlayed)
+	utils.PatchNullInt32(&settings.Collected, payload.Collected)
+	utils.Patch(&settings.CollectedPeriod, payload.CollectedPeriod)
+	utils.PatchNullString(&settings.CronCollected, payload.CronJobCollected)
+
+	utils.PatchNullFloat64(&settings.MinRating, payload.MinRating)
+	utils.PatchNullInt32(&settings.MinVotes, payload.MinVotes)
+	utils.PatchNullFloat64(&settings.MinIMDbRating, payload.MinIMDbRating)
+	utils.PatchNullInt32(&settings.MinMetacritic, payload.MinMetacritic)
+	patchCertifications(&settings.AllowedCertifications, payload.AllowedCertifications)
+	patchCertifications(&settings.BlockedCertifications, payload.BlockedCertifications)
+
+	err = rg.gctx.Crate().SQL.Queries().UpdateMovieSettings(ctx.Context(), settings)
 	if err != nil {
 		return errors.ErrInternalServerError().SetDetail("Failed to update movie settings")
 	}
 
+	// Leaving the keywords out of the payload keeps the current ones
+	if payload.BlacklistedTitleKeywords != nil {
+		if _, err := rg.gctx.Crate().SQL.Queries().ReplaceFilterEntries(ctx.Context(), db.MovieBlacklistedKeywordsList, titleKeywords); err != nil {
+			return errors.ErrInternalServerError().SetDetail("Failed to update blacklisted title keywords")
+		}
+	}
+
+	// Reschedule the movie jobs with the new cron expressions
+	settings, err = rg.gctx.Crate().SQL.Queries().GetMovieSettings(ctx.Context())
+	if err != nil {
+		return errors.ErrInternalServerError().SetDetail("Failed to reload movie settings")
+	}
+	rg.scheduler.ReloadMovieJobs(settings)
+
 	return ctx.JSON(fiber.Map{"success": true})
 }
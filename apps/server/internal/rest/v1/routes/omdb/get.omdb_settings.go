@@ -2,9 +2,9 @@ package omdb
 
 import (
 	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/internal/secrets"
 	"github.com/mahcks/blockbusterr/pkg/errors"
 	"github.com/mahcks/blockbusterr/pkg/structures"
-	"github.com/mahcks/blockbusterr/pkg/utils"
 )
 
 func (rg *RouteGroup) GetOMDbSettings(ctx *respond.Ctx) error {
@@ -17,7 +17,7 @@ func (rg *RouteGroup) GetOMDbSettings(ctx *respond.Ctx) error {
 	// Map the DB TraktSettings struct to the structures.TraktSettings struct for JSON response
 	response := structures.OMDbSettings{
 		ID:     settings.ID,
-		APIKey: utils.NullStringToPointer(settings.APIKey),
+		APIKey: secrets.MaskNullString(settings.APIKey),
 	}
 
 	return ctx.JSON(response)
@@ -5,6 +5,7 @@ import (
 
 	"github.com/charmbracelet/log"
 	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/internal/secrets"
 	"github.com/mahcks/blockbusterr/pkg/errors"
 	"github.com/mahcks/blockbusterr/pkg/structures"
 )
@@ -19,7 +20,23 @@ func (rg *RouteGroup) UpdateOMDbSettings(ctx *respond.Ctx) error {
 		return errors.ErrBadRequest()
 	}
 
-	err = rg.gctx.Crate().SQL.Queries().UpdateOMDbSettings(ctx.Context(), *requestSettings.APIKey)
+	if requestSettings.APIKey == nil {
+		return errors.ErrBadRequest().SetDetail("API key is required")
+	}
+
+	// The placeholder from the GET response means the stored key should be kept
+	apiKey := *requestSettings.APIKey
+	if apiKey == secrets.Placeholder {
+		existing, err := rg.gctx.Crate().SQL.Queries().GetOMDbSettings(ctx.Context())
+		if err != nil {
+			log.Errorf("error fetching omdb settings: %v", err)
+			return errors.ErrInternalServerError()
+		}
+
+		apiKey = existing.APIKey.String
+	}
+
+	err = rg.gctx.Crate().SQL.Queries().UpdateOMDbSettings(ctx.Context(), apiKey)
 	if err != nil {
 		log.Errorf("error updating trakt settings: %v", err)
 		return errors.ErrInternalServerError()
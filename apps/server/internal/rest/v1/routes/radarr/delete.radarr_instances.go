@@ -0,0 +1,32 @@
+package radarr
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/charmbracelet/log"
+	"github.com/gofiber/fiber/v2"
+	"github.com/mahcks/blockbusterr/internal/db"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	commonErrors "github.com/mahcks/blockbusterr/pkg/errors"
+)
+
+// DeleteRadarrInstance removes a Radarr instance and unassigns it from every movie list job
+func (rg *RouteGroup) DeleteRadarrInstance(ctx *respond.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return commonErrors.ErrBadRequest().SetDetail("Invalid instance ID")
+	}
+
+	err = rg.gctx.Crate().SQL.Queries().DeleteRadarrInstance(ctx.Context(), id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoRadarrInstance) {
+			return commonErrors.ErrNotFound().SetDetail("No Radarr instance found with ID %d", id)
+		}
+
+		log.Error("error deleting Radarr instance", "id", id, "error", err)
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to delete Radarr instance")
+	}
+
+	return ctx.JSON(fiber.Map{"success": true})
+}
@@ -0,0 +1,60 @@
+package radarr
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/mahcks/blockbusterr/internal/db"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/internal/secrets"
+	commonErrors "github.com/mahcks/blockbusterr/pkg/errors"
+	"github.com/mahcks/blockbusterr/pkg/structures"
+	"github.com/mahcks/blockbusterr/pkg/utils"
+)
+
+// GetRadarrInstances returns every Radarr instance
+func (rg *RouteGroup) GetRadarrInstances(ctx *respond.Ctx) error {
+	instances, err := rg.gctx.Crate().SQL.Queries().GetRadarrInstances(ctx.Context())
+	if err != nil {
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to retrieve Radarr instances")
+	}
+
+	response := make([]structures.RadarrSettings, 0, len(instances))
+	for _, instance := range instances {
+		response = append(response, toRadarrInstance(instance))
+	}
+
+	return ctx.JSON(response)
+}
+
+// GetRadarrInstance returns a single Radarr instance
+func (rg *RouteGroup) GetRadarrInstance(ctx *respond.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return commonErrors.ErrBadRequest().SetDetail("Invalid instance ID")
+	}
+
+	instance, err := rg.gctx.Crate().SQL.Queries().GetRadarrInstance(ctx.Context(), id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoRadarrInstance) {
+			return commonErrors.ErrNotFound().SetDetail("No Radarr instance found with ID %d", id)
+		}
+
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to retrieve Radarr instance")
+	}
+
+	return ctx.JSON(toRadarrInstance(instance))
+}
+
+// toRadarrInstance converts a Radarr instance to its API representation with the API key masked
+func toRadarrInstance(instance db.RadarrSettings) structures.RadarrSettings {
+	return structures.RadarrSettings{
+		ID:                  instance.ID,
+		Name:                instance.Name,
+		APIKey:              secrets.MaskNullString(instance.APIKey),
+		URL:                 utils.NullStringToPointer(instance.URL),
+		MinimumAvailability: utils.NullStringToPointer(instance.MinimumAvailability),
+		RootFolder:          utils.NullIntToPointer(instance.RootFolder),
+		Quality:             utils.NullIntToPointer(instance.Quality),
+	}
+}
@@ -2,6 +2,7 @@ package radarr
 
 import (
 	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/internal/secrets"
 	"github.com/mahcks/blockbusterr/pkg/errors"
 	"github.com/mahcks/blockbusterr/pkg/structures"
 	"github.com/mahcks/blockbusterr/pkg/utils"
@@ -16,7 +17,8 @@ func (rg *RouteGroup) GetRadarrSettings(ctx *respond.Ctx) error {
 
 	return ctx.JSON(structures.RadarrSettings{
 		ID:                  settings.ID,
-		APIKey:              utils.NullStringToPointer(settings.APIKey),
+		Name:                settings.Name,
+		APIKey:              secrets.MaskNullString(settings.APIKey),
 		URL:                 utils.NullStringToPointer(settings.URL),
 		MinimumAvailability: utils.NullStringToPointer(settings.MinimumAvailability),
 		RootFolder:          utils.NullIntToPointer(settings.RootFolder),
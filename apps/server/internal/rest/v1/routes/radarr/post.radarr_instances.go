@@ -0,0 +1,63 @@
+package radarr
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/gofiber/fiber/v2"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/internal/secrets"
+	"github.com/mahcks/blockbusterr/pkg/errors"
+	"github.com/mahcks/blockbusterr/pkg/utils"
+)
+
+type RadarrInstancePayload struct {
+	Name *string `json:"name"`
+	RadarrSettingsPayload
+}
+
+// validate checks the fields every Radarr instance needs
+func (p RadarrInstancePayload) validate() error {
+	if p.Name == nil || strings.TrimSpace(*p.Name) == "" {
+		return errors.ErrBadRequest().SetDetail("Instance name is required")
+	}
+
+	if p.URL == nil || strings.TrimSpace(*p.URL) == "" {
+		return errors.ErrBadRequest().SetDetail("Instance base URL is required")
+	}
+
+	return nil
+}
+
+// CreateRadarrInstance adds a Radarr instance
+func (rg *RouteGroup) CreateRadarrInstance(ctx *respond.Ctx) error {
+	var payload RadarrInstancePayload
+	if err := json.Unmarshal(ctx.Body(), &payload); err != nil {
+		return errors.ErrBadRequest().SetDetail("Invalid JSON payload")
+	}
+
+	if err := payload.validate(); err != nil {
+		return err
+	}
+
+	if payload.APIKey == nil || *payload.APIKey == "" || *payload.APIKey == secrets.Placeholder {
+		return errors.ErrBadRequest().SetDetail("Instance API key is required")
+	}
+
+	id, err := rg.gctx.Crate().SQL.Queries().CreateRadarrInstance(
+		ctx.Context(),
+		strings.TrimSpace(*payload.Name),
+		utils.PointerToNullString(payload.APIKey),
+		utils.PointerToNullString(payload.URL),
+		utils.PointerToNullString(payload.MinimumAvailability),
+		utils.PointerToNullInt32(payload.QualityProfile),
+		utils.PointerToNullInt32(payload.RootFolder),
+	)
+	if err != nil {
+		log.Error("error creating Radarr instance", "error", err)
+		return errors.ErrInternalServerError().SetDetail("Failed to create Radarr instance")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{"id": id})
+}
@@ -0,0 +1,65 @@
+package radarr
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/gofiber/fiber/v2"
+	"github.com/mahcks/blockbusterr/internal/db"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/internal/secrets"
+	commonErrors "github.com/mahcks/blockbusterr/pkg/errors"
+	"github.com/mahcks/blockbusterr/pkg/utils"
+)
+
+// UpdateRadarrInstance replaces the settings of a Radarr instance
+func (rg *RouteGroup) UpdateRadarrInstance(ctx *respond.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return commonErrors.ErrBadRequest().SetDetail("Invalid instance ID")
+	}
+
+	var payload RadarrInstancePayload
+	if err := json.Unmarshal(ctx.Body(), &payload); err != nil {
+		return commonErrors.ErrBadRequest().SetDetail("Invalid JSON payload")
+	}
+
+	if err := payload.validate(); err != nil {
+		return err
+	}
+
+	existing, err := rg.gctx.Crate().SQL.Queries().GetRadarrInstance(ctx.Context(), id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoRadarrInstance) {
+			return commonErrors.ErrNotFound().SetDetail("No Radarr instance found with ID %d", id)
+		}
+
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to retrieve Radarr instance")
+	}
+
+	// The placeholder from the GET response means the stored key should be kept
+	apiKey := utils.PointerToNullString(payload.APIKey)
+	if payload.APIKey != nil && *payload.APIKey == secrets.Placeholder {
+		apiKey = existing.APIKey
+	}
+
+	err = rg.gctx.Crate().SQL.Queries().UpdateRadarrInstance(
+		ctx.Context(),
+		id,
+		strings.TrimSpace(*payload.Name),
+		apiKey,
+		utils.PointerToNullString(payload.URL),
+		utils.PointerToNullString(payload.MinimumAvailability),
+		utils.PointerToNullInt32(payload.QualityProfile),
+		utils.PointerToNullInt32(payload.RootFolder),
+	)
+	if err != nil {
+		log.Error("error updating Radarr instance", "id", id, "error", err)
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to update Radarr instance")
+	}
+
+	return ctx.JSON(fiber.Map{"success": true})
+}
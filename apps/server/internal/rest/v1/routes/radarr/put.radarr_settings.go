@@ -6,6 +6,7 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/internal/secrets"
 	"github.com/mahcks/blockbusterr/pkg/errors"
 	"github.com/mahcks/blockbusterr/pkg/utils"
 )
@@ -25,10 +26,21 @@ func (rg *RouteGroup) UpdateRadarrSettings(ctx *respond.Ctx) error {
 		return errors.ErrBadRequest().SetDetail("Invalid JSON payload")
 	}
 
-	utils.PrettyPrintStruct(payload)
+	apiKey := utils.PointerToNullString(payload.APIKey)
+
+	// The placeholder from the GET response means the stored key should be kept
+	if payload.APIKey != nil && *payload.APIKey == secrets.Placeholder {
+		existing, err := rg.gctx.Crate().SQL.Queries().GetRadarrSettings(ctx.Context())
+		if err != nil {
+			return errors.ErrInternalServerError().SetDetail("Failed to retrieve Radarr settings")
+		}
+
+		apiKey = existing.APIKey
+	}
+
 	err := rg.gctx.Crate().SQL.Queries().UpdateRadarrSettings(
 		ctx.Context(),
-		utils.PointerToNullString(payload.APIKey),
+		apiKey,
 		utils.PointerToNullString(payload.URL),
 		utils.PointerToNullString(payload.MinimumAvailability),
 		utils.PointerToNullInt32(payload.QualityProfile),
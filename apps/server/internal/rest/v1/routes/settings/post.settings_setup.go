@@ -2,17 +2,23 @@ package settings
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 
 	"github.com/charmbracelet/log"
 	"github.com/gofiber/fiber/v2"
+	"github.com/mahcks/blockbusterr/internal/auth"
+	"github.com/mahcks/blockbusterr/internal/db"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/middleware"
 	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
-	"github.com/mahcks/blockbusterr/pkg/errors"
+	commonErrors "github.com/mahcks/blockbusterr/pkg/errors"
 	"github.com/mahcks/blockbusterr/pkg/utils"
 )
 
 type SetupSettings struct {
+	AdminUsername        string `json:"adminUsername"`
+	AdminPassword        string `json:"adminPassword"`
 	TraktClientID        string `json:"traktClientId"`
 	TraktClientSecret    string `json:"traktClientSecret"`
 	OMDbAPIKey           string `json:"omdbApiKey"`
@@ -32,25 +38,56 @@ type SetupSettings struct {
 func (rg *RouteGroup) PostSettingSetup(ctx *respond.Ctx) error {
 	var payload SetupSettings
 	if err := json.Unmarshal(ctx.Body(), &payload); err != nil {
-		return errors.ErrBadRequest().SetDetail("Invalid JSON payload")
+		return commonErrors.ErrBadRequest().SetDetail("Invalid JSON payload")
 	}
 
 	if payload.TraktClientID == "" {
-		return errors.ErrBadRequest().SetDetail("Trakt Client ID is required")
+		return commonErrors.ErrBadRequest().SetDetail("Trakt Client ID is required")
+	}
+
+	// The first run of the setup creates the admin user, later runs are already signed in
+	if _, signedIn := middleware.User(ctx.Ctx); !signedIn {
+		if err := auth.ValidateCredentials(payload.AdminUsername, payload.AdminPassword); err != nil {
+			return commonErrors.ErrValidationRejected().SetDetail(err.Error())
+		}
+
+		passwordHash, err := auth.HashPassword(payload.AdminPassword)
+		if err != nil {
+			log.Error("error hashing admin password", "error", err)
+			return commonErrors.ErrInternalServerError().SetDetail("Failed to create admin user")
+		}
+
+		user, err := rg.gctx.Crate().SQL.Queries().CreateFirstUser(ctx.Context(), payload.AdminUsername, passwordHash)
+		if errors.Is(err, db.ErrUsersExists) {
+			return commonErrors.ErrConflict().SetDetail("An admin user already exists, sign in to run the setup again")
+		}
+		if err != nil {
+			log.Error("error creating admin user", "error", err)
+			return commonErrors.ErrInternalServerError().SetDetail("Failed to create admin user")
+		}
+
+		// Sign the admin in so the rest of the wizard can continue
+		token, expiresAt, err := auth.CreateSession(ctx.Context(), rg.gctx.Crate().SQL.Queries(), user.ID)
+		if err != nil {
+			log.Error("error creating session", "error", err)
+			return commonErrors.ErrInternalServerError().SetDetail("Failed to sign in")
+		}
+
+		middleware.SetSessionCookie(ctx.Ctx, token, expiresAt)
 	}
 
 	// Create trakt settings
 	err := rg.gctx.Crate().SQL.Queries().CreateTraktSettings(ctx.Context(), payload.TraktClientID, payload.TraktClientSecret)
 	if err != nil {
 		log.Error("error creating Trakt settings", "error", err)
-		return errors.ErrInternalServerError().SetDetail("Failed to insert Trakt settings")
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to insert Trakt settings")
 	}
 
 	// Insert OMDb API key
 	err = rg.gctx.Crate().SQL.Queries().CreateOMDbSettings(ctx.Context(), payload.OMDbAPIKey)
 	if err != nil {
 		log.Error("error creating OMDb settings", "error", err)
-		return errors.ErrInternalServerError().SetDetail("Failed to insert OMDb settings")
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to insert OMDb settings")
 	}
 
 	switch payload.SelectedMode {
@@ -60,12 +97,12 @@ func (rg *RouteGroup) PostSettingSetup(ctx *respond.Ctx) error {
 		// Update radarr settings since in the database schema, there's already a row that's waiting
 		convertedRadarrQualityProfile, err := strconv.Atoi(payload.RadarrQualityProfile)
 		if err != nil {
-			return errors.ErrBadRequest().SetDetail("Invalid Radarr quality profile")
+			return commonErrors.ErrBadRequest().SetDetail("Invalid Radarr quality profile")
 		}
 
 		convertedRadarrRootFolder, err := strconv.Atoi(payload.RadarrRootFolder)
 		if err != nil {
-			return errors.ErrBadRequest().SetDetail("Invalid Radarr root folder")
+			return commonErrors.ErrBadRequest().SetDetail("Invalid Radarr root folder")
 		}
 
 		err = rg.gctx.Crate().SQL.Queries().UpdateRadarrSettings(
@@ -78,17 +115,17 @@ func (rg *RouteGroup) PostSettingSetup(ctx *respond.Ctx) error {
 		)
 		if err != nil {
 			log.Error("error updating Radarr settings", "error", err)
-			return errors.ErrInternalServerError().SetDetail("Failed to update Radarr settings")
+			return commonErrors.ErrInternalServerError().SetDetail("Failed to update Radarr settings")
 		}
 
 		convertedSonarrQualityProfile, err := strconv.Atoi(payload.SonarrQualityProfile)
 		if err != nil {
-			return errors.ErrBadRequest().SetDetail("Invalid Sonarr quality profile")
+			return commonErrors.ErrBadRequest().SetDetail("Invalid Sonarr quality profile")
 		}
 
 		convertedSonarrRootFolder, err := strconv.Atoi(payload.SonarrRootFolder)
 		if err != nil {
-			return errors.ErrBadRequest().SetDetail("Invalid Sonarr root folder")
+			return commonErrors.ErrBadRequest().SetDetail("Invalid Sonarr root folder")
 		}
 
 		// Create Sonarr settings since it's not included in the DB schema
@@ -103,7 +140,7 @@ func (rg *RouteGroup) PostSettingSetup(ctx *respond.Ctx) error {
 		)
 		if err != nil {
 			log.Error("error updating Sonarr settings", "error", err)
-			return errors.ErrInternalServerError().SetDetail("Failed to update Sonarr settings")
+			return commonErrors.ErrInternalServerError().SetDetail("Failed to update Sonarr settings")
 		}
 
 	}
@@ -0,0 +1,16 @@
+package shows
+
+import (
+	"github.com/mahcks/blockbusterr/internal/db"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/filters"
+)
+
+// showFilterLists are the show filter lists by the name used in the route
+var showFilterLists = filters.Lists{
+	"countries": {Table: db.ShowAllowedCountriesList, Normalize: filters.NormalizeCountryEntry},
+	"languages": {Table: db.ShowAllowedLanguagesList, Normalize: filters.NormalizeLanguageEntry},
+	"genres":    {Table: db.ShowBlacklistedGenresList, Normalize: filters.NormalizeTextEntry},
+	"networks":  {Table: db.ShowBlacklistedNetworksList, Normalize: filters.NormalizeTextEntry},
+	"keywords":  {Table: db.ShowBlacklistedKeywordsList, Normalize: filters.NormalizeKeywordEntry},
+	"tvdb_ids":  {Table: db.ShowBlacklistedTVDBIDsList, Normalize: filters.NormalizeIDEntry},
+}
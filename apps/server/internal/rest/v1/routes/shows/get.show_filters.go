@@ -0,0 +1,24 @@
+package shows
+
+import (
+	"github.com/charmbracelet/log"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/filters"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/pkg/errors"
+)
+
+// GetShowFilter returns the entries of one of the show filter lists
+func (rg *RouteGroup) GetShowFilter(ctx *respond.Ctx) error {
+	list, err := showFilterLists.Get(ctx.Params("list"))
+	if err != nil {
+		return err
+	}
+
+	entries, err := rg.gctx.Crate().SQL.Queries().GetFilterEntries(ctx.Context(), list.Table)
+	if err != nil {
+		log.Error("error fetching show filter", "list", ctx.Params("list"), "error", err)
+		return errors.ErrInternalServerError().SetDetail("Failed to retrieve show filter")
+	}
+
+	return ctx.JSON(filters.MapEntries(entries))
+}
@@ -23,10 +23,30 @@ func (rg *RouteGroup) GetShowSettings(ctx *respond.Ctx) error {
 		CronJobPopular:           utils.NullStringToPointer(settings.CronJobPopular),
 		Trending:                 utils.NullIntToPointer(settings.Trending),
 		CronJobTrending:          utils.NullStringToPointer(settings.CronJobTrending),
+		Watched:                  utils.NullIntToPointer(settings.Watched),
+		WatchedPeriod:            settings.WatchedPeriod,
+		CronJobWatched:           utils.NullStringToPointer(settings.CronJobWatched),
+		Played:                   utils.NullIntToPointer(settings.Played),
+		PlayedPeriod:             settings.PlayedPeriod,
+		CronJobPlayed:            utils.NullStringToPointer(settings.CronJobPlayed),
+		Collected:                utils.NullIntToPointer(settings.Collected),
+		CollectedPeriod:          settings.CollectedPeriod,
+		CronJobCollected:         utils.NullStringToPointer(settings.CronJobCollected),
 		MaxRuntime:               utils.NullIntToPointer(settings.MaxRuntime),
 		MinRuntime:               utils.NullIntToPointer(settings.MinRuntime),
 		MinYear:                  utils.NullIntToPointer(settings.MinYear),
 		MaxYear:                  utils.NullIntToPointer(settings.MaxYear),
+		MinRating:                utils.NullFloatToPointer(settings.MinRating),
+		MinVotes:                 utils.NullIntToPointer(settings.MinVotes),
+		MinIMDbRating:            utils.NullFloatToPointer(settings.MinIMDbRating),
+		RottenTomatoes:           utils.NullStringToPointer(settings.RottenTomatoes),
+		MinMetacritic:            utils.NullIntToPointer(settings.MinMetacritic),
+		AllowedCertifications:    mapCertifications(settings.AllowedCertifications),
+		BlockedCertifications:    mapCertifications(settings.BlockedCertifications),
+		SeriesType:               structures.SonarrSeriesType(settings.SeriesType),
+		SeasonFolder:             settings.SeasonFolder,
+		Monitor:                  structures.SonarrMonitor(settings.Monitor),
+		SearchOnAdd:              settings.SearchOnAdd,
 		AllowedCountries:         mapAllowedCountries(settings.AllowedCountries),
 		AllowedLanguages:         mapAllowedLanguages(settings.AllowedLanguages),
 		BlacklistedGenres:        mapBlacklistedGenres(settings.BlacklistedGenres),
@@ -0,0 +1,37 @@
+package shows
+
+import (
+	"encoding/json"
+
+	"github.com/charmbracelet/log"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/filters"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/pkg/errors"
+	"github.com/mahcks/blockbusterr/pkg/structures"
+)
+
+// AddShowFilterEntries adds entries to one of the show filter lists, values already on the list are skipped
+func (rg *RouteGroup) AddShowFilterEntries(ctx *respond.Ctx) error {
+	list, err := showFilterLists.Get(ctx.Params("list"))
+	if err != nil {
+		return err
+	}
+
+	var payload []structures.FilterEntry
+	if err := json.Unmarshal(ctx.Body(), &payload); err != nil {
+		return errors.ErrBadRequest().SetDetail("Invalid JSON payload")
+	}
+
+	entries, err := list.NormalizeEntries(payload)
+	if err != nil {
+		return err
+	}
+
+	updated, err := rg.gctx.Crate().SQL.Queries().AddFilterEntries(ctx.Context(), list.Table, entries)
+	if err != nil {
+		log.Error("error adding show filter entries", "list", ctx.Params("list"), "error", err)
+		return errors.ErrInternalServerError().SetDetail("Failed to update show filter")
+	}
+
+	return ctx.JSON(filters.MapEntries(updated))
+}
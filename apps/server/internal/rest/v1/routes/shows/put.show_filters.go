@@ -0,0 +1,37 @@
+package shows
+
+import (
+	"encoding/json"
+
+	"github.com/charmbracelet/log"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/filters"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/pkg/errors"
+	"github.com/mahcks/blockbusterr/pkg/structures"
+)
+
+// ReplaceShowFilterEntries replaces every entry of one of the show filter lists, an empty list clears it
+func (rg *RouteGroup) ReplaceShowFilterEntries(ctx *respond.Ctx) error {
+	list, err := showFilterLists.Get(ctx.Params("list"))
+	if err != nil {
+		return err
+	}
+
+	var payload []structures.FilterEntry
+	if err := json.Unmarshal(ctx.Body(), &payload); err != nil {
+		return errors.ErrBadRequest().SetDetail("Invalid JSON payload")
+	}
+
+	entries, err := list.NormalizeEntries(payload)
+	if err != nil {
+		return err
+	}
+
+	updated, err := rg.gctx.Crate().SQL.Queries().ReplaceFilterEntries(ctx.Context(), list.Table, entries)
+	if err != nil {
+		log.Error("error replacing show filter entries", "list", ctx.Params("list"), "error", err)
+		return errors.ErrInternalServerError().SetDetail("Failed to update show filter")
+	}
+
+	return ctx.JSON(filters.MapEntries(updated))
+}
@@ -0,0 +1,176 @@
+package shows
+
+import (
+	"encoding/json"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/mahcks/blockbusterr/internal/db"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/filters"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/pkg/errors"
+	"github.com/mahcks/blockbusterr/pkg/structures"
+	"github.com/mahcks/blockbusterr/pkg/utils"
+)
+
+type ShowSettingPayload struct {
+	Anticipated           *int     `json:"anticipated"`
+	CronJobAnticipated    *string  `json:"cron_job_anticipated"`
+	Popular               *int     `json:"popular"`
+	CronJobPopular        *string  `json:"cron_job_popular"`
+	Trending              *int     `json:"trending"`
+	CronJobTrending       *string  `json:"cron_job_trending"`
+	Watched               *int     `json:"watched"`
+	WatchedPeriod         *string  `json:"watched_period"`
+	CronJobWatched        *string  `json:"cron_job_watched"`
+	Played                *int     `json:"played"`
+	PlayedPeriod          *string  `json:"played_period"`
+	CronJobPlayed         *string  `json:"cron_job_played"`
+	Collected             *int     `json:"collected"`
+	CollectedPeriod       *string  `json:"collected_period"`
+	CronJobCollected      *string  `json:"cron_job_collected"`
+	MaxRuntime            *int     `json:"max_runtime"`
+	MinRuntime            *int     `json:"min_runtime"`
+	MinYear               *int     `json:"min_year"`
+	MaxYear               *int     `json:"max_year"`
+	MinRating             *float64 `json:"min_rating"`
+	MinVotes              *int     `json:"min_votes"`
+	MinIMDbRating         *float64 `json:"min_imdb_rating"`
+	RottenTomatoes        *string  `json:"rotten_tomatoes"`
+	MinMetacritic         *int     `json:"min_metacritic"`
+	AllowedCertifications []string `json:"allowed_certifications"`
+	BlockedCertifications []string `json:"blocked_certifications"`
+	SeriesType            *string  `json:"series_type"`
+	SeasonFolder          *bool    `json:"season_folder"`
+	Monitor               *string  `json:"monitor"`
+	SearchOnAdd           *bool    `json:"search_on_add"`
+
+	BlacklistedTitleKeywords *filters.TitleKeywords `json:"blacklisted_title_keywords"` // A list or the comma-separated string of older clients
+}
+
+func (rg *RouteGroup) UpdateShowSettings(ctx *respond.Ctx) error {
+	var payload ShowSettingPayload
+	if err := json.Unmarshal(ctx.Body(), &payload); err != nil {
+		return errors.ErrBadRequest().SetDetail("Invalid JSON payload")
+	}
+
+	// Validate the cron expressions up front so a bad one never reaches the scheduler
+	cronExpressions := []struct {
+		field string
+		value *string
+	}{
+		{"cron_job_anticipated", payload.CronJobAnticipated},
+		{"cron_job_popular", payload.CronJobPopular},
+		{"cron_job_trending", payload.CronJobTrending},
+		{"cron_job_watched", payload.CronJobWatched},
+		{"cron_job_played", payload.CronJobPlayed},
+		{"cron_job_collected", payload.CronJobCollected},
+	}
+	for _, expr := range cronExpressions {
+		if expr.value == nil || *expr.value == "" {
+			continue
+		}
+
+		if err := utils.ValidateCronExpression(*expr.value); err != nil {
+			return errors.ErrBadRequest().SetDetail("Invalid cron expression for %s: %v", expr.field, err)
+		}
+	}
+
+	periods := []struct {
+		field string
+		value *string
+	}{
+		{"watched_period", payload.WatchedPeriod},
+		{"played_period", payload.PlayedPeriod},
+		{"collected_period", payload.CollectedPeriod},
+	}
+	for _, period := range periods {
+		if period.value != nil && !structures.IsValidTraktPeriod(*period.value) {
+			return errors.ErrValidationRejected().SetDetail("%s must be one of weekly, monthly, yearly or all", period.field)
+		}
+	}
+
+	if err := validateRatingFilters(payload.MinRating, payload.MinIMDbRating, payload.MinVotes, payload.MinMetacritic, payload.RottenTomatoes); err != nil {
+		return err
+	}
+
+	if payload.SeriesType != nil && !structures.IsValidSonarrSeriesType(*payload.SeriesType) {
+		return errors.ErrValidationRejected().SetDetail("series_type must be one of standard, anime or daily")
+	}
+
+	if payload.Monitor != nil && !structures.IsValidSonarrMonitor(*payload.Monitor) {
+		return errors.ErrValidationRejected().SetDetail("monitor must be one of all, future, firstSeason, latestSeason, pilot or none")
+	}
+
+	var titleKeywords []db.FilterEntry
+	if payload.BlacklistedTitleKeywords != nil {
+		stored, err := rg.gctx.Crate().SQL.Queries().GetFilterEntries(ctx.Context(), db.ShowBlacklistedKeywordsList)
+		if err != nil {
+			return errors.ErrInternalServerError().SetDetail("Failed to retrieve blacklisted title keywords")
+		}
+
+		titleKeywords, err = payload.BlacklistedTitleKeywords.Entries(stored)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Fields left out of the payload keep their stored value, so older clients don't switch off the lists they don't know about
+	settings, err := rg.gctx.Crate().SQL.Queries().GetShowSettings(ctx.Context())
+	if err != nil {
+		return errors.ErrInternalServerError().SetDetail("Failed to retrieve show settings")
+	}
+
+	utils.PatchNullInt32(&settings.Anticipated, payload.Anticipated)
+	utils.PatchNullInt32(&settings.Popular, payload.Popular)
+	utils.PatchNullInt32(&settings.Trending, payload.Trending)
+	utils.PatchNullInt32(&settings.MaxRuntime, payload.MaxRuntime)
+	utils.PatchNullInt32(&settings.MinRuntime, payload.MinRuntime)
+	utils.PatchNullInt32(&settings.MinYear, payload.MinYear)
+	utils.PatchNullInt32(&settings.MaxYear, payload.MaxYear)
+	utils.PatchNullString(&settings.CronJobAnticipated, payload.CronJobAnticipated)
+	utils.PatchNullString(&settings.CronJobPopular, payload.CronJobPopular)
+	utils.PatchNullString(&settings.CronJobTrending, payload.CronJobTrending)
+	utils.PatchNullInt32(&settings.Watched, payload.Watched)
+	utils.Patch(&settings.WatchedPeriod, payload.WatchedPeriod)
+	utils.PatchNullString(&settings.CronJobWatched, payload.CronJobWatched)
+	utils.PatchNullInt32(&settings.Played, payload.Played)
+	utils.Patch(&settings.PlayedPeriod, payload.PlayedPeriod)
+	utils.PatchNullString(&settings.CronJobPlayed, payload.CronJobPlayed)
+	utils.PatchNullInt32(&settings.Collected, payload.Collected)
+	utils.Patch(&settings.CollectedPeriod, payload.CollectedPeriod)
+	utils.PatchNullString(&settings.CronJobCollected, payload.CronJobCollected)
+
+	utils.PatchNullFloat64(&settings.MinRating, payload.MinRating)
+	utils.PatchNullInt32(&settings.MinVotes, payload.MinVotes)
+	utils.PatchNullFloat64(&settings.MinIMDbRating, payload.MinIMDbRating)
+	utils.PatchNullString(&settings.RottenTomatoes, payload.RottenTomatoes)
+	utils.PatchNullInt32(&settings.MinMetacritic, payload.MinMetacritic)
+	patchCertifications(&settings.AllowedCertifications, payload.AllowedCertifications)
+	patchCertifications(&settings.BlockedCertifications, payload.BlockedCertifications)
+
+	utils.Patch(&settings.SeriesType, payload.SeriesType)
+	utils.Patch(&settings.SeasonFolder, payload.SeasonFolder)
+	utils.Patch(&settings.Monitor, payload.Monitor)
+	utils.Patch(&settings.SearchOnAdd, payload.SearchOnAdd)
+
+	err = rg.gctx.Crate().SQL.Queries().UpdateShowSettings(ctx.Context(), settings)
+	if err != nil {
+		return errors.ErrInternalServerError().SetDetail("Failed to update show settings")
+	}
+
+	// Leaving the keywords out of the payload keeps the current ones
+	if payload.BlacklistedTitleKeywords != nil {
+		if _, err := rg.gctx.Crate().SQL.Queries().ReplaceFilterEntries(ctx.Context(), db.ShowBlacklistedKeywordsList, titleKeywords); err != nil {
+			return errors.ErrInternalServerError().SetDetail("Failed to update blacklisted title keywords")
+		}
+	}
+
+	// Reschedule the show jobs with the new cron expressions
+	settings, err = rg.gctx.Crate().SQL.Queries().GetShowSettings(ctx.Context())
+	if err != nil {
+		return errors.ErrInternalServerError().SetDetail("Failed to reload show settings")
+	}
+	rg.scheduler.ReloadShowJobs(settings)
+
+	return ctx.JSON(fiber.Map{"success": true})
+}
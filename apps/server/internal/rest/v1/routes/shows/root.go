@@ -1,21 +1,29 @@
 package shows
 
 import (
+	"database/sql"
+	"strings"
+
 	"github.com/mahcks/blockbusterr/internal/db"
 	"github.com/mahcks/blockbusterr/internal/global"
 	"github.com/mahcks/blockbusterr/internal/helpers"
+	"github.com/mahcks/blockbusterr/internal/scheduler"
+	"github.com/mahcks/blockbusterr/pkg/errors"
 	"github.com/mahcks/blockbusterr/pkg/structures"
+	"github.com/mahcks/blockbusterr/pkg/utils"
 )
 
 type RouteGroup struct {
-	gctx    global.Context
-	helpers *helpers.Helpers
+	gctx      global.Context
+	helpers   *helpers.Helpers
+	scheduler *scheduler.Scheduler
 }
 
-func NewRouteGroup(gctx global.Context, helpers *helpers.Helpers) *RouteGroup {
+func NewRouteGroup(gctx global.Context, helpers *helpers.Helpers, scheduler *scheduler.Scheduler) *RouteGroup {
 	return &RouteGroup{
-		gctx:    gctx,
-		helpers: helpers,
+		gctx:      gctx,
+		helpers:   helpers,
+		scheduler: scheduler,
 	}
 }
 
@@ -67,8 +75,9 @@ func mapBlacklistedShowTitleKeywords(dbKeywords []db.ShowBlacklistedTitleKeyword
 	keywords := make([]structures.BlacklistedShowTitleKeyword, len(dbKeywords))
 	for i, k := range dbKeywords {
 		keywords[i] = structures.BlacklistedShowTitleKeyword{
-			ID:      k.ID,
-			Keyword: k.Keyword,
+			ID:        k.ID,
+			Keyword:   k.Keyword,
+			MatchMode: structures.KeywordMatchMode(k.MatchMode),
 		}
 	}
 	return keywords
@@ -84,3 +93,61 @@ func mapBlacklistedTVDBIDs(dbTVDBIDs []db.ShowBlacklistedTVDBIDs) []structures.B
 	}
 	return tmdbIDs
 }
+
+// mapCertifications splits the stored comma-separated certifications for the JSON response
+func mapCertifications(certifications sql.NullString) []string {
+	mapped := []string{}
+	for _, cert := range strings.Split(certifications.String, ",") {
+		if cert = strings.TrimSpace(cert); cert != "" {
+			mapped = append(mapped, cert)
+		}
+	}
+	return mapped
+}
+
+// patchCertifications stores the certifications from a payload as a comma-separated list.
+// Leaving them out of the payload keeps the stored ones, an empty list clears them.
+func patchCertifications(dst *sql.NullString, certifications []string) {
+	if certifications == nil {
+		return
+	}
+
+	cleaned := []string{}
+	for _, cert := range certifications {
+		if cert = strings.TrimSpace(cert); cert != "" {
+			cleaned = append(cleaned, cert)
+		}
+	}
+	*dst = utils.StringToNullString(strings.Join(cleaned, ","))
+}
+
+// validateRatingFilters checks the rating and vote thresholds of a settings payload
+func validateRatingFilters(minRating, minIMDbRating *float64, minVotes, minMetacritic *int, rottenTomatoes *string) error {
+	for _, rating := range []struct {
+		field string
+		value *float64
+	}{
+		{"min_rating", minRating},
+		{"min_imdb_rating", minIMDbRating},
+	} {
+		if rating.value != nil && (*rating.value < 0 || *rating.value > 10) {
+			return errors.ErrValidationRejected().SetDetail("%s must be between 0 and 10", rating.field)
+		}
+	}
+
+	if minVotes != nil && *minVotes < 0 {
+		return errors.ErrValidationRejected().SetDetail("min_votes can't be negative")
+	}
+
+	if minMetacritic != nil && (*minMetacritic < 0 || *minMetacritic > 100) {
+		return errors.ErrValidationRejected().SetDetail("min_metacritic must be between 0 and 100")
+	}
+
+	if rottenTomatoes != nil && *rottenTomatoes != "" {
+		if _, err := utils.ParseRottenTomatoes(*rottenTomatoes); err != nil {
+			return errors.ErrValidationRejected().SetDetail("rotten_tomatoes: %v", err)
+		}
+	}
+
+	return nil
+}
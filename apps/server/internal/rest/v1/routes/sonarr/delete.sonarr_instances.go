@@ -0,0 +1,32 @@
+package sonarr
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/charmbracelet/log"
+	"github.com/gofiber/fiber/v2"
+	"github.com/mahcks/blockbusterr/internal/db"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	commonErrors "github.com/mahcks/blockbusterr/pkg/errors"
+)
+
+// DeleteSonarrInstance removes a Sonarr instance and unassigns it from every show list job
+func (rg *RouteGroup) DeleteSonarrInstance(ctx *respond.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return commonErrors.ErrBadRequest().SetDetail("Invalid instance ID")
+	}
+
+	err = rg.gctx.Crate().SQL.Queries().DeleteSonarrInstance(ctx.Context(), id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoSonarrInstance) {
+			return commonErrors.ErrNotFound().SetDetail("No Sonarr instance found with ID %d", id)
+		}
+
+		log.Error("error deleting Sonarr instance", "id", id, "error", err)
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to delete Sonarr instance")
+	}
+
+	return ctx.JSON(fiber.Map{"success": true})
+}
@@ -0,0 +1,61 @@
+package sonarr
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/mahcks/blockbusterr/internal/db"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/internal/secrets"
+	commonErrors "github.com/mahcks/blockbusterr/pkg/errors"
+	"github.com/mahcks/blockbusterr/pkg/structures"
+	"github.com/mahcks/blockbusterr/pkg/utils"
+)
+
+// GetSonarrInstances returns every Sonarr instance
+func (rg *RouteGroup) GetSonarrInstances(ctx *respond.Ctx) error {
+	instances, err := rg.gctx.Crate().SQL.Queries().GetSonarrInstances(ctx.Context())
+	if err != nil {
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to retrieve Sonarr instances")
+	}
+
+	response := make([]structures.SonarrSettings, 0, len(instances))
+	for _, instance := range instances {
+		response = append(response, toSonarrInstance(instance))
+	}
+
+	return ctx.JSON(response)
+}
+
+// GetSonarrInstance returns a single Sonarr instance
+func (rg *RouteGroup) GetSonarrInstance(ctx *respond.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return commonErrors.ErrBadRequest().SetDetail("Invalid instance ID")
+	}
+
+	instance, err := rg.gctx.Crate().SQL.Queries().GetSonarrInstance(ctx.Context(), id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoSonarrInstance) {
+			return commonErrors.ErrNotFound().SetDetail("No Sonarr instance found with ID %d", id)
+		}
+
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to retrieve Sonarr instance")
+	}
+
+	return ctx.JSON(toSonarrInstance(instance))
+}
+
+// toSonarrInstance converts a Sonarr instance to its API representation with the API key masked
+func toSonarrInstance(instance db.SonarrSettings) structures.SonarrSettings {
+	return structures.SonarrSettings{
+		ID:           instance.ID,
+		Name:         instance.Name,
+		APIKey:       secrets.MaskNullString(instance.APIKey),
+		URL:          utils.NullStringToPointer(instance.URL),
+		Language:     utils.NullStringToPointer(instance.Language),
+		Quality:      utils.NullIntToPointer(instance.Quality),
+		RootFolder:   utils.NullIntToPointer(instance.RootFolder),
+		SeasonFolder: utils.NullBoolToPointer(instance.SeasonFolder),
+	}
+}
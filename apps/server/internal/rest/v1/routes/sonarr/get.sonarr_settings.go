@@ -2,6 +2,7 @@ package sonarr
 
 import (
 	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/internal/secrets"
 	"github.com/mahcks/blockbusterr/pkg/errors"
 	"github.com/mahcks/blockbusterr/pkg/structures"
 	"github.com/mahcks/blockbusterr/pkg/utils"
@@ -15,7 +16,8 @@ func (rg *RouteGroup) GetSonarrSettings(ctx *respond.Ctx) error {
 
 	return ctx.JSON(structures.SonarrSettings{
 		ID:           settings.ID,
-		APIKey:       utils.NullStringToPointer(settings.APIKey),
+		Name:         settings.Name,
+		APIKey:       secrets.MaskNullString(settings.APIKey),
 		URL:          utils.NullStringToPointer(settings.URL),
 		Language:     utils.NullStringToPointer(settings.Language),
 		Quality:      utils.NullIntToPointer(settings.Quality),
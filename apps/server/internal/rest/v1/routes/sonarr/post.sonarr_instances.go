@@ -0,0 +1,69 @@
+package sonarr
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/gofiber/fiber/v2"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/internal/secrets"
+	"github.com/mahcks/blockbusterr/pkg/errors"
+	"github.com/mahcks/blockbusterr/pkg/utils"
+)
+
+type SonarrInstancePayload struct {
+	Name         *string `json:"name"`
+	APIKey       *string `json:"api_key"`
+	URL          *string `json:"url"`
+	Language     *string `json:"language"`
+	Quality      *int    `json:"quality"`
+	RootFolder   *int    `json:"root_folder"`
+	SeasonFolder *bool   `json:"season_folder"`
+}
+
+// validate checks the fields every Sonarr instance needs
+func (p SonarrInstancePayload) validate() error {
+	if p.Name == nil || strings.TrimSpace(*p.Name) == "" {
+		return errors.ErrBadRequest().SetDetail("Instance name is required")
+	}
+
+	if p.URL == nil || strings.TrimSpace(*p.URL) == "" {
+		return errors.ErrBadRequest().SetDetail("Instance URL is required")
+	}
+
+	return nil
+}
+
+// CreateSonarrInstance adds a Sonarr instance
+func (rg *RouteGroup) CreateSonarrInstance(ctx *respond.Ctx) error {
+	var payload SonarrInstancePayload
+	if err := json.Unmarshal(ctx.Body(), &payload); err != nil {
+		return errors.ErrBadRequest().SetDetail("Invalid JSON payload")
+	}
+
+	if err := payload.validate(); err != nil {
+		return err
+	}
+
+	if payload.APIKey == nil || *payload.APIKey == "" || *payload.APIKey == secrets.Placeholder {
+		return errors.ErrBadRequest().SetDetail("Instance API key is required")
+	}
+
+	id, err := rg.gctx.Crate().SQL.Queries().CreateSonarrInstance(
+		ctx.Context(),
+		strings.TrimSpace(*payload.Name),
+		utils.PointerToNullString(payload.APIKey),
+		utils.PointerToNullString(payload.URL),
+		utils.PointerToNullString(payload.Language),
+		utils.PointerToNullInt32(payload.Quality),
+		utils.PointerToNullInt32(payload.RootFolder),
+		utils.PointerToNullBool(payload.SeasonFolder),
+	)
+	if err != nil {
+		log.Error("error creating Sonarr instance", "error", err)
+		return errors.ErrInternalServerError().SetDetail("Failed to create Sonarr instance")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{"id": id})
+}
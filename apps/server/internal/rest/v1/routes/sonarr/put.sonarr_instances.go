@@ -0,0 +1,66 @@
+package sonarr
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/gofiber/fiber/v2"
+	"github.com/mahcks/blockbusterr/internal/db"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/internal/secrets"
+	commonErrors "github.com/mahcks/blockbusterr/pkg/errors"
+	"github.com/mahcks/blockbusterr/pkg/utils"
+)
+
+// UpdateSonarrInstance replaces the settings of a Sonarr instance
+func (rg *RouteGroup) UpdateSonarrInstance(ctx *respond.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return commonErrors.ErrBadRequest().SetDetail("Invalid instance ID")
+	}
+
+	var payload SonarrInstancePayload
+	if err := json.Unmarshal(ctx.Body(), &payload); err != nil {
+		return commonErrors.ErrBadRequest().SetDetail("Invalid JSON payload")
+	}
+
+	if err := payload.validate(); err != nil {
+		return err
+	}
+
+	existing, err := rg.gctx.Crate().SQL.Queries().GetSonarrInstance(ctx.Context(), id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoSonarrInstance) {
+			return commonErrors.ErrNotFound().SetDetail("No Sonarr instance found with ID %d", id)
+		}
+
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to retrieve Sonarr instance")
+	}
+
+	// The placeholder from the GET response means the stored key should be kept
+	apiKey := utils.PointerToNullString(payload.APIKey)
+	if payload.APIKey != nil && *payload.APIKey == secrets.Placeholder {
+		apiKey = existing.APIKey
+	}
+
+	err = rg.gctx.Crate().SQL.Queries().UpdateSonarrInstance(
+		ctx.Context(),
+		id,
+		strings.TrimSpace(*payload.Name),
+		apiKey,
+		utils.PointerToNullString(payload.URL),
+		utils.PointerToNullString(payload.Language),
+		utils.PointerToNullInt32(payload.Quality),
+		utils.PointerToNullInt32(payload.RootFolder),
+		utils.PointerToNullBool(payload.SeasonFolder),
+	)
+	if err != nil {
+		log.Error("error updating Sonarr instance", "id", id, "error", err)
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to update Sonarr instance")
+	}
+
+	return ctx.JSON(fiber.Map{"success": true})
+}
@@ -0,0 +1,24 @@
+package trakt
+
+import (
+	commonErrors "errors"
+
+	"github.com/charmbracelet/log"
+	"github.com/gofiber/fiber/v2"
+	"github.com/mahcks/blockbusterr/internal/helpers/trakt"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/pkg/errors"
+)
+
+// DeleteTraktAuth revokes the authorized Trakt account
+func (rg *RouteGroup) DeleteTraktAuth(ctx *respond.Ctx) error {
+	if err := rg.helpers.Trakt.RevokeAuth(ctx.Context()); err != nil {
+		if commonErrors.Is(err, trakt.ErrNoTraktSettings) {
+			return errors.ErrNotFound().SetDetail("No Trakt account is authorized")
+		}
+		log.Error("error revoking trakt authorization", "error", err)
+		return errors.ErrInternalServerError().SetDetail("Failed to revoke Trakt authorization")
+	}
+
+	return ctx.JSON(fiber.Map{"success": true})
+}
@@ -0,0 +1,22 @@
+package trakt
+
+import (
+	"github.com/charmbracelet/log"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/pkg/errors"
+	"github.com/mahcks/blockbusterr/pkg/structures"
+)
+
+// GetTraktAuth returns whether a Trakt account is authorized
+func (rg *RouteGroup) GetTraktAuth(ctx *respond.Ctx) error {
+	status, err := rg.helpers.Trakt.GetAuthStatus(ctx.Context())
+	if err != nil {
+		log.Error("error fetching trakt authorization status", "error", err)
+		return errors.ErrInternalServerError().SetDetail("Failed to retrieve Trakt authorization")
+	}
+
+	return ctx.JSON(structures.TraktAuthStatus{
+		Authorized: status.Authorized,
+		ExpiresAt:  status.ExpiresAt,
+	})
+}
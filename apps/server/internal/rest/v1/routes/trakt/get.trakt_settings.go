@@ -2,6 +2,7 @@ package trakt
 
 import (
 	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/internal/secrets"
 	"github.com/mahcks/blockbusterr/pkg/errors"
 	"github.com/mahcks/blockbusterr/pkg/structures"
 )
@@ -17,7 +18,7 @@ func (rg *RouteGroup) GetTraktSettings(ctx *respond.Ctx) error {
 	response := structures.TraktSettings{
 		ID:           settings.ID,
 		ClientID:     settings.ClientID,
-		ClientSecret: settings.ClientSecret,
+		ClientSecret: secrets.Mask(settings.ClientSecret),
 	}
 
 	return ctx.JSON(response)
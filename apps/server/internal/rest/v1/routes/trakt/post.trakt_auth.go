@@ -0,0 +1,59 @@
+package trakt
+
+import (
+	"encoding/json"
+	commonErrors "errors"
+
+	"github.com/charmbracelet/log"
+	"github.com/mahcks/blockbusterr/internal/helpers/trakt"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/pkg/errors"
+	"github.com/mahcks/blockbusterr/pkg/structures"
+)
+
+// StartTraktAuth starts the device-code flow. The user enters the returned user code at the
+// verification URL while the client polls PollTraktAuth with the device code.
+func (rg *RouteGroup) StartTraktAuth(ctx *respond.Ctx) error {
+	code, err := rg.helpers.Trakt.StartDeviceAuth(ctx.Context())
+	if err != nil {
+		if commonErrors.Is(err, trakt.ErrNoTraktSettings) {
+			return errors.ErrBadRequest().SetDetail("Set a Trakt client ID before authorizing an account")
+		}
+		log.Error("error starting trakt device authorization", "error", err)
+		return errors.ErrInternalServerError().SetDetail("Failed to start Trakt authorization")
+	}
+
+	return ctx.JSON(code)
+}
+
+// PollTraktAuth checks whether the user authorized the device code
+func (rg *RouteGroup) PollTraktAuth(ctx *respond.Ctx) error {
+	var payload structures.TraktAuthPollPayload
+	if err := json.Unmarshal(ctx.Body(), &payload); err != nil {
+		return errors.ErrBadRequest().SetDetail("Invalid JSON payload")
+	}
+
+	if payload.DeviceCode == "" {
+		return errors.ErrValidationRejected().SetDetail("device_code is required")
+	}
+
+	err := rg.helpers.Trakt.PollDeviceAuth(ctx.Context(), payload.DeviceCode)
+	switch {
+	case err == nil:
+		return ctx.JSON(structures.TraktAuthPollResponse{Status: structures.TraktAuthAuthorized})
+	case commonErrors.Is(err, trakt.ErrAuthPending):
+		return ctx.JSON(structures.TraktAuthPollResponse{Status: structures.TraktAuthPending})
+	case commonErrors.Is(err, trakt.ErrAuthSlowDown):
+		return ctx.JSON(structures.TraktAuthPollResponse{Status: structures.TraktAuthSlowDown})
+	case commonErrors.Is(err, trakt.ErrAuthInvalidCode),
+		commonErrors.Is(err, trakt.ErrAuthCodeUsed),
+		commonErrors.Is(err, trakt.ErrAuthExpired),
+		commonErrors.Is(err, trakt.ErrAuthDenied):
+		return errors.ErrBadRequest().SetDetail(err.Error())
+	case commonErrors.Is(err, trakt.ErrNoTraktSettings):
+		return errors.ErrBadRequest().SetDetail("Set a Trakt client ID before authorizing an account")
+	default:
+		log.Error("error polling trakt device authorization", "error", err)
+		return errors.ErrInternalServerError().SetDetail("Failed to poll Trakt authorization")
+	}
+}
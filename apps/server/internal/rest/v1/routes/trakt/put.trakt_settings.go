@@ -5,6 +5,7 @@ import (
 
 	"github.com/charmbracelet/log"
 	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/internal/secrets"
 	"github.com/mahcks/blockbusterr/pkg/errors"
 	"github.com/mahcks/blockbusterr/pkg/structures"
 )
@@ -19,6 +20,17 @@ func (rg *RouteGroup) UpdateTraktSettings(ctx *respond.Ctx) error {
 		return errors.ErrBadRequest()
 	}
 
+	// The placeholder from the GET response means the stored secret should be kept
+	if requestSettings.ClientSecret == secrets.Placeholder {
+		existing, err := rg.gctx.Crate().SQL.Queries().GetTraktSettings(ctx.Context())
+		if err != nil {
+			log.Errorf("error fetching trakt settings: %v", err)
+			return errors.ErrInternalServerError()
+		}
+
+		requestSettings.ClientSecret = existing.ClientSecret
+	}
+
 	err = rg.gctx.Crate().SQL.Queries().UpdateTraktSettings(ctx.Context(), requestSettings.ClientID, requestSettings.ClientSecret)
 	if err != nil {
 		log.Errorf("error updating trakt settings: %v", err)
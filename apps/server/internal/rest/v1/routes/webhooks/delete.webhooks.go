@@ -0,0 +1,34 @@
+package webhooks
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/charmbracelet/log"
+	"github.com/gofiber/fiber/v2"
+	"github.com/mahcks/blockbusterr/internal/db"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	commonErrors "github.com/mahcks/blockbusterr/pkg/errors"
+)
+
+// DeleteNotificationWebhook removes a notification webhook
+func (rg *RouteGroup) DeleteNotificationWebhook(ctx *respond.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return commonErrors.ErrBadRequest().SetDetail("Invalid notification webhook ID")
+	}
+
+	err = rg.gctx.Crate().SQL.Queries().DeleteNotificationWebhook(ctx.Context(), id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoNotificationWebhook) {
+			return commonErrors.ErrNotFound().SetDetail("No notification webhook found with ID %d", id)
+		}
+
+		log.Error("error deleting notification webhook", "id", id, "error", err)
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to delete notification webhook")
+	}
+
+	rg.reloadProviders()
+
+	return ctx.JSON(fiber.Map{"success": true})
+}
@@ -0,0 +1,24 @@
+package webhooks
+
+import (
+	"github.com/charmbracelet/log"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/pkg/errors"
+	"github.com/mahcks/blockbusterr/pkg/structures"
+)
+
+// GetNotificationWebhooks returns every notification webhook
+func (rg *RouteGroup) GetNotificationWebhooks(ctx *respond.Ctx) error {
+	webhooks, err := rg.gctx.Crate().SQL.Queries().GetNotificationWebhooks(ctx.Context())
+	if err != nil {
+		log.Error("error fetching notification webhooks", "error", err)
+		return errors.ErrInternalServerError().SetDetail("Failed to retrieve notification webhooks")
+	}
+
+	response := make([]structures.NotificationWebhook, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		response = append(response, rg.toNotificationWebhookResponse(webhook))
+	}
+
+	return ctx.JSON(response)
+}
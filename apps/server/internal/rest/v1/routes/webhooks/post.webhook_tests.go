@@ -0,0 +1,63 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/charmbracelet/log"
+	"github.com/gofiber/fiber/v2"
+	"github.com/mahcks/blockbusterr/internal/db"
+	"github.com/mahcks/blockbusterr/internal/helpers/trakt"
+	"github.com/mahcks/blockbusterr/internal/notifications"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	commonErrors "github.com/mahcks/blockbusterr/pkg/errors"
+	"github.com/mahcks/blockbusterr/pkg/structures"
+)
+
+// testMovie is the movie a test notification is sent for
+var testMovie = trakt.Movie{
+	Title:    "The Shawshank Redemption",
+	Year:     1994,
+	IDs:      trakt.MovieIDs{Trakt: 234, Slug: "the-shawshank-redemption-1994", IMDB: "tt0111161", TMDB: 278},
+	Overview: "Imprisoned in the 1940s for the double murder of his wife and her lover, upstanding banker Andy Dufresne begins a new life at the Shawshank prison.",
+	Genres:   []string{"drama", "crime"},
+	Rating:   8.8,
+}
+
+// PostNotificationWebhookTest sends a test notification to a webhook, even if it's disabled.
+// The request isn't retried, so a failure is reported right away.
+func (rg *RouteGroup) PostNotificationWebhookTest(ctx *respond.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return commonErrors.ErrBadRequest().SetDetail("Invalid notification webhook ID")
+	}
+
+	webhook, err := rg.gctx.Crate().SQL.Queries().GetNotificationWebhook(ctx.Context(), id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoNotificationWebhook) {
+			return commonErrors.ErrNotFound().SetDetail("No notification webhook found with ID %d", id)
+		}
+
+		log.Error("error fetching notification webhook", "id", id, "error", err)
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to retrieve notification webhook")
+	}
+
+	provider, err := notifications.NewWebhookNotificationFromDB(rg.gctx, webhook)
+	if err != nil {
+		log.Error("error creating notification webhook provider", "id", id, "error", err)
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to load notification webhook")
+	}
+	provider.MaxRetries = 0
+
+	payload, err := json.Marshal(testMovie)
+	if err != nil {
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to create test notification")
+	}
+
+	if err := provider.SendNotification(structures.MOVIEADDEDALERT, payload); err != nil {
+		return commonErrors.ErrBadRequest().SetDetail("Test notification failed: %v", err)
+	}
+
+	return ctx.JSON(fiber.Map{"success": true})
+}
@@ -0,0 +1,33 @@
+package webhooks
+
+import (
+	"encoding/json"
+
+	"github.com/charmbracelet/log"
+	"github.com/gofiber/fiber/v2"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	"github.com/mahcks/blockbusterr/pkg/errors"
+)
+
+// CreateNotificationWebhook adds a notification webhook
+func (rg *RouteGroup) CreateNotificationWebhook(ctx *respond.Ctx) error {
+	var payload NotificationWebhookPayload
+	if err := json.Unmarshal(ctx.Body(), &payload); err != nil {
+		return errors.ErrBadRequest().SetDetail("Invalid JSON payload")
+	}
+
+	webhook, err := payload.toNotificationWebhook(nil)
+	if err != nil {
+		return err
+	}
+
+	id, err := rg.gctx.Crate().SQL.Queries().CreateNotificationWebhook(ctx.Context(), webhook)
+	if err != nil {
+		log.Error("error creating notification webhook", "error", err)
+		return errors.ErrInternalServerError().SetDetail("Failed to create notification webhook")
+	}
+
+	rg.reloadProviders()
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{"id": id})
+}
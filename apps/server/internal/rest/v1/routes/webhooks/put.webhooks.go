@@ -0,0 +1,63 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/charmbracelet/log"
+	"github.com/gofiber/fiber/v2"
+	"github.com/mahcks/blockbusterr/internal/db"
+	"github.com/mahcks/blockbusterr/internal/notifications"
+	"github.com/mahcks/blockbusterr/internal/rest/v1/respond"
+	commonErrors "github.com/mahcks/blockbusterr/pkg/errors"
+)
+
+// UpdateNotificationWebhook replaces a notification webhook
+func (rg *RouteGroup) UpdateNotificationWebhook(ctx *respond.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return commonErrors.ErrBadRequest().SetDetail("Invalid notification webhook ID")
+	}
+
+	var payload NotificationWebhookPayload
+	if err := json.Unmarshal(ctx.Body(), &payload); err != nil {
+		return commonErrors.ErrBadRequest().SetDetail("Invalid JSON payload")
+	}
+
+	existing, err := rg.gctx.Crate().SQL.Queries().GetNotificationWebhook(ctx.Context(), id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoNotificationWebhook) {
+			return commonErrors.ErrNotFound().SetDetail("No notification webhook found with ID %d", id)
+		}
+
+		log.Error("error fetching notification webhook", "id", id, "error", err)
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to retrieve notification webhook")
+	}
+
+	storedHeaders, err := notifications.DecryptWebhookHeaders(rg.gctx, existing.Headers.String)
+	if err != nil {
+		log.Error("error reading notification webhook headers", "id", id, "error", err)
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to retrieve notification webhook")
+	}
+
+	webhook, err := payload.toNotificationWebhook(storedHeaders)
+	if err != nil {
+		return err
+	}
+	webhook.ID = id
+
+	err = rg.gctx.Crate().SQL.Queries().UpdateNotificationWebhook(ctx.Context(), webhook)
+	if err != nil {
+		if errors.Is(err, db.ErrNoNotificationWebhook) {
+			return commonErrors.ErrNotFound().SetDetail("No notification webhook found with ID %d", id)
+		}
+
+		log.Error("error updating notification webhook", "id", id, "error", err)
+		return commonErrors.ErrInternalServerError().SetDetail("Failed to update notification webhook")
+	}
+
+	rg.reloadProviders()
+
+	return ctx.JSON(fiber.Map{"success": true})
+}
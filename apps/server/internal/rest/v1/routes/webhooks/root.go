@@ -0,0 +1,150 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/mahcks/blockbusterr/internal/db"
+	"github.com/mahcks/blockbusterr/internal/global"
+	"github.com/mahcks/blockbusterr/internal/helpers"
+	"github.com/mahcks/blockbusterr/internal/notifications"
+	"github.com/mahcks/blockbusterr/internal/secrets"
+	"github.com/mahcks/blockbusterr/pkg/errors"
+	"github.com/mahcks/blockbusterr/pkg/structures"
+	"github.com/mahcks/blockbusterr/pkg/utils"
+)
+
+type RouteGroup struct {
+	gctx          global.Context
+	helpers       *helpers.Helpers
+	notifications *notifications.NotificationManager
+}
+
+func NewRouteGroup(gctx global.Context, helpers *helpers.Helpers, notifications *notifications.NotificationManager) *RouteGroup {
+	return &RouteGroup{
+		gctx:          gctx,
+		helpers:       helpers,
+		notifications: notifications,
+	}
+}
+
+type NotificationWebhookPayload struct {
+	Name         *string           `json:"name"`
+	Enabled      *bool             `json:"enabled"`
+	URL          *string           `json:"url"`
+	Method       *string           `json:"method"`        // Either "GET", "POST", "PUT" or "PATCH", defaults to "POST"
+	Headers      map[string]string `json:"headers"`       // Extra request headers, the placeholder keeps the stored value of a header
+	BodyTemplate *string           `json:"body_template"` // Go text/template the request body is rendered from, empty for the default JSON body
+	MaxRetries   *int              `json:"max_retries"`
+}
+
+// toNotificationWebhook validates the payload and converts it to a notification webhook.
+// Headers sent as the placeholder are taken from the stored headers.
+func (p NotificationWebhookPayload) toNotificationWebhook(storedHeaders map[string]string) (db.NotificationWebhook, error) {
+	webhook := db.NotificationWebhook{
+		Enabled:    true,
+		Method:     "POST",
+		MaxRetries: 3,
+	}
+
+	if p.Name == nil || strings.TrimSpace(*p.Name) == "" {
+		return webhook, errors.ErrBadRequest().SetDetail("Name is required")
+	}
+	webhook.Name = strings.TrimSpace(*p.Name)
+
+	if p.URL == nil || strings.TrimSpace(*p.URL) == "" {
+		return webhook, errors.ErrBadRequest().SetDetail("URL is required")
+	}
+	parsed, err := url.Parse(strings.TrimSpace(*p.URL))
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return webhook, errors.ErrValidationRejected().SetDetail("URL must be an absolute http or https URL")
+	}
+	webhook.URL = parsed.String()
+
+	if p.Method != nil {
+		method := strings.ToUpper(strings.TrimSpace(*p.Method))
+		if !notifications.IsValidWebhookMethod(method) {
+			return webhook, errors.ErrValidationRejected().SetDetail("Invalid method '%s', expected GET, POST, PUT or PATCH", *p.Method)
+		}
+		webhook.Method = method
+	}
+
+	if len(p.Headers) > 0 {
+		headers := make(map[string]string, len(p.Headers))
+		for key, value := range p.Headers {
+			key = strings.TrimSpace(key)
+			if key == "" {
+				return webhook, errors.ErrValidationRejected().SetDetail("Header names can't be empty")
+			}
+
+			if value == secrets.Placeholder {
+				stored, exists := storedHeaders[key]
+				if !exists {
+					return webhook, errors.ErrValidationRejected().SetDetail("No stored value for header '%s'", key)
+				}
+				value = stored
+			}
+			headers[key] = value
+		}
+
+		encoded, err := json.Marshal(headers)
+		if err != nil {
+			return webhook, errors.ErrBadRequest().SetDetail("Invalid headers")
+		}
+		webhook.Headers = utils.StringToNullString(string(encoded))
+	}
+
+	if p.BodyTemplate != nil && strings.TrimSpace(*p.BodyTemplate) != "" {
+		if _, err := notifications.ParseWebhookTemplate(*p.BodyTemplate); err != nil {
+			return webhook, errors.ErrValidationRejected().SetDetail("Invalid body template: %v", err)
+		}
+		webhook.BodyTemplate = utils.StringToNullString(*p.BodyTemplate)
+	}
+
+	if p.MaxRetries != nil {
+		if *p.MaxRetries < 0 || *p.MaxRetries > 10 {
+			return webhook, errors.ErrValidationRejected().SetDetail("max_retries must be between 0 and 10")
+		}
+		webhook.MaxRetries = *p.MaxRetries
+	}
+
+	if p.Enabled != nil {
+		webhook.Enabled = *p.Enabled
+	}
+
+	return webhook, nil
+}
+
+// toNotificationWebhookResponse converts a notification webhook to its JSON representation, masking the header values
+func (rg *RouteGroup) toNotificationWebhookResponse(webhook db.NotificationWebhook) structures.NotificationWebhook {
+	headers := map[string]string{}
+
+	stored, err := notifications.DecryptWebhookHeaders(rg.gctx, webhook.Headers.String)
+	if err != nil {
+		log.Warn("error reading notification webhook headers", "id", webhook.ID, "error", err)
+	}
+	for key := range stored {
+		headers[key] = secrets.Placeholder
+	}
+
+	return structures.NotificationWebhook{
+		ID:           webhook.ID,
+		Name:         webhook.Name,
+		Enabled:      webhook.Enabled,
+		URL:          webhook.URL,
+		Method:       webhook.Method,
+		Headers:      headers,
+		BodyTemplate: utils.NullStringToPointer(webhook.BodyTemplate),
+		MaxRetries:   webhook.MaxRetries,
+		CreatedAt:    webhook.CreatedAt,
+	}
+}
+
+// reloadProviders picks up a changed webhook, failing to do so only delays it until the next restart
+func (rg *RouteGroup) reloadProviders() {
+	if err := rg.notifications.Reload(); err != nil {
+		log.Error("error reloading notification providers", "error", err)
+	}
+}
@@ -0,0 +1,298 @@
+package scheduler
+
+import (
+	"errors"
+
+	"github.com/charmbracelet/log"
+	"github.com/mahcks/blockbusterr/internal/db"
+	"github.com/mahcks/blockbusterr/internal/helpers/trakt"
+	"github.com/mahcks/blockbusterr/pkg/structures"
+	"github.com/mahcks/blockbusterr/pkg/utils"
+)
+
+// cleanupJobType is the key the cleanup job is scheduled under
+const cleanupJobType = "cleanup"
+
+var ErrCleanupRunning = errors.New("the cleanup job is already running")
+
+// ReloadCleanupJob brings the cleanup job in line with the cleanup settings.
+// The job is rescheduled when its cron expression changed and removed when it has none.
+func (s *Scheduler) ReloadCleanupJob(settings db.CleanupSettings) {
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
+
+	s.reloadTaskJob(settings.Cron, s.CleanupJobFunc, cleanupJobType)
+}
+
+// CleanupJobFunc cleans up the titles blockbusterr added that were never downloaded or watched
+func (s *Scheduler) CleanupJobFunc() {
+	report, err := s.RunCleanup()
+	if err != nil {
+		log.Error("[cleanup-job] Cleanup failed.", "error", err)
+		return
+	}
+
+	log.Infof("[cleanup-job] Completed cleanup job, %d titles were cleaned up.", len(report.Items))
+}
+
+// RunCleanup unmonitors or deletes every title the scheduler added that is older than the minimum age
+// and was never downloaded or watched. In dry-run mode the titles are only reported.
+func (s *Scheduler) RunCleanup() (structures.CleanupReport, error) {
+	if !s.cleanupMu.TryLock() {
+		return structures.CleanupReport{}, ErrCleanupRunning
+	}
+	defer s.cleanupMu.Unlock()
+
+	return s.cleanup(isDryRun(s.gctx))
+}
+
+// PreviewCleanup reports the titles the cleanup job would clean up if it ran right now, without touching them
+func (s *Scheduler) PreviewCleanup() (structures.CleanupReport, error) {
+	return s.cleanup(true)
+}
+
+func (s *Scheduler) cleanup(dryRun bool) (structures.CleanupReport, error) {
+	queries := s.gctx.Crate().SQL.Queries()
+
+	settings, err := queries.GetCleanupSettings(s.gctx)
+	if err != nil {
+		return structures.CleanupReport{}, err
+	}
+
+	exclusions, err := queries.GetCleanupExclusions(s.gctx)
+	if err != nil {
+		return structures.CleanupReport{}, err
+	}
+
+	excluded := make(map[string]map[int]bool)
+	for _, exclusion := range exclusions {
+		if excluded[exclusion.MediaType] == nil {
+			excluded[exclusion.MediaType] = make(map[int]bool)
+		}
+		excluded[exclusion.MediaType][exclusion.MediaID] = true
+	}
+
+	report := structures.CleanupReport{
+		DryRun: dryRun,
+		Action: structures.CleanupAction(settings.Action),
+		Items:  []structures.CleanupItem{},
+	}
+
+	movies, err := s.cleanupMovies(settings, excluded["MOVIE"], dryRun)
+	if err != nil {
+		return report, err
+	}
+	report.Items = append(report.Items, movies...)
+
+	shows, err := s.cleanupShows(settings, excluded["SHOW"], dryRun)
+	if err != nil {
+		return report, err
+	}
+	report.Items = append(report.Items, shows...)
+
+	return report, nil
+}
+
+// cleanupMovies cleans up the movies the scheduler added in the Radarr instances they were added to
+func (s *Scheduler) cleanupMovies(settings db.CleanupSettings, excluded map[int]bool, dryRun bool) ([]structures.CleanupItem, error) {
+	titles, err := s.gctx.Crate().SQL.Queries().GetAddedTitles(s.gctx, "MOVIE", settings.MinAgeDays)
+	if err != nil || len(titles) == 0 {
+		return nil, err
+	}
+
+	instances, err := s.gctx.Crate().SQL.Queries().GetRadarrInstances(s.gctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without a Trakt account only movies that were never downloaded are cleaned up
+	watched, err := s.traktWatchedMovieIDs()
+	if err != nil {
+		watched = nil
+		if !errors.Is(err, trakt.ErrTraktNotAuthorized) && !errors.Is(err, trakt.ErrNoTraktSettings) {
+			log.Warnf("[cleanup-job] Could not fetch the Trakt watch history, only movies without a file are cleaned up: %v", err)
+		}
+	}
+
+	byInstance := groupAddedTitles(titles)
+
+	items := []structures.CleanupItem{}
+	for _, instance := range instances {
+		// Only titles added to this instance are cleaned up in it
+		titles := byInstance[instance.ID]
+		if len(titles) == 0 {
+			continue
+		}
+
+		service := s.helpers.Radarr.Instance(instance.ID)
+		library, err := service.GetMovies(nil, nil)
+		if err != nil {
+			log.Errorf("[cleanup-job] Failed to retrieve the library of Radarr instance '%s': %v", instance.Name, err)
+			continue
+		}
+
+		byTMDBID := make(map[int]int, len(library))
+		for i, movie := range library {
+			byTMDBID[movie.TmdbId] = i
+		}
+
+		for _, title := range titles {
+			index, ok := byTMDBID[title.MediaID]
+			if !ok || excluded[title.MediaID] {
+				continue
+			}
+			movie := library[index]
+
+			// Unmonitored movies were already cleaned up, or unmonitored by hand
+			if settings.Action == structures.CleanupActionUnmonitor.String() && !movie.Monitored {
+				continue
+			}
+
+			var reason structures.CleanupReason
+			switch {
+			case movie.HasFile == nil || !*movie.HasFile:
+				reason = structures.CleanupReasonNoFile
+			case watched != nil && !watched[title.MediaID]:
+				reason = structures.CleanupReasonUnwatched
+			default:
+				continue
+			}
+
+			item := cleanupItem("MOVIE", title, instance.Name, reason)
+			item.TMDBID = title.MediaID
+
+			if !dryRun {
+				if settings.Action == structures.CleanupActionDelete.String() {
+					err = service.DeleteMovie(nil, nil, movie.ID, settings.DeleteFiles)
+				} else {
+					err = service.UnmonitorMovie(nil, nil, movie.ID)
+				}
+
+				if err != nil {
+					log.Errorf("[cleanup-job] Failed to %s movie '%s' in Radarr instance '%s': %v", settings.Action, title.Title, instance.Name, err)
+					errText := err.Error()
+					item.Error = &errText
+				} else {
+					log.Infof("[cleanup-job] Cleaned up movie '%s' (%s) in Radarr instance '%s' with action %s.", title.Title, reason, instance.Name, settings.Action)
+				}
+			}
+
+			items = append(items, item)
+		}
+	}
+
+	return items, nil
+}
+
+// cleanupShows cleans up the shows the scheduler added in the Sonarr instances they were added to
+func (s *Scheduler) cleanupShows(settings db.CleanupSettings, excluded map[int]bool, dryRun bool) ([]structures.CleanupItem, error) {
+	titles, err := s.gctx.Crate().SQL.Queries().GetAddedTitles(s.gctx, "SHOW", settings.MinAgeDays)
+	if err != nil || len(titles) == 0 {
+		return nil, err
+	}
+
+	instances, err := s.gctx.Crate().SQL.Queries().GetSonarrInstances(s.gctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without a Trakt account only shows without a single downloaded episode are cleaned up
+	watched, err := s.traktWatchedShowIDs()
+	if err != nil {
+		watched = nil
+		if !errors.Is(err, trakt.ErrTraktNotAuthorized) && !errors.Is(err, trakt.ErrNoTraktSettings) {
+			log.Warnf("[cleanup-job] Could not fetch the Trakt watch history, only shows without files are cleaned up: %v", err)
+		}
+	}
+
+	byInstance := groupAddedTitles(titles)
+
+	items := []structures.CleanupItem{}
+	for _, instance := range instances {
+		// Only titles added to this instance are cleaned up in it
+		titles := byInstance[instance.ID]
+		if len(titles) == 0 {
+			continue
+		}
+
+		service := s.helpers.Sonarr.Instance(instance.ID)
+		library, err := service.GetSeries(nil, nil)
+		if err != nil {
+			log.Errorf("[cleanup-job] Failed to retrieve the library of Sonarr instance '%s': %v", instance.Name, err)
+			continue
+		}
+
+		byTVDBID := make(map[int]int, len(library))
+		for i, series := range library {
+			byTVDBID[series.TvdbId] = i
+		}
+
+		for _, title := range titles {
+			index, ok := byTVDBID[title.MediaID]
+			if !ok || excluded[title.MediaID] {
+				continue
+			}
+			series := library[index]
+
+			// Unmonitored shows were already cleaned up, or unmonitored by hand
+			if settings.Action == structures.CleanupActionUnmonitor.String() && !series.Monitored {
+				continue
+			}
+
+			var reason structures.CleanupReason
+			switch {
+			case series.Statistics.EpisodeFileCount == 0:
+				reason = structures.CleanupReasonNoFile
+			case watched != nil && !watched[title.MediaID]:
+				reason = structures.CleanupReasonUnwatched
+			default:
+				continue
+			}
+
+			item := cleanupItem("SHOW", title, instance.Name, reason)
+			item.TVDBID = title.MediaID
+
+			if !dryRun {
+				if settings.Action == structures.CleanupActionDelete.String() {
+					err = service.DeleteSeries(nil, nil, series.Id, settings.DeleteFiles)
+				} else {
+					err = service.UnmonitorSeries(nil, nil, series.Id)
+				}
+
+				if err != nil {
+					log.Errorf("[cleanup-job] Failed to %s show '%s' in Sonarr instance '%s': %v", settings.Action, title.Title, instance.Name, err)
+					errText := err.Error()
+					item.Error = &errText
+				} else {
+					log.Infof("[cleanup-job] Cleaned up show '%s' (%s) in Sonarr instance '%s' with action %s.", title.Title, reason, instance.Name, settings.Action)
+				}
+			}
+
+			items = append(items, item)
+		}
+	}
+
+	return items, nil
+}
+
+// groupAddedTitles groups the added titles by the instance they were added to
+func groupAddedTitles(titles []db.AddedTitle) map[int][]db.AddedTitle {
+	grouped := make(map[int][]db.AddedTitle)
+	for _, title := range titles {
+		grouped[title.InstanceID] = append(grouped[title.InstanceID], title)
+	}
+
+	return grouped
+}
+
+func cleanupItem(mediaType string, title db.AddedTitle, instance string, reason structures.CleanupReason) structures.CleanupItem {
+	return structures.CleanupItem{
+		MediaType: mediaType,
+		Title:     title.Title,
+		Year:      utils.NullIntToPointer(title.Year),
+		Source:    title.Source,
+		Instance:  instance,
+		AddedAt:   title.AddedAt,
+		Reason:    reason,
+	}
+}
@@ -0,0 +1,149 @@
+package scheduler
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/log"
+	"github.com/mahcks/blockbusterr/internal/db"
+	"github.com/mahcks/blockbusterr/internal/helpers/radarr"
+	"github.com/mahcks/blockbusterr/internal/helpers/sonarr"
+	"github.com/mahcks/blockbusterr/pkg/structures"
+)
+
+// exclusionSettings reports whether titles on the Radarr and Sonarr exclusion lists are skipped
+// and whether the blacklist is added to those lists
+func (s Scheduler) exclusionSettings() (respect bool, push bool) {
+	queries := s.gctx.Crate().SQL.Queries()
+
+	if setting, err := queries.GetSettingByKey(s.gctx, structures.SettingRespectExclusions.String()); err == nil {
+		respect = setting.Value.String == "true"
+	}
+
+	if setting, err := queries.GetSettingByKey(s.gctx, structures.SettingPushExclusions.String()); err == nil {
+		push = setting.Value.String == "true"
+	}
+
+	return respect, push
+}
+
+// syncMovieExclusions returns the TMDb IDs on the exclusion list of any of the given Radarr instances.
+// When push is set and pushing is enabled, blacklisted TMDb IDs missing from an exclusion list are added to it.
+// Ombi keeps track of its own requests, so nothing is fetched in Ombi mode.
+func (s Scheduler) syncMovieExclusions(instances []db.RadarrSettings, blacklist []db.BlacklistedTMDBIDs, push bool) (map[int]bool, error) {
+	excluded := make(map[int]bool)
+
+	respect, pushEnabled := s.exclusionSettings()
+	push = push && pushEnabled
+	if !respect && !push {
+		return excluded, nil
+	}
+
+	mode, err := s.gctx.Crate().SQL.Queries().GetSettingByKey(s.gctx, structures.SettingMode.String())
+	if err != nil {
+		return excluded, err
+	}
+
+	if mode.Value.String == "ombi" {
+		return excluded, nil
+	}
+
+	for _, instance := range instances {
+		service := s.helpers.Radarr.Instance(instance.ID)
+		exclusions, err := service.GetExclusions(nil, nil)
+		if err != nil {
+			return make(map[int]bool), err
+		}
+
+		listed := make(map[int]bool, len(exclusions))
+		for _, exclusion := range exclusions {
+			listed[exclusion.TmdbID] = true
+			if respect {
+				excluded[exclusion.TmdbID] = true
+			}
+		}
+
+		if !push {
+			continue
+		}
+
+		for _, blacklisted := range blacklist {
+			if listed[blacklisted.TMDBID] {
+				continue
+			}
+
+			_, err := service.AddExclusion(nil, nil, radarr.Exclusion{
+				TmdbID:     blacklisted.TMDBID,
+				MovieTitle: fmt.Sprintf("Blacklisted in blockbusterr (TMDb ID %d)", blacklisted.TMDBID),
+			})
+			if err != nil {
+				log.Warnf("[Scheduler] Failed to add TMDb ID %d to the exclusion list of Radarr instance '%s'. %v", blacklisted.TMDBID, instance.Name, err)
+				continue
+			}
+
+			log.Infof("[Scheduler] Added TMDb ID %d to the exclusion list of Radarr instance '%s'.", blacklisted.TMDBID, instance.Name)
+		}
+	}
+
+	return excluded, nil
+}
+
+// syncShowExclusions returns the TVDB IDs on the import list exclusions of any of the given Sonarr instances.
+// When push is set and pushing is enabled, blacklisted TVDB IDs missing from the exclusions are added to them.
+// Ombi keeps track of its own requests, so nothing is fetched in Ombi mode.
+func (s Scheduler) syncShowExclusions(instances []db.SonarrSettings, blacklist []db.ShowBlacklistedTVDBIDs, push bool) (map[int]bool, error) {
+	excluded := make(map[int]bool)
+
+	respect, pushEnabled := s.exclusionSettings()
+	push = push && pushEnabled
+	if !respect && !push {
+		return excluded, nil
+	}
+
+	mode, err := s.gctx.Crate().SQL.Queries().GetSettingByKey(s.gctx, structures.SettingMode.String())
+	if err != nil {
+		return excluded, err
+	}
+
+	if mode.Value.String == "ombi" {
+		return excluded, nil
+	}
+
+	for _, instance := range instances {
+		service := s.helpers.Sonarr.Instance(instance.ID)
+		exclusions, err := service.GetImportListExclusions(nil, nil)
+		if err != nil {
+			return make(map[int]bool), err
+		}
+
+		listed := make(map[int]bool, len(exclusions))
+		for _, exclusion := range exclusions {
+			listed[exclusion.TvdbID] = true
+			if respect {
+				excluded[exclusion.TvdbID] = true
+			}
+		}
+
+		if !push {
+			continue
+		}
+
+		for _, blacklisted := range blacklist {
+			if listed[blacklisted.TVDBID] {
+				continue
+			}
+
+			_, err := service.AddImportListExclusion(nil, nil, sonarr.ImportListExclusion{
+				TvdbID: blacklisted.TVDBID,
+				Title:  fmt.Sprintf("Blacklisted in blockbusterr (TVDB ID %d)", blacklisted.TVDBID),
+			})
+			if err != nil {
+				log.Warnf("[Scheduler] Failed to add TVDB ID %d to the import list exclusions of Sonarr instance '%s'. %v", blacklisted.TVDBID, instance.Name, err)
+				continue
+			}
+
+			log.Infof("[Scheduler] Added TVDB ID %d to the import list exclusions of Sonarr instance '%s'.", blacklisted.TVDBID, instance.Name)
+		}
+	}
+
+	return excluded, nil
+}
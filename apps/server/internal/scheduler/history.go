@@ -0,0 +1,221 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/mahcks/blockbusterr/internal/db"
+	"github.com/mahcks/blockbusterr/internal/global"
+	"github.com/mahcks/blockbusterr/internal/helpers/trakt"
+	"github.com/mahcks/blockbusterr/pkg/structures"
+	"github.com/mahcks/blockbusterr/pkg/utils"
+)
+
+// recordMovieHistory stores the outcome of requesting a movie in the request history
+func recordMovieHistory(gctx global.Context, run *jobRun, movie trakt.Movie, backend structures.RequestBackend, outcome structures.RequestOutcome, reqErr error) {
+	entry := newMovieHistory(run, movie, backend, outcome)
+
+	var reason string
+	if reqErr != nil {
+		entry.Error = utils.StringToNullString(reqErr.Error())
+		reason = reqErr.Error()
+	}
+
+	run.track(movieItem(movie, outcome, reason))
+	insertHistory(gctx, entry, movie.Title)
+}
+
+// recordShowHistory stores the outcome of requesting a show in the request history
+func recordShowHistory(gctx global.Context, run *jobRun, show trakt.Show, backend structures.RequestBackend, outcome structures.RequestOutcome, reqErr error) {
+	entry := newShowHistory(run, show, backend, outcome)
+
+	var reason string
+	if reqErr != nil {
+		entry.Error = utils.StringToNullString(reqErr.Error())
+		reason = reqErr.Error()
+	}
+
+	run.track(showItem(show, outcome, reason))
+	insertHistory(gctx, entry, show.Title)
+}
+
+// recordSkippedMovie stores a movie the filters dropped in the request history along with the reason.
+// Movies already in the media server library are recorded as owned.
+func recordSkippedMovie(gctx global.Context, run *jobRun, candidate movieCandidate, backend structures.RequestBackend) {
+	outcome := structures.RequestOutcomeSkipped
+	if candidate.Owned {
+		outcome = structures.RequestOutcomeOwned
+	}
+
+	entry := newMovieHistory(run, candidate.Movie, backend, outcome)
+	entry.Reason = utils.StringToNullString(candidate.Reason)
+
+	run.track(movieItem(candidate.Movie, outcome, candidate.Reason))
+	insertHistory(gctx, entry, candidate.Movie.Title)
+}
+
+// recordSkippedShow stores a show the filters dropped in the request history along with the reason.
+// Shows already in the media server library are recorded as owned.
+func recordSkippedShow(gctx global.Context, run *jobRun, candidate showCandidate, backend structures.RequestBackend) {
+	outcome := structures.RequestOutcomeSkipped
+	if candidate.Owned {
+		outcome = structures.RequestOutcomeOwned
+	}
+
+	entry := newShowHistory(run, candidate.Show, backend, outcome)
+	entry.Reason = utils.StringToNullString(candidate.Reason)
+
+	run.track(showItem(candidate.Show, outcome, candidate.Reason))
+	insertHistory(gctx, entry, candidate.Show.Title)
+}
+
+// recordMovieResults stores what happened to a movie on every Radarr instance as a single history entry
+func recordMovieResults(gctx global.Context, run *jobRun, movie trakt.Movie, results instanceResults) structures.RequestOutcome {
+	outcome, reason, reqErr := results.summary()
+
+	entry := newMovieHistory(run, movie, structures.RequestBackendRadarr, outcome)
+	entry.Reason = utils.StringToNullString(reason)
+	entry.InstanceIDs = results.addedTo()
+	if reqErr != nil {
+		entry.Error = utils.StringToNullString(reqErr.Error())
+		if reason == "" {
+			reason = reqErr.Error()
+		}
+	}
+
+	run.track(movieItem(movie, outcome, reason))
+	insertHistory(gctx, entry, movie.Title)
+
+	return outcome
+}
+
+// recordShowResults stores what happened to a show on every Sonarr instance as a single history entry
+func recordShowResults(gctx global.Context, run *jobRun, show trakt.Show, results instanceResults) structures.RequestOutcome {
+	outcome, reason, reqErr := results.summary()
+
+	entry := newShowHistory(run, show, structures.RequestBackendSonarr, outcome)
+	entry.Reason = utils.StringToNullString(reason)
+	entry.InstanceIDs = results.addedTo()
+	if reqErr != nil {
+		entry.Error = utils.StringToNullString(reqErr.Error())
+		if reason == "" {
+			reason = reqErr.Error()
+		}
+	}
+
+	run.track(showItem(show, outcome, reason))
+	insertHistory(gctx, entry, show.Title)
+
+	return outcome
+}
+
+func newMovieHistory(run *jobRun, movie trakt.Movie, backend structures.RequestBackend, outcome structures.RequestOutcome) db.RequestHistory {
+	return db.RequestHistory{
+		RunID:     run.ID,
+		MediaType: "MOVIE",
+		Title:     movie.Title,
+		Year:      utils.Int32ToNullInt32(int32(movie.Year)),
+		TMDBID:    utils.Int32ToNullInt32(int32(movie.IDs.TMDB)),
+		IMDBID:    utils.StringToNullString(movie.IDs.IMDB),
+		Source:    run.Source,
+		Backend:   backend.String(),
+		Outcome:   outcome.String(),
+	}
+}
+
+func newShowHistory(run *jobRun, show trakt.Show, backend structures.RequestBackend, outcome structures.RequestOutcome) db.RequestHistory {
+	return db.RequestHistory{
+		RunID:     run.ID,
+		MediaType: "SHOW",
+		Title:     show.Title,
+		Year:      utils.Int32ToNullInt32(int32(show.Year)),
+		TMDBID:    utils.Int32ToNullInt32(int32(show.IDs.TMDB)),
+		TVDBID:    utils.Int32ToNullInt32(int32(show.IDs.TVDB)),
+		IMDBID:    utils.StringToNullString(show.IDs.IMDB),
+		Source:    run.Source,
+		Backend:   backend.String(),
+		Outcome:   outcome.String(),
+	}
+}
+
+func insertHistory(gctx global.Context, entry db.RequestHistory, title string) {
+	if err := gctx.Crate().SQL.Queries().InsertRequestHistory(gctx, entry); err != nil {
+		log.Errorf("[Scheduler] Failed to record request history for %s '%s': %v", strings.ToLower(entry.MediaType), title, err)
+	}
+}
+
+// instanceResult is what happened to a title on one Radarr or Sonarr instance
+type instanceResult struct {
+	instanceID int
+	name       string
+	outcome    structures.RequestOutcome // Added, exists or failed
+	err        error
+}
+
+// instanceResults collects the results of a title on every instance a job sends to,
+// so the title is counted and recorded once no matter how many instances there are
+type instanceResults []instanceResult
+
+// summary picks the overall outcome of a title, added if any instance added it, then failed, then exists.
+// With more than one instance the reason lists the result on each of them.
+func (r instanceResults) summary() (structures.RequestOutcome, string, error) {
+	outcome := structures.RequestOutcomeExists
+	var errs []string
+	for _, result := range r {
+		switch result.outcome {
+		case structures.RequestOutcomeAdded:
+			outcome = structures.RequestOutcomeAdded
+		case structures.RequestOutcomeFailed:
+			if outcome != structures.RequestOutcomeAdded {
+				outcome = structures.RequestOutcomeFailed
+			}
+			errs = append(errs, fmt.Sprintf("%s: %v", result.name, result.err))
+		}
+	}
+
+	var reason string
+	if len(r) > 1 {
+		details := make([]string, 0, len(r))
+		for _, result := range r {
+			switch result.outcome {
+			case structures.RequestOutcomeAdded:
+				details = append(details, fmt.Sprintf("added to %s", result.name))
+			case structures.RequestOutcomeExists:
+				details = append(details, fmt.Sprintf("already in %s", result.name))
+			case structures.RequestOutcomeFailed:
+				details = append(details, fmt.Sprintf("failed on %s", result.name))
+			}
+		}
+		reason = strings.Join(details, ", ")
+	}
+
+	if len(errs) > 0 {
+		return outcome, reason, errors.New(strings.Join(errs, "; "))
+	}
+
+	return outcome, reason, nil
+}
+
+// addedTo returns the IDs of the instances the title was added to
+func (r instanceResults) addedTo() []int {
+	ids := []int{}
+	for _, result := range r {
+		if result.outcome == structures.RequestOutcomeAdded {
+			ids = append(ids, result.instanceID)
+		}
+	}
+
+	return ids
+}
+
+// requestBackend returns where candidates are requested in the current mode, Ombi or the given *arr backend
+func requestBackend(gctx global.Context, arr structures.RequestBackend) structures.RequestBackend {
+	mode, err := gctx.Crate().SQL.Queries().GetSettingByKey(gctx, structures.SettingMode.String())
+	if err == nil && mode.Value.String == "ombi" {
+		return structures.RequestBackendOmbi
+	}
+
+	return arr
+}
@@ -0,0 +1,61 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mahcks/blockbusterr/pkg/structures"
+)
+
+var ErrUnknownInstance = errors.New("unknown instance")
+
+// GetJobInstances returns the IDs of the Radarr or Sonarr instances a list job is assigned to.
+// An empty result means the job sends to every instance.
+func (s *Scheduler) GetJobInstances(listType string) ([]int, error) {
+	if _, err := s.jobMediaType(listType); err != nil {
+		return nil, err
+	}
+
+	return s.gctx.Crate().SQL.Queries().GetJobInstanceIDs(s.gctx, listType)
+}
+
+// SetJobInstances assigns a list job to the given Radarr instances for movie jobs or Sonarr instances for show jobs.
+// Passing no IDs makes the job send to every instance.
+func (s *Scheduler) SetJobInstances(listType string, ids []int) error {
+	queries := s.gctx.Crate().SQL.Queries()
+
+	mediaType, err := s.jobMediaType(listType)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[int]bool)
+	switch mediaType {
+	case structures.ListSourceMediaTypeMovie:
+		instances, err := queries.GetRadarrInstances(s.gctx)
+		if err != nil {
+			return fmt.Errorf("error fetching Radarr instances: %w", err)
+		}
+		for _, instance := range instances {
+			existing[instance.ID] = true
+		}
+	case structures.ListSourceMediaTypeShow:
+		instances, err := queries.GetSonarrInstances(s.gctx)
+		if err != nil {
+			return fmt.Errorf("error fetching Sonarr instances: %w", err)
+		}
+		for _, instance := range instances {
+			existing[instance.ID] = true
+		}
+	default:
+		return ErrUnknownJobType
+	}
+
+	for _, id := range ids {
+		if !existing[id] {
+			return fmt.Errorf("%w: %d", ErrUnknownInstance, id)
+		}
+	}
+
+	return queries.SetJobInstanceIDs(s.gctx, listType, ids)
+}
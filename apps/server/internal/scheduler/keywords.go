@@ -0,0 +1,80 @@
+package scheduler
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/mahcks/blockbusterr/pkg/structures"
+)
+
+// keywordRule is a blacklisted title keyword compiled for its match mode
+type keywordRule struct {
+	keyword string
+	mode    structures.KeywordMatchMode
+	pattern *regexp.Regexp // Set for the word and regex modes
+}
+
+// compileKeywordRule prepares a blacklisted title keyword for matching. An empty mode means substring.
+func compileKeywordRule(keyword string, mode structures.KeywordMatchMode) (keywordRule, error) {
+	if strings.TrimSpace(keyword) == "" {
+		return keywordRule{}, fmt.Errorf("keyword can't be empty")
+	}
+
+	rule := keywordRule{keyword: keyword, mode: mode}
+	switch mode {
+	case structures.KeywordMatchSubstring, "":
+		rule.mode = structures.KeywordMatchSubstring
+		rule.keyword = strings.ToLower(keyword)
+	case structures.KeywordMatchWord:
+		// \b only knows ASCII word characters, so the boundaries are spelled out to handle accented titles
+		rule.pattern = regexp.MustCompile(`(?i)(?:^|[^\p{L}\p{N}_])` + regexp.QuoteMeta(keyword) + `(?:$|[^\p{L}\p{N}_])`)
+	case structures.KeywordMatchRegex:
+		pattern, err := regexp.Compile("(?i)" + keyword)
+		if err != nil {
+			return keywordRule{}, fmt.Errorf("invalid regular expression: %w", err)
+		}
+		rule.pattern = pattern
+	default:
+		return keywordRule{}, fmt.Errorf("unknown match mode %q, expected substring, word or regex", mode)
+	}
+
+	return rule, nil
+}
+
+// ValidateKeywordRule returns an error if the keyword can't be matched with the mode
+func ValidateKeywordRule(keyword string, mode structures.KeywordMatchMode) error {
+	_, err := compileKeywordRule(keyword, mode)
+	return err
+}
+
+// compileStoredKeywordRule compiles a blacklisted title keyword from the settings when a job run starts.
+// Keywords are validated when they're saved, so one that doesn't compile is logged and ignored.
+func compileStoredKeywordRule(keyword, mode string) (keywordRule, bool) {
+	rule, err := compileKeywordRule(keyword, structures.KeywordMatchMode(mode))
+	if err != nil {
+		log.Warn("[Scheduler] Ignoring blacklisted title keyword.", "keyword", keyword, "error", err)
+		return keywordRule{}, false
+	}
+
+	return rule, true
+}
+
+// matches reports whether the title matches the rule
+func (r keywordRule) matches(title string) bool {
+	if r.pattern != nil {
+		return r.pattern.MatchString(title)
+	}
+
+	return strings.Contains(strings.ToLower(title), r.keyword)
+}
+
+// skipReason describes the rule for the skip reason of a title it matched
+func (r keywordRule) skipReason() string {
+	if r.mode == structures.KeywordMatchRegex {
+		return fmt.Sprintf("title matches blacklisted pattern '%s'", r.keyword)
+	}
+
+	return fmt.Sprintf("blacklisted title keyword '%s'", r.keyword)
+}
@@ -0,0 +1,78 @@
+package scheduler
+
+import (
+	"github.com/mahcks/blockbusterr/internal/db"
+	"github.com/mahcks/blockbusterr/pkg/structures"
+)
+
+// fetchOwnedMovieIDs returns the TMDb IDs of every movie already in the library of every given Radarr instance.
+// A movie missing from any of them still has to be requested there, so it isn't counted as owned.
+// Ombi keeps track of its own requests, so nothing is fetched in Ombi mode.
+func (s Scheduler) fetchOwnedMovieIDs(instances []db.RadarrSettings) (map[int]bool, error) {
+	owned := make(map[int]bool)
+
+	mode, err := s.gctx.Crate().SQL.Queries().GetSettingByKey(s.gctx, structures.SettingMode.String())
+	if err != nil {
+		return owned, err
+	}
+
+	if mode.Value.String == "ombi" || len(instances) == 0 {
+		return owned, nil
+	}
+
+	counts := make(map[int]int)
+	for _, instance := range instances {
+		movies, err := s.helpers.Radarr.Instance(instance.ID).GetMovies(nil, nil)
+		if err != nil {
+			return make(map[int]bool), err
+		}
+
+		for _, movie := range movies {
+			counts[movie.TmdbId]++
+		}
+	}
+
+	for tmdbID, count := range counts {
+		if count == len(instances) {
+			owned[tmdbID] = true
+		}
+	}
+
+	return owned, nil
+}
+
+// fetchOwnedShowIDs returns the TVDB IDs of every series already in the library of every given Sonarr instance.
+// A series missing from any of them still has to be requested there, so it isn't counted as owned.
+// Ombi keeps track of its own requests, so nothing is fetched in Ombi mode.
+func (s Scheduler) fetchOwnedShowIDs(instances []db.SonarrSettings) (map[int]bool, error) {
+	owned := make(map[int]bool)
+
+	mode, err := s.gctx.Crate().SQL.Queries().GetSettingByKey(s.gctx, structures.SettingMode.String())
+	if err != nil {
+		return owned, err
+	}
+
+	if mode.Value.String == "ombi" || len(instances) == 0 {
+		return owned, nil
+	}
+
+	counts := make(map[int]int)
+	for _, instance := range instances {
+		series, err := s.helpers.Sonarr.Instance(instance.ID).GetSeries(nil, nil)
+		if err != nil {
+			return make(map[int]bool), err
+		}
+
+		for _, show := range series {
+			counts[show.TvdbId]++
+		}
+	}
+
+	for tvdbID, count := range counts {
+		if count == len(instances) {
+			owned[tvdbID] = true
+		}
+	}
+
+	return owned, nil
+}
@@ -0,0 +1,246 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/mahcks/blockbusterr/internal/db"
+	"github.com/mahcks/blockbusterr/internal/helpers/trakt"
+	"github.com/mahcks/blockbusterr/pkg/structures"
+)
+
+// ListSourceJobType returns the job type list source runs, history and instance assignments are recorded under
+func ListSourceJobType(id int) string {
+	return fmt.Sprintf("%s%d", db.ListSourceJobPrefix, id)
+}
+
+// parseListSourceJobType returns the ID of the list source a job type belongs to
+func parseListSourceJobType(listType string) (int, bool) {
+	if !strings.HasPrefix(listType, db.ListSourceJobPrefix) {
+		return 0, false
+	}
+
+	id, err := strconv.Atoi(strings.TrimPrefix(listType, db.ListSourceJobPrefix))
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+
+	return id, true
+}
+
+// getListSource returns the list source a job type belongs to
+func (s Scheduler) getListSource(listType string) (db.ListSource, error) {
+	id, ok := parseListSourceJobType(listType)
+	if !ok {
+		return db.ListSource{}, ErrUnknownJobType
+	}
+
+	source, err := s.gctx.Crate().SQL.Queries().GetListSource(s.gctx, id)
+	if err != nil {
+		if err == db.ErrNoListSource {
+			return source, ErrUnknownJobType
+		}
+		return source, err
+	}
+
+	return source, nil
+}
+
+// jobMediaType returns whether a job requests movies or shows, failing for unknown jobs
+func (s Scheduler) jobMediaType(listType string) (structures.ListSourceMediaType, error) {
+	switch {
+	case isMovieJob(listType):
+		return structures.ListSourceMediaTypeMovie, nil
+	case isShowJob(listType):
+		return structures.ListSourceMediaTypeShow, nil
+	}
+
+	source, err := s.getListSource(listType)
+	if err != nil {
+		return "", err
+	}
+
+	return structures.ListSourceMediaType(source.MediaType), nil
+}
+
+// ReloadListSourceJobs brings the list source jobs in line with the list sources.
+// Enabled sources with a cron expression are scheduled, every other list source job is removed.
+func (s *Scheduler) ReloadListSourceJobs() {
+	sources, err := s.gctx.Crate().SQL.Queries().GetListSources(s.gctx)
+	if err != nil {
+		log.Error("[Scheduler] Failed to retrieve list sources from the database.", "error", err)
+		return
+	}
+
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
+
+	current := make(map[string]bool, len(sources))
+	for _, source := range sources {
+		listType := ListSourceJobType(source.ID)
+		isMovie := source.MediaType == structures.ListSourceMediaTypeMovie.String()
+		current[listType] = true
+
+		// A source whose media type changed has to move to the other job map
+		s.stopJob(listType, !isMovie)
+
+		cronExpr := source.Cron
+		if !source.Enabled {
+			cronExpr.Valid = false
+		}
+
+		s.reloadJob(cronExpr, func() { s.runScheduledJob(listType) }, listType, isMovie)
+	}
+
+	// Remove the jobs of deleted sources
+	for listType := range s.jobSpecs {
+		if _, ok := parseListSourceJobType(listType); ok && !current[listType] {
+			s.stopJob(listType, true)
+			s.stopJob(listType, false)
+		}
+	}
+}
+
+// fetchListSourceMovies fetches the movies of a list source from Trakt along with the number of movies to request
+func (s Scheduler) fetchListSourceMovies(listType string) ([]trakt.Movie, int, error) {
+	source, err := s.getListSource(listType)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if source.MediaType != structures.ListSourceMediaTypeMovie.String() {
+		return nil, 0, ErrUnknownJobType
+	}
+
+	movies := []trakt.Movie{}
+	switch structures.ListSourceType(source.SourceType) {
+	case structures.ListSourceTypeRecommendations:
+		movies, err = s.helpers.Trakt.GetMovieRecommendations(s.gctx, &trakt.GetRecommendationsParams{
+			MediaType: "movies",
+			Extended:  "full",
+			Limit:     100,
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+	default:
+		items, err := s.fetchListSourceItems(source, "movies")
+		if err != nil {
+			return nil, 0, err
+		}
+
+		for _, item := range items {
+			if item.Movie != nil {
+				movies = append(movies, *item.Movie)
+			}
+		}
+	}
+
+	return movies, source.Limit, nil
+}
+
+// fetchListSourceShows fetches the shows of a list source from Trakt along with the number of shows to request
+func (s Scheduler) fetchListSourceShows(listType string) ([]trakt.Show, int, error) {
+	source, err := s.getListSource(listType)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if source.MediaType != structures.ListSourceMediaTypeShow.String() {
+		return nil, 0, ErrUnknownJobType
+	}
+
+	shows := []trakt.Show{}
+	switch structures.ListSourceType(source.SourceType) {
+	case structures.ListSourceTypeRecommendations:
+		shows, err = s.helpers.Trakt.GetShowRecommendations(s.gctx, &trakt.GetRecommendationsParams{
+			MediaType: "shows",
+			Extended:  "full",
+			Limit:     100,
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+	default:
+		items, err := s.fetchListSourceItems(source, "shows")
+		if err != nil {
+			return nil, 0, err
+		}
+
+		for _, item := range items {
+			if item.Show != nil {
+				shows = append(shows, *item.Show)
+			}
+		}
+	}
+
+	return shows, source.Limit, nil
+}
+
+// fetchListSourceItems fetches the items on the list or watchlist of a list source
+func (s Scheduler) fetchListSourceItems(source db.ListSource, mediaType string) (trakt.GetListItemsResponse, error) {
+	params := &trakt.GetListItemsParams{
+		User:      source.Username.String,
+		List:      source.ListSlug.String,
+		MediaType: mediaType,
+		Extended:  "full",
+	}
+
+	if structures.ListSourceType(source.SourceType) == structures.ListSourceTypeWatchlist {
+		return s.helpers.Trakt.GetWatchlist(s.gctx, params)
+	}
+
+	return s.helpers.Trakt.GetListItems(s.gctx, params)
+}
+
+// listBounds holds the year and runtime limits of a list source. Trakt can't filter list items,
+// so they are checked for every candidate instead. A zero value means there is no limit.
+type listBounds struct {
+	minYear    int
+	maxYear    int
+	minRuntime int
+	maxRuntime int
+}
+
+// listSourceBounds returns the year and runtime limits of a list source job, or no limits for the built-in jobs
+func (s Scheduler) listSourceBounds(listType string) listBounds {
+	if _, ok := parseListSourceJobType(listType); !ok {
+		return listBounds{}
+	}
+
+	source, err := s.getListSource(listType)
+	if err != nil {
+		log.Warnf("[Scheduler] Could not fetch the limits of %s, the year and runtime will not be checked. %v", listType, err)
+		return listBounds{}
+	}
+
+	return listBounds{
+		minYear:    int(source.MinYear.Int32),
+		maxYear:    int(source.MaxYear.Int32),
+		minRuntime: int(source.MinRuntime.Int32),
+		maxRuntime: int(source.MaxRuntime.Int32),
+	}
+}
+
+// skipReason returns why a title with the given year and runtime is out of bounds, or an empty string if it isn't
+func (b listBounds) skipReason(year, runtime int) string {
+	if b.minYear > 0 && year < b.minYear {
+		return fmt.Sprintf("released before %d", b.minYear)
+	}
+
+	if b.maxYear > 0 && year > b.maxYear {
+		return fmt.Sprintf("released after %d", b.maxYear)
+	}
+
+	if b.minRuntime > 0 && runtime < b.minRuntime {
+		return fmt.Sprintf("runtime shorter than %d minutes", b.minRuntime)
+	}
+
+	if b.maxRuntime > 0 && runtime > b.maxRuntime {
+		return fmt.Sprintf("runtime longer than %d minutes", b.maxRuntime)
+	}
+
+	return ""
+}
@@ -0,0 +1,129 @@
+package scheduler
+
+import (
+	"errors"
+
+	"github.com/charmbracelet/log"
+	"github.com/mahcks/blockbusterr/internal/db"
+	"github.com/mahcks/blockbusterr/internal/helpers/trakt"
+	"github.com/mahcks/blockbusterr/pkg/utils"
+)
+
+// mediaServerJobType is the key the media server library refresh is scheduled under
+const mediaServerJobType = "media-server"
+
+// mediaServerOwnedReason is the skip reason of titles already in the media server library, they are recorded as owned
+const mediaServerOwnedReason = "already in the media server library"
+
+var ErrMediaServerSyncRunning = errors.New("the media server library is already being refreshed")
+
+// ReloadMediaServerJob brings the media server library refresh in line with the media server settings.
+// The refresh is only scheduled while the media server is enabled.
+func (s *Scheduler) ReloadMediaServerJob(settings db.MediaServerSettings) {
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
+
+	cronExpr := settings.Cron
+	if !settings.Enabled {
+		cronExpr.Valid = false
+	}
+
+	s.reloadTaskJob(cronExpr, s.MediaServerJobFunc, mediaServerJobType)
+}
+
+// MediaServerJobFunc refreshes the cached media server library
+func (s *Scheduler) MediaServerJobFunc() {
+	count, err := s.SyncMediaServerLibrary()
+	if err != nil {
+		log.Error("[media-server] Failed to refresh the media server library.", "error", err)
+		return
+	}
+
+	log.Infof("[media-server] Refreshed the media server library, %d items are cached.", count)
+}
+
+// SyncMediaServerLibrary replaces the cached media server library with the current one and returns the number of items in it
+func (s *Scheduler) SyncMediaServerLibrary() (int, error) {
+	if !s.mediaServerMu.TryLock() {
+		return 0, ErrMediaServerSyncRunning
+	}
+	defer s.mediaServerMu.Unlock()
+
+	library, err := s.helpers.MediaServer.GetLibrary(s.gctx)
+	if err != nil {
+		return 0, err
+	}
+
+	items := make([]db.MediaServerItem, 0, len(library))
+	for _, item := range library {
+		items = append(items, db.MediaServerItem{
+			MediaType: item.MediaType,
+			Title:     item.Title,
+			Year:      utils.Int32ToNullInt32(int32(item.Year)),
+			IMDBID:    utils.StringToNullString(item.IMDBID),
+			TMDBID:    utils.Int32ToNullInt32(int32(item.TMDBID)),
+			TVDBID:    utils.Int32ToNullInt32(int32(item.TVDBID)),
+		})
+	}
+
+	if err := s.gctx.Crate().SQL.Queries().ReplaceMediaServerLibrary(s.gctx, items); err != nil {
+		return 0, err
+	}
+
+	return len(items), nil
+}
+
+// mediaServerLibrary holds the external IDs of the cached media server library items of one media type
+type mediaServerLibrary struct {
+	imdbIDs map[string]bool
+	tmdbIDs map[int]bool
+	tvdbIDs map[int]bool
+}
+
+// loadMediaServerLibrary returns the cached media server library items of a media type.
+// The library is empty while the media server is disabled.
+func (s Scheduler) loadMediaServerLibrary(mediaType string) mediaServerLibrary {
+	library := mediaServerLibrary{
+		imdbIDs: make(map[string]bool),
+		tmdbIDs: make(map[int]bool),
+		tvdbIDs: make(map[int]bool),
+	}
+
+	settings, err := s.gctx.Crate().SQL.Queries().GetMediaServerSettings(s.gctx)
+	if err != nil {
+		log.Warn("[Scheduler] Error fetching media server settings, owned titles will not be skipped.", "error", err)
+		return library
+	}
+
+	if !settings.Enabled {
+		return library
+	}
+
+	items, err := s.gctx.Crate().SQL.Queries().GetMediaServerLibrary(s.gctx, mediaType)
+	if err != nil {
+		log.Warn("[Scheduler] Error fetching the media server library, owned titles will not be skipped.", "error", err)
+		return library
+	}
+
+	for _, item := range items {
+		if item.IMDBID.Valid {
+			library.imdbIDs[item.IMDBID.String] = true
+		}
+		if item.TMDBID.Valid {
+			library.tmdbIDs[int(item.TMDBID.Int32)] = true
+		}
+		if item.TVDBID.Valid {
+			library.tvdbIDs[int(item.TVDBID.Int32)] = true
+		}
+	}
+
+	return library
+}
+
+func (l mediaServerLibrary) ownsMovie(ids trakt.MovieIDs) bool {
+	return (ids.TMDB != 0 && l.tmdbIDs[ids.TMDB]) || (ids.IMDB != "" && l.imdbIDs[ids.IMDB])
+}
+
+func (l mediaServerLibrary) ownsShow(ids trakt.ShowIDs) bool {
+	return (ids.TVDB != 0 && l.tvdbIDs[ids.TVDB]) || (ids.TMDB != 0 && l.tmdbIDs[ids.TMDB]) || (ids.IMDB != "" && l.imdbIDs[ids.IMDB])
+}
@@ -0,0 +1,60 @@
+package scheduler
+
+import (
+	"errors"
+
+	"github.com/mahcks/blockbusterr/internal/helpers/ombi"
+	"github.com/mahcks/blockbusterr/pkg/structures"
+)
+
+var ErrNotShowJob = errors.New("only show jobs have a monitor strategy")
+
+// GetJobMonitor returns the Sonarr monitor strategy of a show list job.
+// An empty result means the job uses the monitor option of the show settings.
+func (s *Scheduler) GetJobMonitor(listType string) (structures.SonarrMonitor, error) {
+	if err := s.requireShowJob(listType); err != nil {
+		return "", err
+	}
+
+	monitor, err := s.gctx.Crate().SQL.Queries().GetJobMonitor(s.gctx, listType)
+	return structures.SonarrMonitor(monitor), err
+}
+
+// SetJobMonitor sets the Sonarr monitor strategy of a show list job. An empty strategy makes the job use the show settings again.
+func (s *Scheduler) SetJobMonitor(listType string, monitor structures.SonarrMonitor) error {
+	if err := s.requireShowJob(listType); err != nil {
+		return err
+	}
+
+	return s.gctx.Crate().SQL.Queries().SetJobMonitor(s.gctx, listType, monitor.String())
+}
+
+func (s *Scheduler) requireShowJob(listType string) error {
+	mediaType, err := s.jobMediaType(listType)
+	if err != nil {
+		return err
+	}
+
+	if mediaType != structures.ListSourceMediaTypeShow {
+		return ErrNotShowJob
+	}
+
+	return nil
+}
+
+// ombiSeasons maps a monitor strategy onto the seasons of an Ombi request, which can only ask for
+// every season, the first or the latest. It reports false for "none", which Ombi has no request for.
+func ombiSeasons(monitor structures.SonarrMonitor, body *ombi.RequestShowBody) bool {
+	switch monitor {
+	case structures.SonarrMonitorFirstSeason, structures.SonarrMonitorPilot:
+		body.FirstSeason = true
+	case structures.SonarrMonitorLatestSeason, structures.SonarrMonitorFuture:
+		body.LatestSeason = true
+	case structures.SonarrMonitorNone:
+		return false
+	default:
+		body.RequestAll = true
+	}
+
+	return true
+}
@@ -2,6 +2,7 @@ package scheduler
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -20,216 +21,214 @@ import (
 )
 
 type radarrJob struct {
-	gctx              global.Context
-	helpers           helpers.Helpers
-	ombiSettings      db.OmbiSettings
-	radarrSettings    db.RadarrSettings
-	movieSettings     db.MovieSettings
-	anticipatedMovies []trakt.Movie
-	boxOfficeMovies   []trakt.Movie
-	popularMovies     []trakt.Movie
-	trendingMovies    []trakt.Movie
+	gctx            global.Context
+	helpers         helpers.Helpers
+	ombiSettings    db.OmbiSettings
+	radarrInstances []db.RadarrSettings // Radarr instances the job sends to
+	movieSettings   db.MovieSettings
+	ownedTMDBIDs    map[int]bool // TMDb IDs already in every targeted Radarr library, fetched once per run
+	tags            []string     // Labels of the tags movies are added to Radarr with
+	run             *jobRun
+}
+
+// movieJobNames maps each movie list type to the name used in logs
+var movieJobNames = map[string]string{
+	"movie-anticipated": "Anticipated Movies",
+	"movie-box_office":  "Box Office Movies",
+	"movie-popular":     "Popular Movies",
+	"movie-trending":    "Trending Movies",
+	"movie-watched":     "Most Watched Movies",
+	"movie-played":      "Most Played Movies",
+	"movie-collected":   "Most Collected Movies",
 }
 
 // AnticipatedJobFunc fetches and processes anticipated movies
 func (s Scheduler) AnticipatedJobFunc() {
-	err := s.helpers.Trakt.Ping(context.Background())
-	if err != nil {
-		log.Warn("[Scheduler] Skipping 'Anticipated Movies' job. Trakt credentials are missing.")
-		s.gctx.Crate().SQL.Queries().InsertLog(s.gctx, structures.LogLevelWarn, "Scheduler", "Skipping 'Anticipated Movies' job. Trakt credentials are missing.")
-		return
-	}
-
-	log.Info("[Scheduler] Starting 'Anticipated Movies' job...")
-	startTime := time.Now()
+	s.runScheduledJob("movie-anticipated")
+}
 
-	mj := radarrJob{
-		gctx:    s.gctx,
-		helpers: s.helpers,
-	}
+// BoxOfficeJobFunc fetches and processes box office movies
+func (s Scheduler) BoxOfficeJobFunc() {
+	s.runScheduledJob("movie-box_office")
+}
 
-	largeMovieQueryLimit := 1000
+// PopularJobFunc fetches and processes popular movies
+func (s Scheduler) PopularJobFunc() {
+	s.runScheduledJob("movie-popular")
+}
 
-	// Initialize movie settings
-	if err := s.initializeMovieJob(&mj); err != nil {
-		log.Error("[Scheduler] Failed to initialize 'Anticipated Movies' job. Check your settings and try again.", "error", err)
-		return
-	}
+// TrendingJobFunc fetches and processes trending movies
+func (s Scheduler) TrendingJobFunc() {
+	s.runScheduledJob("movie-trending")
+}
 
-	// Fetch anticipated movies from Trakt
-	if mj.movieSettings.Anticipated.Valid && mj.movieSettings.Anticipated.Int32 > 0 {
-		params := buildTraktParamsFromSettings(mj.movieSettings, largeMovieQueryLimit, true)
-		anticipatedMovies, err := s.helpers.Trakt.GetAnticipatedMovies(s.gctx, params)
-		if err != nil {
-			if errors.Is(err, trakt.ErrNoTraktSettings) {
-				log.Warn("[Scheduler] 'Anticipated Movies' job could not be completed. Trakt Client ID is not set.")
-				s.gctx.Crate().SQL.Queries().InsertLog(s.gctx, structures.LogLevelWarn, "Scheduler", "'Anticipated Movies' job could not be completed. Trakt Client ID is not set.")
-				return
-			} else {
-				log.Error("[Scheduler] Error fetching 'Anticipated Movies' from Trakt.", "error", err)
-				return
-			}
-		}
+// WatchedJobFunc fetches and processes the most watched movies
+func (s Scheduler) WatchedJobFunc() {
+	s.runScheduledJob("movie-watched")
+}
 
-		// Process the fetched movies
-		mj.anticipatedMovies = filterAndLimitMovies(extractMoviesFromAnticipated(anticipatedMovies), mj.movieSettings, int(mj.movieSettings.Anticipated.Int32))
-		s.processMovies(mj.anticipatedMovies, mj)
-	}
+// PlayedJobFunc fetches and processes the most played movies
+func (s Scheduler) PlayedJobFunc() {
+	s.runScheduledJob("movie-played")
+}
 
-	log.Infof("[Scheduler] Completed 'Anticipated Movies' job in %.2f seconds.", time.Since(startTime).Seconds())
-	s.gctx.Crate().SQL.Queries().InsertLog(s.gctx, structures.LogLevelInfo, "Scheduler", fmt.Sprintf("Completed 'Anticipated Movies' job in %.2f seconds.", time.Since(startTime).Seconds()))
+// CollectedJobFunc fetches and processes the most collected movies
+func (s Scheduler) CollectedJobFunc() {
+	s.runScheduledJob("movie-collected")
 }
 
-// BoxOfficeJobFunc fetches and processes box office movies
-func (s Scheduler) BoxOfficeJobFunc() {
+// runMovieJob fetches the movies for a list from Trakt, filters them and requests them
+func (s Scheduler) runMovieJob(run *jobRun) {
+	listType := run.Source
+	jobName, exists := movieJobNames[listType]
+	if !exists {
+		jobName = listType
+	}
+
 	err := s.helpers.Trakt.Ping(context.Background())
 	if err != nil {
-		log.Warn("[Scheduler] Skipping 'Box Office Movies' job. Trakt credentials are missing.")
-		s.gctx.Crate().SQL.Queries().InsertLog(s.gctx, structures.LogLevelWarn, "Scheduler", "Skipping 'Box Office Movies' job. Trakt credentials are missing.")
+		run.fail(fmt.Errorf("trakt credentials are missing: %w", err))
+		log.Warnf("[Scheduler] Skipping '%s' job. Trakt credentials are missing.", jobName)
+		s.gctx.Crate().SQL.Queries().InsertLog(s.gctx, structures.LogLevelWarn, "Scheduler", fmt.Sprintf("Skipping '%s' job. Trakt credentials are missing.", jobName))
 		return
 	}
 
-	log.Info("[Scheduler] Starting 'Box Office Movies' job...")
+	log.Infof("[Scheduler] Starting '%s' job...", jobName)
 	startTime := time.Now()
 
-	mj := radarrJob{}
-	largeMovieQueryLimit := 1000
+	mj := radarrJob{
+		gctx:    s.gctx,
+		helpers: s.helpers,
+		run:     run,
+	}
 
 	// Initialize movie settings
 	if err := s.initializeMovieJob(&mj); err != nil {
-		log.Error("[Scheduler] Failed to initialize 'Box Office Movies' job. Check your settings and try again.", "error", err)
-		s.gctx.Crate().SQL.Queries().InsertLog(s.gctx, structures.LogLevelError, "Scheduler", "Failed to initialize 'Box Office Movies' job. Check your settings and try again.")
+		run.fail(err)
+		log.Errorf("[Scheduler] Failed to initialize '%s' job. Check your settings and try again. %v", jobName, err)
+		s.gctx.Crate().SQL.Queries().InsertLog(s.gctx, structures.LogLevelError, "Scheduler", fmt.Sprintf("Failed to initialize '%s' job. Check your settings and try again.", jobName))
 		return
 	}
 
-	// Fetch box office movies from Trakt
-	if mj.movieSettings.BoxOffice.Valid && mj.movieSettings.BoxOffice.Int32 > 0 {
-		params := buildTraktParamsFromSettings(mj.movieSettings, largeMovieQueryLimit, false)
-		boxOfficeMovies, err := s.helpers.Trakt.GetBoxOfficeMovies(s.gctx, params)
-		if err != nil {
-			if errors.Is(err, trakt.ErrNoTraktSettings) {
-				log.Warn("[Scheduler] 'Box Office Movies' job could not be completed. Trakt Client ID is not set.")
-				s.gctx.Crate().SQL.Queries().InsertLog(s.gctx, structures.LogLevelWarn, "Scheduler", "'Box Office Movies' job could not be completed. Trakt Client ID is not set.")
-				return
-			} else {
-				log.Error("[Scheduler] Error fetching 'Box Office Movies' from Trakt.", "error", err)
-				s.gctx.Crate().SQL.Queries().InsertLog(s.gctx, structures.LogLevelError, "Scheduler", "Error fetching 'Box Office Movies' from Trakt.")
-				return
-			}
-		}
-
-		// Process the fetched movies
-		mj.boxOfficeMovies = filterAndLimitMovies(extractMoviesFromBoxOffice(boxOfficeMovies), mj.movieSettings, int(mj.movieSettings.BoxOffice.Int32))
-		s.processMovies(mj.boxOfficeMovies, mj)
-	}
-
-	log.Infof("[Scheduler] Completed 'Box Office Movies' job in %.2f seconds.", time.Since(startTime).Seconds())
-	s.gctx.Crate().SQL.Queries().InsertLog(s.gctx, structures.LogLevelInfo, "Scheduler", fmt.Sprintf("Completed 'Box Office Movies' job in %.2f seconds.", time.Since(startTime).Seconds()))
-}
-
-// PopularJobFunc fetches and processes popular movies
-func (s Scheduler) PopularJobFunc() {
-	err := s.helpers.Trakt.Ping(context.Background())
+	// Fetch the movies from Trakt
+	movies, limit, err := s.fetchMovieCandidates(listType, mj.movieSettings)
 	if err != nil {
-		log.Warn("[Scheduler] Skipping 'Popular Movies' job. Trakt credentials are missing.")
-		s.gctx.Crate().SQL.Queries().InsertLog(s.gctx, structures.LogLevelWarn, "Scheduler", "Skipping 'Popular Movies' job. Trakt credentials are missing.")
-		return
-	}
-
-	log.Info("[Scheduler] Starting 'Popular Movies' job...")
-	startTime := time.Now()
-
-	mj := radarrJob{}
-	largeMovieQueryLimit := 1000
-
-	// Initialize movie settings
-	if err := s.initializeMovieJob(&mj); err != nil {
-		log.Error("[Scheduler] Failed to initialize 'Popular Movies' job. Check your settings and try again.", "error", err)
-		s.gctx.Crate().SQL.Queries().InsertLog(s.gctx, structures.LogLevelError, "Scheduler", "Failed to initialize 'Popular Movies' job. Check your settings and try again.")
+		run.fail(err)
+		if errors.Is(err, trakt.ErrNoTraktSettings) {
+			log.Warnf("[Scheduler] '%s' job could not be completed. Trakt Client ID is not set.", jobName)
+			s.gctx.Crate().SQL.Queries().InsertLog(s.gctx, structures.LogLevelWarn, "Scheduler", fmt.Sprintf("'%s' job could not be completed. Trakt Client ID is not set.", jobName))
+		} else {
+			log.Errorf("[Scheduler] Error fetching '%s' from Trakt. %v", jobName, err)
+			s.gctx.Crate().SQL.Queries().InsertLog(s.gctx, structures.LogLevelError, "Scheduler", fmt.Sprintf("Error fetching '%s' from Trakt.", jobName))
+		}
 		return
 	}
 
-	// Fetch popular movies from Trakt
-	if mj.movieSettings.Popular.Valid && mj.movieSettings.Popular.Int32 > 0 {
-		params := buildTraktParamsFromSettings(mj.movieSettings, largeMovieQueryLimit, false)
-		popularMovies, err := s.helpers.Trakt.GetPopularMovies(s.gctx, params)
+	// Process the fetched movies
+	if limit > 0 {
+		mj.ownedTMDBIDs, err = s.fetchOwnedMovieIDs(mj.radarrInstances)
 		if err != nil {
-			if errors.Is(err, trakt.ErrNoTraktSettings) {
-				log.Warn("[Scheduler] 'Popular Movies' job could not be completed. Trakt Client ID is not set.")
-				s.gctx.Crate().SQL.Queries().InsertLog(s.gctx, structures.LogLevelWarn, "Scheduler", "'Popular Movies' job could not be completed. Trakt Client ID is not set.")
-				return
-			} else {
-				log.Error("[Scheduler] Error fetching 'Popular Movies' from Trakt.", "error", err)
-				s.gctx.Crate().SQL.Queries().InsertLog(s.gctx, structures.LogLevelError, "Scheduler", "Error fetching 'Popular Movies' from Trakt.")
-				return
-			}
+			log.Warnf("[Scheduler] Could not fetch the Radarr library for '%s' job, owned movies will not be skipped. %v", jobName, err)
 		}
 
-		// Process the fetched movies
-		mj.popularMovies = filterAndLimitMovies(extractMoviesFromPopular(popularMovies), mj.movieSettings, int(mj.movieSettings.Popular.Int32))
-		s.processMovies(mj.popularMovies, mj)
+		filter := newMovieFilter(mj.movieSettings, mj.ownedTMDBIDs)
+		filter.bounds = s.listSourceBounds(listType)
+		filter.ratings = newRatingFilter(s.gctx, s.helpers.OMDb, movieRatingThresholds(mj.movieSettings))
+		filter.library = s.loadMediaServerLibrary("MOVIE")
+		filter.watched, err = s.fetchWatchedMovieIDs()
+		if err != nil {
+			log.Warnf("[Scheduler] Could not fetch the Trakt watch history for '%s' job, watched movies will not be skipped. %v", jobName, err)
+		}
+		filter.excluded, err = s.syncMovieExclusions(mj.radarrInstances, mj.movieSettings.BlacklistedTMDBIDs, !isDryRun(s.gctx))
+		if err != nil {
+			log.Warnf("[Scheduler] Could not fetch the Radarr exclusion list for '%s' job, excluded movies will not be skipped. %v", jobName, err)
+		}
+		filteredMovies, skipped := filterAndLimitMovies(movies, filter, limit)
+		backend := requestBackend(s.gctx, structures.RequestBackendRadarr)
+		for _, candidate := range skipped {
+			recordSkippedMovie(s.gctx, run, candidate, backend)
+		}
+		s.processMovies(filteredMovies, mj)
 	}
 
-	log.Infof("[Scheduler] Completed 'Popular Movies' job in %.2f seconds.", time.Since(startTime).Seconds())
-	s.gctx.Crate().SQL.Queries().InsertLog(s.gctx, structures.LogLevelInfo, "Scheduler", fmt.Sprintf("Completed 'Popular Movies' job in %.2f seconds.", time.Since(startTime).Seconds()))
+	log.Infof("[Scheduler] Completed '%s' job in %.2f seconds.", jobName, time.Since(startTime).Seconds())
+	s.gctx.Crate().SQL.Queries().InsertLog(s.gctx, structures.LogLevelInfo, "Scheduler", fmt.Sprintf("Completed '%s' job in %.2f seconds.", jobName, time.Since(startTime).Seconds()))
 }
 
-// TrendingJobFunc fetches and processes trending movies
-func (s Scheduler) TrendingJobFunc() {
-	err := s.helpers.Trakt.Ping(context.Background())
-	if err != nil {
-		log.Warn("[Scheduler] Skipping 'Trending Movies' job. Trakt credentials are missing.")
-		s.gctx.Crate().SQL.Queries().InsertLog(s.gctx, structures.LogLevelWarn, "Scheduler", "Skipping 'Trending Movies' job. Trakt credentials are missing.")
-		return
+// fetchMovieCandidates fetches the movies for a list from Trakt along with the number of movies to request.
+// Nothing is fetched when the list is disabled in the movie settings.
+func (s Scheduler) fetchMovieCandidates(listType string, settings db.MovieSettings) ([]trakt.Movie, int, error) {
+	// List sources have their own limit
+	if _, ok := parseListSourceJobType(listType); ok {
+		return s.fetchListSourceMovies(listType)
 	}
 
-	log.Info("[Scheduler] Starting 'Trending Movies' job...")
-	startTime := time.Now()
-
-	mj := radarrJob{}
 	largeMovieQueryLimit := 1000
 
-	// Initialize movie settings
-	if err := s.initializeMovieJob(&mj); err != nil {
-		log.Error("[Scheduler] Failed to initialize 'Trending Movies' job. Check your settings and try again.", "error", err)
-		s.gctx.Crate().SQL.Queries().InsertLog(s.gctx, structures.LogLevelError, "Scheduler", "Failed to initialize 'Trending Movies' job. Check your settings and try again.")
-		return
-	}
-
-	// Fetch trending movies from Trakt
-	if mj.movieSettings.Trending.Valid && mj.movieSettings.Trending.Int32 > 0 {
-		params := buildTraktParamsFromSettings(mj.movieSettings, largeMovieQueryLimit, false)
+	var limitSetting sql.NullInt32
+	switch listType {
+	case "movie-anticipated":
+		limitSetting = settings.Anticipated
+	case "movie-box_office":
+		limitSetting = settings.BoxOffice
+	case "movie-popular":
+		limitSetting = settings.Popular
+	case "movie-trending":
+		limitSetting = settings.Trending
+	case "movie-watched":
+		limitSetting = settings.Watched
+	case "movie-played":
+		limitSetting = settings.Played
+	case "movie-collected":
+		limitSetting = settings.Collected
+	default:
+		return nil, 0, ErrUnknownJobType
+	}
+
+	if !limitSetting.Valid || limitSetting.Int32 <= 0 {
+		return nil, 0, nil
+	}
+	limit := int(limitSetting.Int32)
+
+	params := buildTraktParamsFromSettings(settings, largeMovieQueryLimit, listType == "movie-anticipated")
+
+	switch listType {
+	case "movie-anticipated":
+		anticipatedMovies, err := s.helpers.Trakt.GetAnticipatedMovies(s.gctx, params)
+		return extractMoviesFromAnticipated(anticipatedMovies), limit, err
+	case "movie-box_office":
+		boxOfficeMovies, err := s.helpers.Trakt.GetBoxOfficeMovies(s.gctx, params)
+		return extractMoviesFromBoxOffice(boxOfficeMovies), limit, err
+	case "movie-popular":
+		popularMovies, err := s.helpers.Trakt.GetPopularMovies(s.gctx, params)
+		return extractMoviesFromPopular(popularMovies), limit, err
+	case "movie-watched":
+		params.Period = settings.WatchedPeriod
+		watchedMovies, err := s.helpers.Trakt.GetMostWatchedMovies(s.gctx, params)
+		return extractMoviesFromMostWatched(watchedMovies), limit, err
+	case "movie-played":
+		params.Period = settings.PlayedPeriod
+		playedMovies, err := s.helpers.Trakt.GetMostPlayedMovies(s.gctx, params)
+		return extractMoviesFromMostPlayed(playedMovies), limit, err
+	case "movie-collected":
+		params.Period = settings.CollectedPeriod
+		collectedMovies, err := s.helpers.Trakt.GetMostCollectedMovies(s.gctx, params)
+		return extractMoviesFromMostWatched(collectedMovies), limit, err
+	default:
 		trendingMovies, err := s.helpers.Trakt.GetTrendingMovies(s.gctx, params)
-		if err != nil {
-			if errors.Is(err, trakt.ErrNoTraktSettings) {
-				log.Warn("[Scheduler] 'Trending Movies' job could not be completed. Trakt Client ID is not set.")
-				s.gctx.Crate().SQL.Queries().InsertLog(s.gctx, structures.LogLevelWarn, "Scheduler", "'Trending Movies' job could not be completed. Trakt Client ID is not set.")
-				return
-			} else {
-				log.Error("[Scheduler] Error fetching 'Trending Movies' from Trakt.", "error", err)
-				s.gctx.Crate().SQL.Queries().InsertLog(s.gctx, structures.LogLevelError, "Scheduler", "Error fetching 'Trending Movies' from Trakt.")
-				return
-			}
-		}
-
-		// Process the fetched movies
-		mj.trendingMovies = filterAndLimitMovies(extractMoviesFromTrending(trendingMovies), mj.movieSettings, int(mj.movieSettings.Trending.Int32))
-		s.processMovies(mj.trendingMovies, mj)
+		return extractMoviesFromTrending(trendingMovies), limit, err
 	}
-
-	log.Infof("[Scheduler] Completed 'Trending Movies' job in %.2f seconds.", time.Since(startTime).Seconds())
-	s.gctx.Crate().SQL.Queries().InsertLog(s.gctx, structures.LogLevelInfo, "Scheduler", fmt.Sprintf("Completed 'Trending Movies' job in %.2f seconds.", time.Since(startTime).Seconds()))
 }
 
 // initializeMovieJob handles common setup logic for all movie jobs
 func (s Scheduler) initializeMovieJob(mj *radarrJob) error {
 	gctx := s.gctx
 
-	// Get all settings for Radarr
+	// Get the Radarr instances the job sends to
 	var err error
-	mj.radarrSettings, err = gctx.Crate().SQL.Queries().GetRadarrSettings(gctx)
+	mj.radarrInstances, err = gctx.Crate().SQL.Queries().GetRadarrInstancesForJob(gctx, mj.run.Source)
 	if err != nil {
-		return fmt.Errorf("[Scheduler] Error fetching Radarr settings: %w", err)
+		return fmt.Errorf("[Scheduler] Error fetching Radarr instances: %w", err)
 	}
 
 	// Get all settings for movies
@@ -238,6 +237,8 @@ func (s Scheduler) initializeMovieJob(mj *radarrJob) error {
 		return fmt.Errorf("[Scheduler] Error fetching movie settings: %w", err)
 	}
 
+	mj.tags = s.jobTagLabels(mj.run.Source)
+
 	return nil
 }
 
@@ -249,14 +250,30 @@ func (s Scheduler) processMovies(movies []trakt.Movie, mj radarrJob) {
 	// Check if Ombi is enabled
 	ombiEnabled, err := gctx.Crate().SQL.Queries().GetSettingByKey(gctx, structures.SettingMode.String())
 	if err != nil {
+		mj.run.fail(err)
 		log.Error("[Scheduler] Error fetching Ombi enabled setting.", "error", err)
 		return
 	}
 
+	// In dry-run mode only report what would have been requested
+	if isDryRun(gctx) {
+		backend := structures.RequestBackendRadarr
+		if ombiEnabled.Value.String == "ombi" {
+			backend = structures.RequestBackendOmbi
+		}
+
+		for _, movie := range movies {
+			log.Infof("[Scheduler] Dry run: would request movie '%s' via %s.", movie.Title, backend)
+			recordMovieHistory(gctx, mj.run, movie, backend, structures.RequestOutcomeDryRun, nil)
+		}
+		return
+	}
+
 	// If Ombi is enabled, request movies via Ombi
 	if ombiEnabled.Value.String == "ombi" {
 		mj.ombiSettings, err = gctx.Crate().SQL.Queries().GetOmbiSettings(gctx)
 		if err != nil {
+			mj.run.fail(err)
 			if errors.Is(err, db.ErrNoOmbiSettings) {
 				log.Warn("[Scheduler] Skipping Ombi job. Ombi settings are not configured.")
 				return
@@ -267,17 +284,17 @@ func (s Scheduler) processMovies(movies []trakt.Movie, mj radarrJob) {
 		}
 
 		// Request movies via Ombi
-		requestMoviesToOmbi(s.gctx, helpers, s.notifications, movies, mj.ombiSettings)
+		requestMoviesToOmbi(s.gctx, helpers, s.notifications, movies, mj.ombiSettings, mj.run)
 	} else {
 		// Otherwise, use Radarr to request movies
-		requestMoviesToRadarr(s.gctx, helpers, s.notifications, movies, mj.radarrSettings)
-	}
-}
+		if len(mj.radarrInstances) == 0 {
+			mj.run.fail(fmt.Errorf("no Radarr instance is configured for %s", mj.run.Source))
+			log.Warnf("[Scheduler] Skipping Radarr requests for %s. No Radarr instance is configured.", mj.run.Source)
+			return
+		}
 
-// Helper function to filter and limit movies based on settings
-func filterAndLimitMovies(movies []trakt.Movie, settings db.MovieSettings, limit int) []trakt.Movie {
-	filteredMovies := applyAdditionalFilters(movies, settings)
-	return getTopNMovies(filteredMovies, limit)
+		requestMoviesToRadarr(s.gctx, helpers, s.notifications, movies, mj.radarrInstances, mj.tags, mj.run)
+	}
 }
 
 // Helper function to build Trakt API request parameters from the settings
@@ -332,64 +349,131 @@ func buildTraktParamsFromSettings(settings db.MovieSettings, limit int, isAntici
 	return params
 }
 
-// Additional filtering logic for movies
-func applyAdditionalFilters(movies []trakt.Movie, settings db.MovieSettings) []trakt.Movie {
+// movieCandidate is a movie returned by Trakt along with the reason it was filtered out, if any
+type movieCandidate struct {
+	Movie     trakt.Movie
+	Reason    string // Empty when the movie will be requested
+	OverLimit bool   // Whether the movie passed the filters but the list limit was already reached
+	Owned     bool   // Whether the movie is already in the media server library
+}
+
+// Helper function to filter and limit movies based on settings.
+// Also returns the movies that were dropped by the filters, not counting those over the limit.
+func filterAndLimitMovies(movies []trakt.Movie, filter movieFilter, limit int) ([]trakt.Movie, []movieCandidate) {
 	filteredMovies := []trakt.Movie{}
+	skipped := []movieCandidate{}
+	for _, candidate := range evaluateMovies(movies, filter, limit) {
+		if candidate.Reason == "" {
+			filteredMovies = append(filteredMovies, candidate.Movie)
+		} else if !candidate.OverLimit {
+			skipped = append(skipped, candidate)
+		}
+	}
+	return filteredMovies, skipped
+}
+
+// evaluateMovies runs every movie through the filters and the list limit, keeping the reason each dropped movie was filtered out.
+// Movies that are filtered out do not count towards the limit.
+func evaluateMovies(movies []trakt.Movie, filter movieFilter, limit int) []movieCandidate {
+	candidates := make([]movieCandidate, 0, len(movies))
+
+	included := 0
+	for _, movie := range movies {
+		candidate := movieCandidate{Movie: movie, Reason: filter.skipReason(movie)}
+		candidate.Owned = candidate.Reason == mediaServerOwnedReason
+		if candidate.Reason == "" {
+			if included >= limit {
+				candidate.Reason = fmt.Sprintf("exceeds list limit of %d", limit)
+				candidate.OverLimit = true
+			} else if candidate.Reason = filter.ratings.omdbSkipReason(movie.IDs.IMDB); candidate.Reason == "" {
+				// OMDb is only asked about candidates that would otherwise be requested
+				included++
+			}
+		}
+
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates
+}
+
+// movieFilter holds the blacklists from the movie settings and the movies that are already owned
+type movieFilter struct {
+	genres   map[string]bool
+	keywords []keywordRule
+	tmdbIDs  map[int]bool
+	owned    map[int]bool
+	excluded map[int]bool       // TMDb IDs on the exclusion list of a Radarr instance
+	watched  map[int]bool       // TMDb IDs the authorized Trakt account already watched
+	bounds   listBounds         // Year and runtime limits of a list source
+	ratings  ratingFilter       // Rating, vote and certification thresholds
+	library  mediaServerLibrary // Titles already in the media server library
+}
+
+func newMovieFilter(settings db.MovieSettings, ownedTMDBIDs map[int]bool) movieFilter {
+	filter := movieFilter{
+		genres:  make(map[string]bool),
+		tmdbIDs: make(map[int]bool),
+		owned:   ownedTMDBIDs,
+	}
 
 	// Build blacklisted genres, keywords, and TMDb IDs from settings
-	blacklistedGenres := make(map[string]bool)
 	for _, genre := range settings.BlacklistedGenres {
-		blacklistedGenres[strings.ToLower(genre.Genre)] = true
+		filter.genres[strings.ToLower(genre.Genre)] = true
 	}
 
-	blacklistedKeywords := make(map[string]bool)
 	for _, keyword := range settings.BlacklistedTitleKeywords {
-		blacklistedKeywords[strings.ToLower(keyword.Keyword)] = true
+		if rule, ok := compileStoredKeywordRule(keyword.Keyword, keyword.MatchMode); ok {
+			filter.keywords = append(filter.keywords, rule)
+		}
 	}
 
-	blacklistedTMDBIDs := make(map[int]bool)
 	for _, tmdbID := range settings.BlacklistedTMDBIDs {
-		blacklistedTMDBIDs[tmdbID.TMDBID] = true
+		filter.tmdbIDs[tmdbID.TMDBID] = true
 	}
 
-	// Apply filters to each movie
-	for _, movie := range movies {
-		if blacklistedTMDBIDs[movie.IDs.TMDB] {
-			continue
-		}
+	return filter
+}
 
-		isBlacklisted := false
-		for _, genre := range movie.Genres {
-			if blacklistedGenres[strings.ToLower(genre)] {
-				isBlacklisted = true
-				break
-			}
-		}
-		if isBlacklisted {
-			continue
-		}
+// skipReason returns why the movie should not be requested, or an empty string if it passes every filter
+func (f movieFilter) skipReason(movie trakt.Movie) string {
+	if f.owned[movie.IDs.TMDB] {
+		return "already in the Radarr library"
+	}
 
-		for keyword := range blacklistedKeywords {
-			if strings.Contains(strings.ToLower(movie.Title), keyword) {
-				isBlacklisted = true
-				break
-			}
-		}
-		if isBlacklisted {
-			continue
-		}
+	if f.library.ownsMovie(movie.IDs) {
+		return mediaServerOwnedReason
+	}
 
-		filteredMovies = append(filteredMovies, movie)
+	if f.watched[movie.IDs.TMDB] {
+		return "already watched on Trakt"
 	}
 
-	return filteredMovies
-}
+	if f.tmdbIDs[movie.IDs.TMDB] {
+		return fmt.Sprintf("blacklisted TMDb ID %d", movie.IDs.TMDB)
+	}
 
-func getTopNMovies(movies []trakt.Movie, limit int) []trakt.Movie {
-	if len(movies) > limit {
-		return movies[:limit]
+	if f.excluded[movie.IDs.TMDB] {
+		return "on the Radarr exclusion list"
 	}
-	return movies
+
+	for _, genre := range movie.Genres {
+		if f.genres[strings.ToLower(genre)] {
+			return fmt.Sprintf("blacklisted genre '%s'", genre)
+		}
+	}
+
+	for _, keyword := range f.keywords {
+		if keyword.matches(movie.Title) {
+			return keyword.skipReason()
+		}
+	}
+
+	if reason := f.ratings.skipReason(movie.Rating, movie.Votes, movie.Certification); reason != "" {
+		return reason
+	}
+
+	return f.bounds.skipReason(movie.Year, movie.Runtime)
 }
 
 // Extract movies from various Trakt types
@@ -421,6 +505,23 @@ func extractMoviesFromBoxOffice(boxOfficeMovies []trakt.TraktBoxOfficeMovie) []t
 	return movies
 }
 
+// extractMoviesFromMostWatched works for the most watched and most collected lists, they return the same stats
+func extractMoviesFromMostWatched(mostWatchedMovies []trakt.TraktMostWatchedMovie) []trakt.Movie {
+	var movies []trakt.Movie
+	for _, mostWatched := range mostWatchedMovies {
+		movies = append(movies, mostWatched.Movie)
+	}
+	return movies
+}
+
+func extractMoviesFromMostPlayed(mostPlayedMovies trakt.GetMostPlayedMoviesResponse) []trakt.Movie {
+	var movies []trakt.Movie
+	for _, mostPlayed := range mostPlayedMovies.Movies {
+		movies = append(movies, mostPlayed.Movie)
+	}
+	return movies
+}
+
 func fetchRadarrSettings(r radarr.Service, radarrSettings db.RadarrSettings) (int, string, error) {
 	// Fetch quality profiles from Radarr
 	qualityProfiles, err := r.GetQualityProfiles(nil, nil)
@@ -459,7 +560,7 @@ func fetchRadarrSettings(r radarr.Service, radarrSettings db.RadarrSettings) (in
 }
 
 // Request movies to Ombi
-func requestMoviesToOmbi(gctx global.Context, helpers helpers.Helpers, notifications *notifications.NotificationManager, movies []trakt.Movie, ombiSettings db.OmbiSettings) {
+func requestMoviesToOmbi(gctx global.Context, helpers helpers.Helpers, notifications *notifications.NotificationManager, movies []trakt.Movie, ombiSettings db.OmbiSettings, run *jobRun) {
 	for _, movie := range movies {
 		body := ombi.RequestMovieBody{
 			TheMovieDBID: movie.IDs.TMDB,
@@ -485,11 +586,14 @@ func requestMoviesToOmbi(gctx global.Context, helpers helpers.Helpers, notificat
 		if err != nil {
 			if errors.Is(err, ombi.ErrMovieAlreadyRequested) {
 				log.Warnf("[Ombi Job] Skipping '%s' - already requested.", movie.Title)
+				recordMovieHistory(gctx, run, movie, structures.RequestBackendOmbi, structures.RequestOutcomeExists, nil)
 			} else {
 				log.Errorf("[Ombi Job] Failed to request movie '%s': %v", movie.Title, err)
+				recordMovieHistory(gctx, run, movie, structures.RequestBackendOmbi, structures.RequestOutcomeFailed, err)
 			}
 		} else {
 			log.Infof("[Ombi Job] Movie '%s' successfully requested.", movie.Title)
+			recordMovieHistory(gctx, run, movie, structures.RequestBackendOmbi, structures.RequestOutcomeAdded, nil)
 
 			// Fetch and store movie poster
 			media, err := helpers.OMDb.GetMedia(context.Background(), movie.IDs.IMDB)
@@ -517,58 +621,102 @@ func requestMoviesToOmbi(gctx global.Context, helpers helpers.Helpers, notificat
 	}
 }
 
-// Request movies to Radarr
-func requestMoviesToRadarr(gctx global.Context, helpers helpers.Helpers, notifications *notifications.NotificationManager, movies []trakt.Movie, radarrSettings db.RadarrSettings) {
-	qualityProfileID, rootFolderPath, err := fetchRadarrSettings(helpers.Radarr, radarrSettings)
-	if err != nil {
-		log.Error("[Radarr Job] Failed to retrieve Radarr settings.", "error", err)
-		return
-	}
+// radarrTarget is a Radarr instance along with the quality profile, root folder and tags movies are added with
+type radarrTarget struct {
+	service          radarr.Service
+	settings         db.RadarrSettings
+	qualityProfileID int
+	rootFolderPath   string
+	tagIDs           []int
+}
 
-	for _, movie := range movies {
-		body := radarr.RequestMovieBody{
-			Title:               movie.Title,
-			TMDBID:              movie.IDs.TMDB,
-			Monitored:           true,
-			QualityProfileID:    qualityProfileID,
-			RootFolderPath:      rootFolderPath,
-			MinimumAvailability: radarrSettings.MinimumAvailability.String, // Adjust as needed
+// Request movies to every Radarr instance the job sends to
+func requestMoviesToRadarr(gctx global.Context, helpers helpers.Helpers, notifications *notifications.NotificationManager, movies []trakt.Movie, instances []db.RadarrSettings, tags []string, run *jobRun) {
+	targets := []radarrTarget{}
+	unavailable := instanceResults{} // Instances whose settings couldn't be fetched, every movie fails on them
+	for _, instance := range instances {
+		service := helpers.Radarr.Instance(instance.ID)
+		qualityProfileID, rootFolderPath, err := fetchRadarrSettings(service, instance)
+		if err != nil {
+			log.Errorf("[Radarr Job] Failed to retrieve settings for Radarr instance '%s'. %v", instance.Name, err)
+			unavailable = append(unavailable, instanceResult{instanceID: instance.ID, name: instance.Name, outcome: structures.RequestOutcomeFailed, err: err})
+			continue
 		}
 
-		body.AddOptions.SearchForMovie = true
-
-		_, err := helpers.Radarr.RequestMovie(nil, nil, body)
+		tagIDs, err := radarrTagIDs(service, tags)
 		if err != nil {
-			if errors.Is(err, radarr.ErrMovieAlreadyExists) {
-				log.Warnf("[Radarr Job] Skipping '%s' - already exists in Radarr.", movie.Title)
-			} else {
-				log.Errorf("[Radarr Job] Failed to request movie '%s': %v", movie.Title, err)
-			}
-		} else {
-			log.Infof("[Radarr Job] Movie '%s' successfully requested.", movie.Title)
+			log.Warnf("[Radarr Job] Failed to retrieve tags for Radarr instance '%s', adding movies without tags. %v", instance.Name, err)
+		}
 
-			// Fetch and store movie poster
-			media, err := helpers.OMDb.GetMedia(context.Background(), movie.IDs.IMDB)
-			if err != nil {
-				log.Errorf("[Radarr Job] Failed to fetch movie poster for '%s': %v", movie.Title, err)
-				continue
-			}
+		targets = append(targets, radarrTarget{
+			service:          service,
+			settings:         instance,
+			qualityProfileID: qualityProfileID,
+			rootFolderPath:   rootFolderPath,
+			tagIDs:           tagIDs,
+		})
+	}
 
-			// Add movie to recently added list
-			err = gctx.Crate().SQL.Queries().AddToRecentlyAddedMedia(context.Background(), "MOVIE", media.Title, movie.Year, media.Plot, media.IMDBID, media.Poster)
-			if err != nil {
-				log.Errorf("[Radarr Job] Failed to add movie '%s' to recently added list: %v", movie.Title, err)
+	for _, movie := range movies {
+		results := append(instanceResults{}, unavailable...)
+		for _, target := range targets {
+			body := radarr.RequestMovieBody{
+				Title:               movie.Title,
+				TMDBID:              movie.IDs.TMDB,
+				Monitored:           true,
+				QualityProfileID:    target.qualityProfileID,
+				RootFolderPath:      target.rootFolderPath,
+				MinimumAvailability: target.settings.MinimumAvailability.String,
+				Tags:                target.tagIDs,
 			}
 
-			// Send notification
-			moviePayload, err := json.Marshal(movie)
-			if err != nil {
-				log.Errorf("[Radarr Job] Failed to marshal movie payload for notifications: %v", err)
-			}
-			err = notifications.SendNotification(structures.MOVIEADDEDALERT, moviePayload)
+			body.AddOptions.SearchForMovie = true
+
+			result := instanceResult{instanceID: target.settings.ID, name: target.settings.Name, outcome: structures.RequestOutcomeAdded}
+			_, err := target.service.RequestMovie(nil, nil, body)
 			if err != nil {
-				log.Errorf("[Radarr Job] Failed to send notification for movie '%s': %v", movie.Title, err)
+				if errors.Is(err, radarr.ErrMovieAlreadyExists) {
+					log.Warnf("[Radarr Job] Skipping '%s' - already exists in Radarr instance '%s'.", movie.Title, target.settings.Name)
+					result.outcome = structures.RequestOutcomeExists
+				} else {
+					log.Errorf("[Radarr Job] Failed to request movie '%s' from Radarr instance '%s': %v", movie.Title, target.settings.Name, err)
+					result.outcome = structures.RequestOutcomeFailed
+					result.err = err
+				}
+			} else {
+				log.Infof("[Radarr Job] Movie '%s' successfully requested from Radarr instance '%s'.", movie.Title, target.settings.Name)
 			}
+			results = append(results, result)
+		}
+
+		outcome := recordMovieResults(gctx, run, movie, results)
+
+		// Only announce the movie once, no matter how many instances it was added to
+		if outcome != structures.RequestOutcomeAdded {
+			continue
+		}
+
+		// Fetch and store movie poster
+		media, err := helpers.OMDb.GetMedia(context.Background(), movie.IDs.IMDB)
+		if err != nil {
+			log.Errorf("[Radarr Job] Failed to fetch movie poster for '%s': %v", movie.Title, err)
+			continue
+		}
+
+		// Add movie to recently added list
+		err = gctx.Crate().SQL.Queries().AddToRecentlyAddedMedia(context.Background(), "MOVIE", media.Title, movie.Year, media.Plot, media.IMDBID, media.Poster)
+		if err != nil {
+			log.Errorf("[Radarr Job] Failed to add movie '%s' to recently added list: %v", movie.Title, err)
+		}
+
+		// Send notification
+		moviePayload, err := json.Marshal(movie)
+		if err != nil {
+			log.Errorf("[Radarr Job] Failed to marshal movie payload for notifications: %v", err)
+		}
+		err = notifications.SendNotification(structures.MOVIEADDEDALERT, moviePayload)
+		if err != nil {
+			log.Errorf("[Radarr Job] Failed to send notification for movie '%s': %v", movie.Title, err)
 		}
 	}
 }
@@ -0,0 +1,135 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/charmbracelet/log"
+	"github.com/mahcks/blockbusterr/internal/global"
+	"github.com/mahcks/blockbusterr/pkg/structures"
+)
+
+var ErrUnknownJobType = errors.New("unknown job type")
+
+// isDryRun reports whether scheduled jobs should only record what they would request
+func isDryRun(gctx global.Context) bool {
+	setting, err := gctx.Crate().SQL.Queries().GetSettingByKey(gctx, structures.SettingDryRun.String())
+	if err != nil {
+		log.Warn("[Scheduler] Error fetching dry-run setting, assuming it is disabled.", "error", err)
+		return false
+	}
+
+	return setting.Value.String == "true"
+}
+
+// PreviewJob runs the Trakt fetch and filters for a list job and returns every candidate
+// along with whether it would be requested, without calling Radarr, Sonarr or Ombi
+func (s *Scheduler) PreviewJob(listType string) (structures.JobPreview, error) {
+	preview := structures.JobPreview{
+		JobType:    listType,
+		Candidates: []structures.JobPreviewItem{},
+	}
+
+	mediaType, err := s.jobMediaType(listType)
+	if err != nil {
+		return preview, err
+	}
+
+	switch mediaType {
+	case structures.ListSourceMediaTypeMovie:
+		movieSettings, err := s.gctx.Crate().SQL.Queries().GetMovieSettings(s.gctx)
+		if err != nil {
+			return preview, fmt.Errorf("error fetching movie settings: %w", err)
+		}
+
+		movies, limit, err := s.fetchMovieCandidates(listType, movieSettings)
+		if err != nil {
+			return preview, err
+		}
+
+		instances, err := s.gctx.Crate().SQL.Queries().GetRadarrInstancesForJob(s.gctx, listType)
+		if err != nil {
+			return preview, fmt.Errorf("error fetching Radarr instances: %w", err)
+		}
+
+		// A preview without the library is still useful, so only warn if it can't be fetched
+		owned, err := s.fetchOwnedMovieIDs(instances)
+		if err != nil {
+			log.Warn("[Scheduler] Could not fetch the Radarr library for the preview.", "error", err)
+		}
+
+		preview.Limit = limit
+		filter := newMovieFilter(movieSettings, owned)
+		filter.bounds = s.listSourceBounds(listType)
+		filter.ratings = newRatingFilter(s.gctx, s.helpers.OMDb, movieRatingThresholds(movieSettings))
+		filter.library = s.loadMediaServerLibrary("MOVIE")
+		filter.watched, err = s.fetchWatchedMovieIDs()
+		if err != nil {
+			log.Warn("[Scheduler] Could not fetch the Trakt watch history for the preview.", "error", err)
+		}
+		filter.excluded, err = s.syncMovieExclusions(instances, movieSettings.BlacklistedTMDBIDs, false)
+		if err != nil {
+			log.Warn("[Scheduler] Could not fetch the Radarr exclusion list for the preview.", "error", err)
+		}
+		for _, candidate := range evaluateMovies(movies, filter, limit) {
+			preview.Candidates = append(preview.Candidates, structures.JobPreviewItem{
+				Title:    candidate.Movie.Title,
+				Year:     candidate.Movie.Year,
+				TMDBID:   candidate.Movie.IDs.TMDB,
+				IMDBID:   candidate.Movie.IDs.IMDB,
+				Included: candidate.Reason == "",
+				Reason:   candidate.Reason,
+			})
+		}
+	case structures.ListSourceMediaTypeShow:
+		showSettings, err := s.gctx.Crate().SQL.Queries().GetShowSettings(s.gctx)
+		if err != nil {
+			return preview, fmt.Errorf("error fetching show settings: %w", err)
+		}
+
+		shows, limit, err := s.fetchShowCandidates(listType, showSettings)
+		if err != nil {
+			return preview, err
+		}
+
+		instances, err := s.gctx.Crate().SQL.Queries().GetSonarrInstancesForJob(s.gctx, listType)
+		if err != nil {
+			return preview, fmt.Errorf("error fetching Sonarr instances: %w", err)
+		}
+
+		// A preview without the library is still useful, so only warn if it can't be fetched
+		owned, err := s.fetchOwnedShowIDs(instances)
+		if err != nil {
+			log.Warn("[Scheduler] Could not fetch the Sonarr library for the preview.", "error", err)
+		}
+
+		preview.Limit = limit
+		filter := newShowFilter(showSettings, owned)
+		filter.bounds = s.listSourceBounds(listType)
+		filter.ratings = newRatingFilter(s.gctx, s.helpers.OMDb, showRatingThresholds(showSettings))
+		filter.library = s.loadMediaServerLibrary("SHOW")
+		filter.watched, err = s.fetchWatchedShowIDs()
+		if err != nil {
+			log.Warn("[Scheduler] Could not fetch the Trakt watch history for the preview.", "error", err)
+		}
+		filter.excluded, err = s.syncShowExclusions(instances, showSettings.BlacklistedTVDBIDs, false)
+		if err != nil {
+			log.Warn("[Scheduler] Could not fetch the Sonarr import list exclusions for the preview.", "error", err)
+		}
+		for _, candidate := range evaluateShows(shows, filter, limit) {
+			preview.Candidates = append(preview.Candidates, structures.JobPreviewItem{
+				Title:    candidate.Show.Title,
+				Year:     candidate.Show.Year,
+				TMDBID:   candidate.Show.IDs.TMDB,
+				TVDBID:   candidate.Show.IDs.TVDB,
+				IMDBID:   candidate.Show.IDs.IMDB,
+				Included: candidate.Reason == "",
+				Reason:   candidate.Reason,
+			})
+		}
+	default:
+		return preview, ErrUnknownJobType
+	}
+
+	return preview, nil
+}
@@ -0,0 +1,201 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/mahcks/blockbusterr/internal/db"
+	"github.com/mahcks/blockbusterr/internal/helpers/omdb"
+	"github.com/mahcks/blockbusterr/pkg/utils"
+)
+
+// ratingFilter holds the rating, vote and certification thresholds the movie and show settings share.
+// The Trakt rating, votes and certification come with the candidates, the other ratings are looked up on OMDb.
+type ratingFilter struct {
+	minRating      float64
+	minVotes       int
+	minIMDbRating  float64
+	minRotten      int
+	minMetacritic  int
+	allowedCerts   map[string]bool
+	blockedCerts   map[string]bool
+	ctx            context.Context
+	omdb           omdb.Service
+	omdbRatingsMap map[string]omdbRatings // OMDb ratings by IMDb ID, so a title is only looked up once per run
+	omdbFailed     *bool                  // Set once an OMDb lookup fails, the OMDb thresholds are ignored for the rest of the run
+}
+
+// omdbRatings are the ratings OMDb returned for a title, a value below zero means OMDb doesn't have it
+type omdbRatings struct {
+	imdb       float64
+	rotten     int
+	metacritic int
+}
+
+// ratingThresholds are the columns of the movie and show settings the rating filter is built from
+type ratingThresholds struct {
+	MinRating             sql.NullFloat64
+	MinVotes              sql.NullInt32
+	MinIMDbRating         sql.NullFloat64
+	RottenTomatoes        sql.NullString
+	MinMetacritic         sql.NullInt32
+	AllowedCertifications sql.NullString
+	BlockedCertifications sql.NullString
+}
+
+func newRatingFilter(ctx context.Context, omdbService omdb.Service, thresholds ratingThresholds) ratingFilter {
+	filter := ratingFilter{
+		minRating:      thresholds.MinRating.Float64,
+		minVotes:       int(thresholds.MinVotes.Int32),
+		minIMDbRating:  thresholds.MinIMDbRating.Float64,
+		minMetacritic:  int(thresholds.MinMetacritic.Int32),
+		allowedCerts:   ParseCertifications(thresholds.AllowedCertifications.String),
+		blockedCerts:   ParseCertifications(thresholds.BlockedCertifications.String),
+		ctx:            ctx,
+		omdb:           omdbService,
+		omdbRatingsMap: make(map[string]omdbRatings),
+		omdbFailed:     new(bool),
+	}
+
+	// Invalid values are rejected when the settings are saved, so an error here means the threshold is unset
+	if thresholds.RottenTomatoes.Valid {
+		filter.minRotten, _ = utils.ParseRottenTomatoes(thresholds.RottenTomatoes.String)
+	}
+
+	return filter
+}
+
+// skipReason checks the Trakt rating, votes and certification of a candidate
+func (f ratingFilter) skipReason(rating float64, votes int, certification string) string {
+	if f.minRating > 0 && rating < f.minRating {
+		return fmt.Sprintf("Trakt rating %.1f is below the minimum of %.1f", rating, f.minRating)
+	}
+
+	if f.minVotes > 0 && votes < f.minVotes {
+		return fmt.Sprintf("%d Trakt votes is below the minimum of %d", votes, f.minVotes)
+	}
+
+	cert := strings.ToLower(strings.TrimSpace(certification))
+	if f.blockedCerts[cert] && cert != "" {
+		return fmt.Sprintf("blocked certification '%s'", certification)
+	}
+
+	if len(f.allowedCerts) > 0 && !f.allowedCerts[cert] {
+		if cert == "" {
+			return "no certification while only certain certifications are allowed"
+		}
+		return fmt.Sprintf("certification '%s' is not allowed", certification)
+	}
+
+	return ""
+}
+
+// needsOMDb reports whether any threshold has to be checked on OMDb
+func (f ratingFilter) needsOMDb() bool {
+	return f.minIMDbRating > 0 || f.minRotten > 0 || f.minMetacritic > 0
+}
+
+// omdbSkipReason checks the IMDb, Rotten Tomatoes and Metacritic ratings of a candidate on OMDb.
+// Ratings OMDb doesn't have are not held against the candidate, and neither is OMDb being unavailable.
+func (f ratingFilter) omdbSkipReason(imdbID string) string {
+	if !f.needsOMDb() || *f.omdbFailed {
+		return ""
+	}
+
+	if imdbID == "" {
+		return "no IMDb ID to look up the OMDb ratings with"
+	}
+
+	ratings, ok := f.omdbRatingsMap[imdbID]
+	if !ok {
+		media, err := f.omdb.GetMedia(f.ctx, imdbID)
+		if err != nil {
+			log.Warnf("[Scheduler] Could not fetch the OMDb ratings, the IMDb, Rotten Tomatoes and Metacritic thresholds are ignored for this run. %v", err)
+			*f.omdbFailed = true
+			return ""
+		}
+
+		ratings = parseOMDbRatings(media)
+		f.omdbRatingsMap[imdbID] = ratings
+	}
+
+	if f.minIMDbRating > 0 && ratings.imdb >= 0 && ratings.imdb < f.minIMDbRating {
+		return fmt.Sprintf("IMDb rating %.1f is below the minimum of %.1f", ratings.imdb, f.minIMDbRating)
+	}
+
+	if f.minRotten > 0 && ratings.rotten >= 0 && ratings.rotten < f.minRotten {
+		return fmt.Sprintf("Rotten Tomatoes score %d%% is below the minimum of %d%%", ratings.rotten, f.minRotten)
+	}
+
+	if f.minMetacritic > 0 && ratings.metacritic >= 0 && ratings.metacritic < f.minMetacritic {
+		return fmt.Sprintf("Metacritic score %d is below the minimum of %d", ratings.metacritic, f.minMetacritic)
+	}
+
+	return ""
+}
+
+// parseOMDbRatings reads the ratings from the OMDb Ratings list, e.g. "7.8/10", "91%" and "74/100"
+func parseOMDbRatings(media *omdb.Media) omdbRatings {
+	ratings := omdbRatings{imdb: -1, rotten: -1, metacritic: -1}
+
+	for _, rating := range media.Ratings {
+		switch rating.Source {
+		case "Internet Movie Database":
+			score, _, _ := strings.Cut(rating.Value, "/")
+			if value, err := strconv.ParseFloat(score, 64); err == nil {
+				ratings.imdb = value
+			}
+		case "Rotten Tomatoes":
+			if value, err := strconv.Atoi(strings.TrimSuffix(rating.Value, "%")); err == nil {
+				ratings.rotten = value
+			}
+		case "Metacritic":
+			score, _, _ := strings.Cut(rating.Value, "/")
+			if value, err := strconv.Atoi(score); err == nil {
+				ratings.metacritic = value
+			}
+		}
+	}
+
+	return ratings
+}
+
+// ParseCertifications splits a comma-separated list of certifications into a lowercase set
+func ParseCertifications(value string) map[string]bool {
+	certs := make(map[string]bool)
+	for _, cert := range strings.Split(value, ",") {
+		if cert = strings.ToLower(strings.TrimSpace(cert)); cert != "" {
+			certs[cert] = true
+		}
+	}
+
+	return certs
+}
+
+func movieRatingThresholds(settings db.MovieSettings) ratingThresholds {
+	return ratingThresholds{
+		MinRating:             settings.MinRating,
+		MinVotes:              settings.MinVotes,
+		MinIMDbRating:         settings.MinIMDbRating,
+		RottenTomatoes:        settings.RottenTomatoes,
+		MinMetacritic:         settings.MinMetacritic,
+		AllowedCertifications: settings.AllowedCertifications,
+		BlockedCertifications: settings.BlockedCertifications,
+	}
+}
+
+func showRatingThresholds(settings db.ShowSettings) ratingThresholds {
+	return ratingThresholds{
+		MinRating:             settings.MinRating,
+		MinVotes:              settings.MinVotes,
+		MinIMDbRating:         settings.MinIMDbRating,
+		RottenTomatoes:        settings.RottenTomatoes,
+		MinMetacritic:         settings.MinMetacritic,
+		AllowedCertifications: settings.AllowedCertifications,
+		BlockedCertifications: settings.BlockedCertifications,
+	}
+}
@@ -1,13 +1,18 @@
 package scheduler
 
 import (
+	"database/sql"
 	"fmt"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/charmbracelet/log"
+	"github.com/mahcks/blockbusterr/internal/db"
 	"github.com/mahcks/blockbusterr/internal/global"
 	"github.com/mahcks/blockbusterr/internal/helpers"
 	"github.com/mahcks/blockbusterr/internal/notifications"
+	"github.com/mahcks/blockbusterr/internal/websocket"
 	"github.com/robfig/cron/v3"
 )
 
@@ -18,10 +23,17 @@ type Scheduler struct {
 	helpers       helpers.Helpers
 	movieJobIDs   map[string]cron.EntryID
 	showJobIDs    map[string]cron.EntryID
+	taskJobIDs    map[string]cron.EntryID // Jobs that don't request titles, like the cleanup job, keyed by job type
+	jobSpecs      map[string]string       // Cron expression each scheduled job was added with, keyed by list or job type
+	jobsMu        *sync.Mutex             // Guards movieJobIDs, showJobIDs, taskJobIDs and jobSpecs
+	cleanupMu     *sync.Mutex             // Held while the cleanup job runs
+	mediaServerMu *sync.Mutex             // Held while the media server library is refreshed
+	runs          *runTracker
+	hub           *websocket.Hub
 }
 
 // Setup initializes a new scheduler instance
-func Setup(gctx global.Context, helpers helpers.Helpers, notifications *notifications.NotificationManager) *Scheduler {
+func Setup(gctx global.Context, helpers helpers.Helpers, notifications *notifications.NotificationManager, hub *websocket.Hub) *Scheduler {
 	svc := &Scheduler{
 		gctx:          gctx,
 		notifications: notifications,
@@ -29,6 +41,18 @@ func Setup(gctx global.Context, helpers helpers.Helpers, notifications *notifica
 		cron:          cron.New(),
 		movieJobIDs:   make(map[string]cron.EntryID),
 		showJobIDs:    make(map[string]cron.EntryID),
+		taskJobIDs:    make(map[string]cron.EntryID),
+		jobSpecs:      make(map[string]string),
+		jobsMu:        &sync.Mutex{},
+		cleanupMu:     &sync.Mutex{},
+		mediaServerMu: &sync.Mutex{},
+		runs:          newRunTracker(),
+		hub:           hub,
+	}
+
+	// Any run still marked as running was cut short by the last shutdown
+	if err := gctx.Crate().SQL.Queries().FailInterruptedJobRuns(gctx); err != nil {
+		log.Error("[Scheduler] Failed to mark interrupted job runs as failed.", "error", err)
 	}
 
 	// Setup individual cron jobs for each movie list
@@ -38,41 +62,55 @@ func Setup(gctx global.Context, helpers helpers.Helpers, notifications *notifica
 		return nil
 	}
 
-	// Schedule each list job with its cron expression
-	if movieSettings.CronAnticipated.Valid {
-		svc.scheduleMovieJob(movieSettings.CronAnticipated.String, svc.AnticipatedJobFunc, "movie-anticipated")
+	// Setup individual cron jobs for each show list
+	showSettings, err := gctx.Crate().SQL.Queries().GetShowSettings(gctx)
+	if err != nil {
+		log.Error("[Scheduler] Failed to retrieve show settings from the database. Please check your database connection and try again.", "error", err)
+		return nil
 	}
 
-	if movieSettings.CronBoxOffice.Valid {
-		svc.scheduleMovieJob(movieSettings.CronBoxOffice.String, svc.BoxOfficeJobFunc, "movie-box_office")
-	}
+	// Schedule each list job with its cron expression
+	svc.ReloadMovieJobs(movieSettings)
+	svc.ReloadShowJobs(showSettings)
+	svc.ReloadListSourceJobs()
 
-	if movieSettings.CronPopular.Valid {
-		svc.scheduleMovieJob(movieSettings.CronPopular.String, svc.PopularJobFunc, "movie-popular")
+	cleanupSettings, err := gctx.Crate().SQL.Queries().GetCleanupSettings(gctx)
+	if err != nil {
+		log.Error("[Scheduler] Failed to retrieve cleanup settings from the database.", "error", err)
+	} else {
+		svc.ReloadCleanupJob(cleanupSettings)
 	}
 
-	if movieSettings.CronTrending.Valid {
-		svc.scheduleMovieJob(movieSettings.CronTrending.String, svc.TrendingJobFunc, "movie-trending")
+	mediaServerSettings, err := gctx.Crate().SQL.Queries().GetMediaServerSettings(gctx)
+	if err != nil {
+		log.Error("[Scheduler] Failed to retrieve media server settings from the database.", "error", err)
+	} else {
+		svc.ReloadMediaServerJob(mediaServerSettings)
 	}
 
-	// Setup individual cron jobs for each show list
-	showSettings, err := gctx.Crate().SQL.Queries().GetShowSettings(gctx)
-	if err != nil {
-		log.Error("[Scheduler] Failed to retrieve show settings from the database. Please check your database connection and try again.", "error", err)
-		return nil
+	// Run every scheduled job once right away
+	for _, listType := range []string{"movie-anticipated", "movie-box_office", "movie-popular", "movie-trending", "movie-watched", "movie-played", "movie-collected"} {
+		if _, exists := svc.movieJobIDs[listType]; exists {
+			svc.RunJobOnDemand(listType, true)
+		}
 	}
 
-	// Schedule each show list job with its cron expression
-	if showSettings.CronJobAnticipated.Valid {
-		svc.scheduleShowJob(showSettings.CronJobAnticipated.String, svc.AnticipatedShowJobFunc, "show-anticipated")
+	for _, listType := range []string{"show-anticipated", "show-popular", "show-trending", "show-watched", "show-played", "show-collected"} {
+		if _, exists := svc.showJobIDs[listType]; exists {
+			svc.RunJobOnDemand(listType, false)
+		}
 	}
 
-	if showSettings.CronJobPopular.Valid {
-		svc.scheduleShowJob(showSettings.CronJobPopular.String, svc.PopularShowJobFunc, "show-popular")
+	for listType := range svc.jobSpecs {
+		if _, ok := parseListSourceJobType(listType); ok {
+			_, isMovie := svc.movieJobIDs[listType]
+			svc.RunJobOnDemand(listType, isMovie)
+		}
 	}
 
-	if showSettings.CronJobTrending.Valid {
-		svc.scheduleShowJob(showSettings.CronJobTrending.String, svc.TrendingShowJobFunc, "show-trending")
+	// Keep the Trakt access token fresh even when no job uses it for a while
+	if _, err := svc.cron.AddFunc("@hourly", svc.refreshTraktToken); err != nil {
+		log.Error("[Scheduler] Failed to schedule the Trakt token refresh.", "error", err)
 	}
 
 	// Start the scheduler
@@ -82,66 +120,154 @@ func Setup(gctx global.Context, helpers helpers.Helpers, notifications *notifica
 	return svc
 }
 
-// scheduleMovieJob schedules a movie list job using a cron expression
+// ReloadMovieJobs brings the movie list jobs in line with the movie settings.
+// Jobs whose cron expression changed are rescheduled and jobs without one are removed.
+func (s *Scheduler) ReloadMovieJobs(settings db.MovieSettings) {
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
+
+	s.reloadJob(settings.CronAnticipated, s.AnticipatedJobFunc, "movie-anticipated", true)
+	s.reloadJob(settings.CronBoxOffice, s.BoxOfficeJobFunc, "movie-box_office", true)
+	s.reloadJob(settings.CronPopular, s.PopularJobFunc, "movie-popular", true)
+	s.reloadJob(settings.CronTrending, s.TrendingJobFunc, "movie-trending", true)
+	s.reloadJob(settings.CronWatched, s.WatchedJobFunc, "movie-watched", true)
+	s.reloadJob(settings.CronPlayed, s.PlayedJobFunc, "movie-played", true)
+	s.reloadJob(settings.CronCollected, s.CollectedJobFunc, "movie-collected", true)
+}
+
+// ReloadShowJobs brings the show list jobs in line with the show settings.
+// Jobs whose cron expression changed are rescheduled and jobs without one are removed.
+func (s *Scheduler) ReloadShowJobs(settings db.ShowSettings) {
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
+
+	s.reloadJob(settings.CronJobAnticipated, s.AnticipatedShowJobFunc, "show-anticipated", false)
+	s.reloadJob(settings.CronJobPopular, s.PopularShowJobFunc, "show-popular", false)
+	s.reloadJob(settings.CronJobTrending, s.TrendingShowJobFunc, "show-trending", false)
+	s.reloadJob(settings.CronJobWatched, s.WatchedShowJobFunc, "show-watched", false)
+	s.reloadJob(settings.CronJobPlayed, s.PlayedShowJobFunc, "show-played", false)
+	s.reloadJob(settings.CronJobCollected, s.CollectedShowJobFunc, "show-collected", false)
+}
+
+// reloadJob schedules, reschedules or removes a single list job. The caller must hold jobsMu.
+func (s *Scheduler) reloadJob(cronExpr sql.NullString, jobFunc func(), listType string, isMovie bool) {
+	if !cronExpr.Valid || strings.TrimSpace(cronExpr.String) == "" {
+		s.stopJob(listType, isMovie)
+		return
+	}
+
+	// Leave the job alone if its schedule didn't change
+	if spec, exists := s.jobSpecs[listType]; exists && spec == cronExpr.String {
+		return
+	}
+
+	if isMovie {
+		s.scheduleMovieJob(cronExpr.String, jobFunc, listType)
+	} else {
+		s.scheduleShowJob(cronExpr.String, jobFunc, listType)
+	}
+}
+
+// scheduleMovieJob schedules a movie list job using a cron expression. The caller must hold jobsMu.
 func (s *Scheduler) scheduleMovieJob(cronExpr string, jobFunc func(), listType string) {
 	// If a job is already scheduled, stop it first
 	if jobID, exists := s.movieJobIDs[listType]; exists {
 		s.cron.Remove(jobID)
+		delete(s.movieJobIDs, listType)
+		delete(s.jobSpecs, listType)
 		log.Infof("[Scheduler] Existing %s movie job stopped.", listType)
 	}
 
 	// Schedule the new job
 	jobID, err := s.cron.AddFunc(cronExpr, jobFunc)
 	if err != nil {
-		log.Error("[Scheduler] Could not schedule %s movie job. Please check cron expression %s and verify your settings.", listType, cronExpr, "error", err)
+		log.Errorf("[Scheduler] Could not schedule %s movie job. Please check cron expression %s and verify your settings. %v", listType, cronExpr, err)
 		return
 	}
 
 	s.movieJobIDs[listType] = jobID
+	s.jobSpecs[listType] = cronExpr
 	log.Infof("[Scheduler] Successfully scheduled %s movie job with cron expression: %s.", listType, cronExpr)
-
-	// Run the job immediately once after scheduling
-	s.RunJobOnDemand(listType, true)
 }
 
-// scheduleShowJob schedules a show list job using a cron expression
+// scheduleShowJob schedules a show list job using a cron expression. The caller must hold jobsMu.
 func (s *Scheduler) scheduleShowJob(cronExpr string, jobFunc func(), listType string) {
 	// If a job is already scheduled, stop it first
 	if jobID, exists := s.showJobIDs[listType]; exists {
 		s.cron.Remove(jobID)
+		delete(s.showJobIDs, listType)
+		delete(s.jobSpecs, listType)
 		log.Infof("[Scheduler] Existing %s show job stopped.", listType)
 	}
 
 	// Schedule the new job
 	jobID, err := s.cron.AddFunc(cronExpr, jobFunc)
 	if err != nil {
-		log.Error("[Scheduler] Could not schedule %s show job. Please check cron expression %s and verify your settings.", listType, cronExpr, "error", err)
+		log.Errorf("[Scheduler] Could not schedule %s show job. Please check cron expression %s and verify your settings. %v", listType, cronExpr, err)
 		return
 	}
 
 	s.showJobIDs[listType] = jobID
+	s.jobSpecs[listType] = cronExpr
 	log.Infof("[Scheduler] Successfully scheduled %s show job with cron expression: %s.", listType, cronExpr)
+}
 
-	// Run the job immediately once after scheduling
-	s.RunJobOnDemand(listType, false)
+// reloadTaskJob schedules, reschedules or removes a job that doesn't request titles. The caller must hold jobsMu.
+func (s *Scheduler) reloadTaskJob(cronExpr sql.NullString, jobFunc func(), jobType string) {
+	spec := strings.TrimSpace(cronExpr.String)
+	if current, exists := s.jobSpecs[jobType]; exists && current == spec {
+		return
+	}
+
+	if jobID, exists := s.taskJobIDs[jobType]; exists {
+		s.cron.Remove(jobID)
+		delete(s.taskJobIDs, jobType)
+		delete(s.jobSpecs, jobType)
+		log.Infof("[Scheduler] Existing %s job stopped.", jobType)
+	}
+
+	if !cronExpr.Valid || spec == "" {
+		return
+	}
+
+	jobID, err := s.cron.AddFunc(spec, jobFunc)
+	if err != nil {
+		log.Errorf("[Scheduler] Could not schedule %s job. Please check cron expression %s and verify your settings. %v", jobType, spec, err)
+		return
+	}
+
+	s.taskJobIDs[jobType] = jobID
+	s.jobSpecs[jobType] = spec
+	log.Infof("[Scheduler] Successfully scheduled %s job with cron expression: %s.", jobType, spec)
 }
 
 // StopJob stops a specific movie job by listType
 func (s *Scheduler) StopJob(listType string, isMovie bool) {
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
+
+	if _, exists := s.jobSpecs[listType]; !exists {
+		log.Warnf("[Scheduler] No %s job found to stop.", listType)
+		return
+	}
+
+	s.stopJob(listType, isMovie)
+}
+
+// stopJob removes a job from the cron schedule. The caller must hold jobsMu.
+func (s *Scheduler) stopJob(listType string, isMovie bool) {
+	jobIDs := s.showJobIDs
+	kind := "show"
 	if isMovie {
-		if jobID, exists := s.movieJobIDs[listType]; exists {
-			s.cron.Remove(jobID)
-			log.Infof("[Scheduler] Successfully stopped %s movie job.", listType)
-		} else {
-			log.Warnf("[Scheduler] No %s movie job found to stop.", listType)
-		}
-	} else {
-		if jobID, exists := s.showJobIDs[listType]; exists {
-			s.cron.Remove(jobID)
-			log.Infof("[Scheduler] Successfully stopped %s show job.", listType)
-		} else {
-			log.Warnf("[Scheduler] No %s show job found to stop.", listType)
-		}
+		jobIDs = s.movieJobIDs
+		kind = "movie"
+	}
+
+	if jobID, exists := jobIDs[listType]; exists {
+		s.cron.Remove(jobID)
+		delete(jobIDs, listType)
+		delete(s.jobSpecs, listType)
+		log.Infof("[Scheduler] Successfully stopped %s %s job.", listType, kind)
 	}
 }
 
@@ -155,6 +281,9 @@ type JobStatus struct {
 
 // GetJobStatus returns the status of the movie and show jobs
 func (s *Scheduler) GetJobStatus() []JobStatus {
+	s.jobsMu.Lock()
+	defer s.jobsMu.Unlock()
+
 	var statuses []JobStatus
 
 	// Movie Job Statuses
@@ -179,24 +308,44 @@ func (s *Scheduler) GetJobStatus() []JobStatus {
 		})
 	}
 
+	// Task Job Statuses
+	for jobType, jobID := range s.taskJobIDs {
+		entry := s.cron.Entry(jobID)
+		statuses = append(statuses, JobStatus{
+			JobID:   fmt.Sprintf("%d", jobID),
+			JobType: jobType,
+			LastRun: entry.Prev,
+			NextRun: entry.Next,
+		})
+	}
+
 	return statuses
 }
 
 // RunJobOnDemand runs a specific job immediately without affecting the cron schedule
 func (s *Scheduler) RunJobOnDemand(listType string, isMovie bool) error {
+	// Look the job up under the lock, but don't hold it while the job runs
+	s.jobsMu.Lock()
+	var job cron.Job
 	if isMovie {
 		if jobID, exists := s.movieJobIDs[listType]; exists {
-			log.Infof("[Scheduler] Manually triggered %s movie job.", listType)
-			s.cron.Entry(jobID).Job.Run()
-			return nil
+			job = s.cron.Entry(jobID).Job
 		}
-		return fmt.Errorf("[Scheduler] No movie job found for %s", listType)
 	} else {
 		if jobID, exists := s.showJobIDs[listType]; exists {
-			log.Infof("[Scheduler] Manually triggered %s show job.", listType)
-			s.cron.Entry(jobID).Job.Run()
-			return nil
+			job = s.cron.Entry(jobID).Job
+		}
+	}
+	s.jobsMu.Unlock()
+
+	if job == nil {
+		if isMovie {
+			return fmt.Errorf("[Scheduler] No movie job found for %s", listType)
 		}
 		return fmt.Errorf("[Scheduler] No show job found for %s", listType)
 	}
+
+	log.Infof("[Scheduler] Manually triggered %s job.", listType)
+	job.Run()
+	return nil
 }
@@ -0,0 +1,290 @@
+package scheduler
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/google/uuid"
+	"github.com/mahcks/blockbusterr/internal/db"
+	"github.com/mahcks/blockbusterr/internal/helpers/trakt"
+	"github.com/mahcks/blockbusterr/internal/websocket"
+	"github.com/mahcks/blockbusterr/pkg/structures"
+	"github.com/mahcks/blockbusterr/pkg/utils"
+)
+
+var ErrJobAlreadyRunning = errors.New("job is already running")
+
+// jobRun identifies a single execution of a list job and counts what happened to its candidates
+type jobRun struct {
+	ID        string    // Unique ID shared by every history entry recorded during the run
+	Source    string    // The list the candidates came from (e.g. movie-trending)
+	IsMovie   bool      // Whether the run requests movies rather than shows
+	StartedAt time.Time // Time the run started
+
+	added   atomic.Int32
+	skipped atomic.Int32
+	failed  atomic.Int32
+	owned   atomic.Int32
+	err     error // Why the run stopped early, only set by the goroutine running the job
+
+	hub *websocket.Hub // Receives live updates about the run, may be nil
+}
+
+func newJobRun(source string, hub *websocket.Hub) *jobRun {
+	return &jobRun{
+		ID:        uuid.NewString(),
+		Source:    source,
+		StartedAt: time.Now(),
+		hub:       hub,
+	}
+}
+
+// fail marks the run as stopped early
+func (r *jobRun) fail(err error) {
+	r.err = err
+}
+
+// track counts what happened to a title and publishes it on job.item
+func (r *jobRun) track(item structures.JobItemPayload) {
+	switch item.Outcome {
+	case structures.RequestOutcomeAdded:
+		r.added.Add(1)
+	case structures.RequestOutcomeFailed:
+		r.failed.Add(1)
+	case structures.RequestOutcomeOwned:
+		r.owned.Add(1)
+	default:
+		r.skipped.Add(1)
+	}
+
+	item.RunID = r.ID
+	item.JobType = r.Source
+	r.publish(structures.TopicJobItem, item)
+}
+
+// publish dispatches data about the run to the websocket clients subscribed to the topic
+func (r *jobRun) publish(topic structures.Topic, data interface{}) {
+	if r.hub == nil {
+		return
+	}
+
+	r.hub.Dispatch(topic, data)
+}
+
+func movieItem(movie trakt.Movie, outcome structures.RequestOutcome, reason string) structures.JobItemPayload {
+	return structures.JobItemPayload{
+		MediaType: "MOVIE",
+		Title:     movie.Title,
+		Year:      movie.Year,
+		TMDBID:    movie.IDs.TMDB,
+		Outcome:   outcome,
+		Reason:    reason,
+	}
+}
+
+func showItem(show trakt.Show, outcome structures.RequestOutcome, reason string) structures.JobItemPayload {
+	return structures.JobItemPayload{
+		MediaType: "SHOW",
+		Title:     show.Title,
+		Year:      show.Year,
+		TMDBID:    show.IDs.TMDB,
+		TVDBID:    show.IDs.TVDB,
+		Outcome:   outcome,
+		Reason:    reason,
+	}
+}
+
+// snapshot returns the current state of a run that is still in progress
+func (r *jobRun) snapshot() structures.JobRun {
+	return structures.JobRun{
+		ID:        r.ID,
+		JobType:   r.Source,
+		Status:    structures.JobRunStatusRunning,
+		Added:     int(r.added.Load()),
+		Skipped:   int(r.skipped.Load()),
+		Failed:    int(r.failed.Load()),
+		Owned:     int(r.owned.Load()),
+		StartedAt: r.StartedAt,
+	}
+}
+
+// runTracker keeps track of the runs in progress so the same job never runs twice at once
+type runTracker struct {
+	mu     sync.Mutex
+	active map[string]*jobRun // Keyed by list type
+}
+
+func newRunTracker() *runTracker {
+	return &runTracker{
+		active: make(map[string]*jobRun),
+	}
+}
+
+func (t *runTracker) start(run *jobRun) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, exists := t.active[run.Source]; exists {
+		return fmt.Errorf("%w: %s", ErrJobAlreadyRunning, run.Source)
+	}
+
+	t.active[run.Source] = run
+	return nil
+}
+
+func (t *runTracker) finish(run *jobRun) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.active, run.Source)
+}
+
+func (t *runTracker) get(id string) (*jobRun, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, run := range t.active {
+		if run.ID == id {
+			return run, true
+		}
+	}
+
+	return nil, false
+}
+
+func isMovieJob(listType string) bool {
+	_, exists := movieJobNames[listType]
+	return exists
+}
+
+func isShowJob(listType string) bool {
+	_, exists := showJobNames[listType]
+	return exists
+}
+
+// beginRun registers a new run of a list job, failing if the job is already running
+func (s Scheduler) beginRun(listType string) (*jobRun, error) {
+	mediaType, err := s.jobMediaType(listType)
+	if err != nil {
+		return nil, err
+	}
+
+	run := newJobRun(listType, s.hub)
+	run.IsMovie = mediaType == structures.ListSourceMediaTypeMovie
+	if err := s.runs.start(run); err != nil {
+		return nil, err
+	}
+
+	err = s.gctx.Crate().SQL.Queries().InsertJobRun(s.gctx, db.JobRun{
+		ID:        run.ID,
+		JobType:   run.Source,
+		Status:    structures.JobRunStatusRunning.String(),
+		StartedAt: run.StartedAt,
+	})
+	if err != nil {
+		log.Errorf("[Scheduler] Failed to record the start of %s job run: %v", listType, err)
+	}
+
+	run.publish(structures.TopicJobStarted, structures.JobStartedPayload{
+		RunID:     run.ID,
+		JobType:   run.Source,
+		StartedAt: run.StartedAt,
+	})
+
+	return run, nil
+}
+
+// executeRun runs the job for a registered run and stores how it ended
+func (s Scheduler) executeRun(run *jobRun) {
+	defer s.runs.finish(run)
+
+	if run.IsMovie {
+		s.runMovieJob(run)
+	} else {
+		s.runShowJob(run)
+	}
+
+	result := db.JobRun{
+		ID:         run.ID,
+		Status:     structures.JobRunStatusCompleted.String(),
+		Added:      int(run.added.Load()),
+		Skipped:    int(run.skipped.Load()),
+		Failed:     int(run.failed.Load()),
+		Owned:      int(run.owned.Load()),
+		FinishedAt: sql.NullTime{Time: time.Now(), Valid: true},
+	}
+
+	if run.err != nil {
+		result.Status = structures.JobRunStatusFailed.String()
+		result.Error = utils.StringToNullString(run.err.Error())
+	}
+
+	if err := s.gctx.Crate().SQL.Queries().FinishJobRun(s.gctx, result); err != nil {
+		log.Errorf("[Scheduler] Failed to record the end of %s job run: %v", run.Source, err)
+	}
+
+	summary := run.snapshot()
+	summary.Status = structures.JobRunStatus(result.Status)
+	summary.Error = utils.NullStringToPointer(result.Error)
+	summary.FinishedAt = &result.FinishedAt.Time
+	run.publish(structures.TopicJobRan, summary)
+}
+
+// runScheduledJob runs a list job in the foreground, skipping it if the job is already running
+func (s Scheduler) runScheduledJob(listType string) {
+	run, err := s.beginRun(listType)
+	if err != nil {
+		log.Warnf("[Scheduler] Skipping %s job. %v", listType, err)
+		return
+	}
+
+	s.executeRun(run)
+}
+
+// StartJob starts a list job in the background and returns the ID of the run
+func (s *Scheduler) StartJob(listType string) (string, error) {
+	run, err := s.beginRun(listType)
+	if err != nil {
+		return "", err
+	}
+
+	log.Infof("[Scheduler] Manually triggered %s job.", listType)
+	go s.executeRun(run)
+
+	return run.ID, nil
+}
+
+// GetJobRun returns a run by ID, with live counts for runs that are still in progress
+func (s *Scheduler) GetJobRun(id string) (structures.JobRun, error) {
+	if run, exists := s.runs.get(id); exists {
+		return run.snapshot(), nil
+	}
+
+	run, err := s.gctx.Crate().SQL.Queries().GetJobRunByID(s.gctx, id)
+	if err != nil {
+		return structures.JobRun{}, err
+	}
+
+	response := structures.JobRun{
+		ID:        run.ID,
+		JobType:   run.JobType,
+		Status:    structures.JobRunStatus(run.Status),
+		Added:     run.Added,
+		Skipped:   run.Skipped,
+		Failed:    run.Failed,
+		Owned:     run.Owned,
+		Error:     utils.NullStringToPointer(run.Error),
+		StartedAt: run.StartedAt,
+	}
+
+	if run.FinishedAt.Valid {
+		response.FinishedAt = &run.FinishedAt.Time
+	}
+
+	return response, nil
+}
@@ -2,6 +2,7 @@ package scheduler
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -20,141 +21,79 @@ import (
 )
 
 type sonarrJob struct {
-	ombiSettings   db.OmbiSettings
-	sonarrSettings db.SonarrSettings
-	showSettings   db.ShowSettings
+	ombiSettings    db.OmbiSettings
+	sonarrInstances []db.SonarrSettings // Sonarr instances the job sends to
+	showSettings    db.ShowSettings
+	ownedTVDBIDs    map[int]bool // TVDB IDs already in every targeted Sonarr library, fetched once per run
 
-	anticipatedShows []trakt.Show
-	popularShows     []trakt.Show
-	trendingShows    []trakt.Show
+	run *jobRun
+}
+
+// showJobNames maps each show list type to the name used in logs
+var showJobNames = map[string]string{
+	"show-anticipated": "Anticipated",
+	"show-popular":     "Popular",
+	"show-trending":    "Trending",
+	"show-watched":     "Most Watched",
+	"show-played":      "Most Played",
+	"show-collected":   "Most Collected",
 }
 
 // AnticipatedShowJobFunc handles fetching and processing anticipated shows
 func (s Scheduler) AnticipatedShowJobFunc() {
-	err := s.helpers.Trakt.Ping(context.Background())
-	if err != nil {
-		log.Warn("[scheduler] Skipping antcipated show job because of missing Trakt client ID.")
-		return
-	}
-
-	log.Info("[scheduler] Running anticipated shows job...")
-
-	startTime := time.Now()
-	sj := sonarrJob{}
-	gctx := s.gctx
-
-	// Get Ombi enabled setting
-	currentMode, err := gctx.Crate().SQL.Queries().GetSettingByKey(gctx, structures.SettingMode.String())
-	if err != nil {
-		log.Error("[show-job] Error getting Ombi enabled setting", "error", err)
-		return
-	}
-
-	var ombiEnabled string
-	if currentMode.Value.String == "ombi" {
-		ombiEnabled = "true"
-	} else {
-		ombiEnabled = "false"
-	}
-
-	// Get Sonarr and Show settings
-	sj.sonarrSettings, sj.showSettings, err = getSonarrAndShowSettings(gctx)
-	if err != nil {
-		return
-	}
-
-	// Fetch Anticipated Shows
-	if sj.showSettings.Anticipated.Valid && sj.showSettings.Anticipated.Int32 > 0 {
-		params := buildTraktParamsFromShowSettings(sj.showSettings, 1000, true)
-		anticipatedShows, err := s.helpers.Trakt.GetAnticipatedShows(gctx, params)
-		if err != nil {
-			if errors.Is(err, trakt.ErrNoTraktSettings) {
-				log.Warn("[show-job] Couldn't complete the job because Trakt client ID isn't set!")
-			} else {
-				log.Error("[show-job] Error fetching anticipated shows from Trakt", "error", err)
-			}
-		} else {
-			sj.anticipatedShows = filterAndLimitShows(extractShowsFromAnticipated(anticipatedShows), sj.showSettings, int(sj.showSettings.Anticipated.Int32))
-		}
-	}
-
-	// Process Ombi or Sonarr
-	processShows(s, s.helpers, sj.anticipatedShows, sj.sonarrSettings, sj.ombiSettings, ombiEnabled, "Anticipated")
-
-	log.Infof("[scheduler] Completed anticipated shows job in %.2f seconds!", time.Since(startTime).Seconds())
+	s.runScheduledJob("show-anticipated")
 }
 
 // PopularShowJobFunc handles fetching and processing popular shows
 func (s Scheduler) PopularShowJobFunc() {
-	err := s.helpers.Trakt.Ping(context.Background())
-	if err != nil {
-		log.Warn("[scheduler] Skipping popular show job because of missing Trakt client ID.")
-		return
-	}
-
-	log.Info("[scheduler] Running popular shows job...")
+	s.runScheduledJob("show-popular")
+}
 
-	startTime := time.Now()
-	sj := sonarrJob{}
+// TrendingShowJobFunc handles fetching and processing trending shows
+func (s Scheduler) TrendingShowJobFunc() {
+	s.runScheduledJob("show-trending")
+}
 
-	// Get Ombi enabled setting
-	currentMode, err := s.gctx.Crate().SQL.Queries().GetSettingByKey(s.gctx, structures.SettingMode.String())
-	if err != nil {
-		log.Error("[show-job] Error getting Ombi enabled setting", "error", err)
-		return
-	}
+// WatchedShowJobFunc handles fetching and processing the most watched shows
+func (s Scheduler) WatchedShowJobFunc() {
+	s.runScheduledJob("show-watched")
+}
 
-	var ombiEnabled string
-	if currentMode.Value.String == "ombi" {
-		ombiEnabled = "true"
-	} else {
-		ombiEnabled = "false"
-	}
+// PlayedShowJobFunc handles fetching and processing the most played shows
+func (s Scheduler) PlayedShowJobFunc() {
+	s.runScheduledJob("show-played")
+}
 
-	// Get Sonarr and Show settings
-	sj.sonarrSettings, sj.showSettings, err = getSonarrAndShowSettings(s.gctx)
-	if err != nil {
-		return
-	}
+// CollectedShowJobFunc handles fetching and processing the most collected shows
+func (s Scheduler) CollectedShowJobFunc() {
+	s.runScheduledJob("show-collected")
+}
 
-	// Fetch Popular Shows
-	if sj.showSettings.Popular.Valid && sj.showSettings.Popular.Int32 > 0 {
-		params := buildTraktParamsFromShowSettings(sj.showSettings, 1000, false)
-		popularShows, err := s.helpers.Trakt.GetPopularShows(s.gctx, params)
-		if err != nil {
-			if errors.Is(err, trakt.ErrNoTraktSettings) {
-				log.Warn("[show-job] Couldn't complete the job because Trakt client ID isn't set!")
-			} else {
-				log.Error("[show-job] Error fetching popular shows from Trakt", "error", err)
-			}
-		} else {
-			sj.popularShows = filterAndLimitShows(extractShowsFromPopular(popularShows), sj.showSettings, int(sj.showSettings.Popular.Int32))
-		}
+// runShowJob fetches the shows for a list from Trakt, filters them and requests them
+func (s Scheduler) runShowJob(run *jobRun) {
+	listType := run.Source
+	jobName, exists := showJobNames[listType]
+	if !exists {
+		jobName = listType
 	}
 
-	// Process Ombi or Sonarr
-	processShows(s, s.helpers, sj.popularShows, sj.sonarrSettings, sj.ombiSettings, ombiEnabled, "Popular")
-
-	log.Infof("[scheduler] Completed popular shows job in %.2f seconds!", time.Since(startTime).Seconds())
-}
-
-// TrendingShowJobFunc handles fetching and processing trending shows
-func (s Scheduler) TrendingShowJobFunc() {
 	err := s.helpers.Trakt.Ping(context.Background())
 	if err != nil {
-		log.Warn("[scheduler] Skipping trending show job because of missing Trakt client ID.")
+		run.fail(fmt.Errorf("trakt credentials are missing: %w", err))
+		log.Warnf("[scheduler] Skipping %s show job because of missing Trakt client ID.", strings.ToLower(jobName))
 		return
 	}
 
-	log.Info("[scheduler] Running trending shows job...")
+	log.Infof("[scheduler] Running %s shows job...", strings.ToLower(jobName))
 
 	startTime := time.Now()
-	sj := sonarrJob{}
+	sj := sonarrJob{run: run}
+	gctx := s.gctx
 
 	// Get Ombi enabled setting
-	// Get Ombi enabled setting
-	currentMode, err := s.gctx.Crate().SQL.Queries().GetSettingByKey(s.gctx, structures.SettingMode.String())
+	currentMode, err := gctx.Crate().SQL.Queries().GetSettingByKey(gctx, structures.SettingMode.String())
 	if err != nil {
+		run.fail(err)
 		log.Error("[show-job] Error getting Ombi enabled setting", "error", err)
 		return
 	}
@@ -167,51 +106,138 @@ func (s Scheduler) TrendingShowJobFunc() {
 	}
 
 	// Get Sonarr and Show settings
-	sj.sonarrSettings, sj.showSettings, err = getSonarrAndShowSettings(s.gctx)
+	sj.sonarrInstances, sj.showSettings, err = getSonarrAndShowSettings(gctx, listType)
 	if err != nil {
+		run.fail(err)
 		if errors.Is(err, db.ErrNoShowSettings) {
 			log.Warn("[show-job] Skipping Sonarr job because of missing Show settings.")
 		}
 		return
 	}
 
-	// Fetch Trending Shows
-	if sj.showSettings.Trending.Valid && sj.showSettings.Trending.Int32 > 0 {
-		params := buildTraktParamsFromShowSettings(sj.showSettings, 1000, false)
-		trendingShows, err := s.helpers.Trakt.GetTrendingShows(s.gctx, params)
-		if err != nil {
-			if errors.Is(err, trakt.ErrNoTraktSettings) {
-				log.Warn("[show-job] Couldn't complete the job because Trakt client ID isn't set!")
-			} else {
-				log.Error("[show-job] Error fetching trending shows from Trakt", "error", err)
-			}
+	// Fetch the shows from Trakt
+	var shows []trakt.Show
+	candidates, limit, err := s.fetchShowCandidates(listType, sj.showSettings)
+	if err != nil {
+		run.fail(err)
+		if errors.Is(err, trakt.ErrNoTraktSettings) {
+			log.Warn("[show-job] Couldn't complete the job because Trakt client ID isn't set!")
 		} else {
-			sj.trendingShows = filterAndLimitShows(extractShowsFromTrending(trendingShows), sj.showSettings, int(sj.showSettings.Trending.Int32))
+			log.Errorf("[show-job] Error fetching %s shows from Trakt: %v", strings.ToLower(jobName), err)
+		}
+	} else if limit > 0 {
+		sj.ownedTVDBIDs, err = s.fetchOwnedShowIDs(sj.sonarrInstances)
+		if err != nil {
+			log.Warnf("[show-job] Could not fetch the Sonarr library, owned shows will not be skipped: %v", err)
+		}
+
+		filter := newShowFilter(sj.showSettings, sj.ownedTVDBIDs)
+		filter.bounds = s.listSourceBounds(listType)
+		filter.ratings = newRatingFilter(s.gctx, s.helpers.OMDb, showRatingThresholds(sj.showSettings))
+		filter.library = s.loadMediaServerLibrary("SHOW")
+		filter.watched, err = s.fetchWatchedShowIDs()
+		if err != nil {
+			log.Warnf("[show-job] Could not fetch the Trakt watch history, watched shows will not be skipped: %v", err)
+		}
+		filter.excluded, err = s.syncShowExclusions(sj.sonarrInstances, sj.showSettings.BlacklistedTVDBIDs, !isDryRun(s.gctx))
+		if err != nil {
+			log.Warnf("[show-job] Could not fetch the Sonarr import list exclusions, excluded shows will not be skipped: %v", err)
+		}
+		var skipped []showCandidate
+		shows, skipped = filterAndLimitShows(candidates, filter, limit)
+		backend := structures.RequestBackendSonarr
+		if ombiEnabled == "true" {
+			backend = structures.RequestBackendOmbi
+		}
+		for _, candidate := range skipped {
+			recordSkippedShow(s.gctx, run, candidate, backend)
 		}
 	}
 
+	// The monitor strategy of the list takes precedence over the one in the show settings
+	addOptions := newSonarrAddOptions(sj.showSettings)
+	monitor, err := gctx.Crate().SQL.Queries().GetJobMonitor(gctx, listType)
+	if err != nil {
+		log.Warnf("[show-job] Could not fetch the monitor strategy of %s, using the show settings: %v", listType, err)
+	} else if monitor != "" {
+		addOptions.monitor = structures.SonarrMonitor(monitor)
+	}
+	addOptions.tags = s.jobTagLabels(listType)
+
 	// Process Ombi or Sonarr
-	processShows(s, s.helpers, sj.trendingShows, sj.sonarrSettings, sj.ombiSettings, ombiEnabled, "Trending")
+	processShows(s, s.helpers, shows, sj.sonarrInstances, addOptions, sj.ombiSettings, ombiEnabled, jobName, sj.run)
 
-	log.Infof("[scheduler] Completed trending shows job in %.2f seconds!", time.Since(startTime).Seconds())
+	log.Infof("[scheduler] Completed %s shows job in %.2f seconds!", strings.ToLower(jobName), time.Since(startTime).Seconds())
 }
 
-// Helper function to get Sonarr and Show settings
-func getSonarrAndShowSettings(gctx global.Context) (db.SonarrSettings, db.ShowSettings, error) {
+// fetchShowCandidates fetches the shows for a list from Trakt along with the number of shows to request.
+// Nothing is fetched when the list is disabled in the show settings.
+func (s Scheduler) fetchShowCandidates(listType string, settings db.ShowSettings) ([]trakt.Show, int, error) {
+	// List sources have their own limit
+	if _, ok := parseListSourceJobType(listType); ok {
+		return s.fetchListSourceShows(listType)
+	}
+
+	var limitSetting sql.NullInt32
+	switch listType {
+	case "show-anticipated":
+		limitSetting = settings.Anticipated
+	case "show-popular":
+		limitSetting = settings.Popular
+	case "show-trending":
+		limitSetting = settings.Trending
+	case "show-watched":
+		limitSetting = settings.Watched
+	case "show-played":
+		limitSetting = settings.Played
+	case "show-collected":
+		limitSetting = settings.Collected
+	default:
+		return nil, 0, ErrUnknownJobType
+	}
+
+	if !limitSetting.Valid || limitSetting.Int32 <= 0 {
+		return nil, 0, nil
+	}
+	limit := int(limitSetting.Int32)
+
+	params := buildTraktParamsFromShowSettings(settings, 1000, listType == "show-anticipated")
+
+	switch listType {
+	case "show-anticipated":
+		anticipatedShows, err := s.helpers.Trakt.GetAnticipatedShows(s.gctx, params)
+		return extractShowsFromAnticipated(anticipatedShows), limit, err
+	case "show-popular":
+		popularShows, err := s.helpers.Trakt.GetPopularShows(s.gctx, params)
+		return extractShowsFromPopular(popularShows), limit, err
+	case "show-watched":
+		params.Period = settings.WatchedPeriod
+		watchedShows, err := s.helpers.Trakt.GetMostWatchedShows(s.gctx, params)
+		return extractShowsFromMostWatched(watchedShows), limit, err
+	case "show-played":
+		params.Period = settings.PlayedPeriod
+		playedShows, err := s.helpers.Trakt.GetMostPlayedShows(s.gctx, params)
+		return extractShowsFromMostWatched(playedShows), limit, err
+	case "show-collected":
+		params.Period = settings.CollectedPeriod
+		collectedShows, err := s.helpers.Trakt.GetMostCollectedShows(s.gctx, params)
+		return extractShowsFromMostWatched(collectedShows), limit, err
+	default:
+		trendingShows, err := s.helpers.Trakt.GetTrendingShows(s.gctx, params)
+		return extractShowsFromTrending(trendingShows), limit, err
+	}
+}
+
+// Helper function to get the Sonarr instances a job sends to and the Show settings
+func getSonarrAndShowSettings(gctx global.Context, listType string) ([]db.SonarrSettings, db.ShowSettings, error) {
 	sj := sonarrJob{}
 	var err error
 
-	// Get all settings from Sonarr table
-	sj.sonarrSettings, err = gctx.Crate().SQL.Queries().GetSonarrSettings(gctx)
+	// Get the Sonarr instances the job sends to
+	sj.sonarrInstances, err = gctx.Crate().SQL.Queries().GetSonarrInstancesForJob(gctx, listType)
 	if err != nil {
-		if errors.Is(err, db.ErrNoSonarrSettings) {
-			log.Warn("[sonarr-job] Skipping Sonarr job because of missing Sonarr settings.")
-			return sj.sonarrSettings, sj.showSettings, nil
-		} else {
-			log.Errorf("[sonarr-job] Error getting Sonarr settings: %v", err)
-
-			return sj.sonarrSettings, sj.showSettings, err
-		}
+		log.Errorf("[sonarr-job] Error getting Sonarr instances: %v", err)
+		return sj.sonarrInstances, sj.showSettings, err
 	}
 
 	// Get all the settings for shows
@@ -219,24 +245,41 @@ func getSonarrAndShowSettings(gctx global.Context) (db.SonarrSettings, db.ShowSe
 	if err != nil {
 		if errors.Is(err, db.ErrNoShowSettings) {
 			log.Warn("[sonarr-job] Skipping Sonarr job because of missing Show settings.")
-			return sj.sonarrSettings, sj.showSettings, nil
+			return sj.sonarrInstances, sj.showSettings, nil
 		} else {
 			log.Error("[sonarr-job] Error getting show settings", "error", err)
-			return sj.sonarrSettings, sj.showSettings, err
+			return sj.sonarrInstances, sj.showSettings, err
 		}
 	}
 
-	return sj.sonarrSettings, sj.showSettings, nil
+	return sj.sonarrInstances, sj.showSettings, nil
 }
 
 // Helper function to process shows (Ombi or Sonarr)
-func processShows(s Scheduler, helpers helpers.Helpers, shows []trakt.Show, sonarrSettings db.SonarrSettings, ombiSettings db.OmbiSettings, ombiEnabled string, jobType string) {
-	if ombiEnabled == "true" {
+func processShows(s Scheduler, helpers helpers.Helpers, shows []trakt.Show, sonarrInstances []db.SonarrSettings, addOptions sonarrAddOptions, ombiSettings db.OmbiSettings, ombiEnabled string, jobType string, run *jobRun) {
+	if isDryRun(s.gctx) {
+		// In dry-run mode only report what would have been requested
+		backend := structures.RequestBackendSonarr
+		if ombiEnabled == "true" {
+			backend = structures.RequestBackendOmbi
+		}
+
+		for _, show := range shows {
+			log.Infof("[scheduler] Dry run: would request show '%s' via %s.", show.Title, backend)
+			recordShowHistory(s.gctx, run, show, backend, structures.RequestOutcomeDryRun, nil)
+		}
+	} else if ombiEnabled == "true" {
 		// If Ombi is enabled, request shows via Ombi
-		requestShowsToOmbi(helpers.Ombi, s.notifications, shows, ombiSettings)
+		requestShowsToOmbi(s.gctx, helpers.Ombi, s.notifications, shows, ombiSettings, addOptions.monitor, run)
 	} else {
 		// Otherwise, request shows via Sonarr
-		requestShowsToSonarr(s.gctx, helpers, s.notifications, shows, sonarrSettings)
+		if len(sonarrInstances) == 0 {
+			run.fail(fmt.Errorf("no Sonarr instance is configured for %s", run.Source))
+			log.Warnf("[sonarr-job] Skipping Sonarr requests for %s. No Sonarr instance is configured.", run.Source)
+			return
+		}
+
+		requestShowsToSonarr(s.gctx, helpers, s.notifications, shows, sonarrInstances, addOptions, run)
 	}
 
 	log.Infof("[scheduler] %s shows processed. Total: %d", jobType, len(shows))
@@ -300,77 +343,131 @@ func buildTraktParamsFromShowSettings(settings db.ShowSettings, limit int, isAnt
 	return params
 }
 
-func filterAndLimitShows(shows []trakt.Show, settings db.ShowSettings, limit int) []trakt.Show {
-	// Apply additional filters like allowed countries, allowed languages, and blacklists
-	filteredShows := applyAdditionalFiltersToShows(shows, settings)
-
-	// Limit the number of shows to the specified limit
-	return getTopNShows(filteredShows, limit)
+// showCandidate is a show returned by Trakt along with the reason it was filtered out, if any
+type showCandidate struct {
+	Show      trakt.Show
+	Reason    string // Empty when the show will be requested
+	OverLimit bool   // Whether the show passed the filters but the list limit was already reached
+	Owned     bool   // Whether the show is already in the media server library
 }
 
-func applyAdditionalFiltersToShows(shows []trakt.Show, settings db.ShowSettings) []trakt.Show {
+// filterAndLimitShows returns the shows to request and the shows that were dropped by the filters,
+// not counting those over the limit
+func filterAndLimitShows(shows []trakt.Show, filter showFilter, limit int) ([]trakt.Show, []showCandidate) {
 	filteredShows := []trakt.Show{}
+	skipped := []showCandidate{}
+	for _, candidate := range evaluateShows(shows, filter, limit) {
+		if candidate.Reason == "" {
+			filteredShows = append(filteredShows, candidate.Show)
+		} else if !candidate.OverLimit {
+			skipped = append(skipped, candidate)
+		}
+	}
+	return filteredShows, skipped
+}
+
+// evaluateShows runs every show through the filters and the list limit, keeping the reason each dropped show was filtered out.
+// Shows that are filtered out do not count towards the limit.
+func evaluateShows(shows []trakt.Show, filter showFilter, limit int) []showCandidate {
+	candidates := make([]showCandidate, 0, len(shows))
+
+	included := 0
+	for _, show := range shows {
+		candidate := showCandidate{Show: show, Reason: filter.skipReason(show)}
+		candidate.Owned = candidate.Reason == mediaServerOwnedReason
+		if candidate.Reason == "" {
+			if included >= limit {
+				candidate.Reason = fmt.Sprintf("exceeds list limit of %d", limit)
+				candidate.OverLimit = true
+			} else if candidate.Reason = filter.ratings.omdbSkipReason(show.IDs.IMDB); candidate.Reason == "" {
+				// OMDb is only asked about candidates that would otherwise be requested
+				included++
+			}
+		}
+
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates
+}
+
+// showFilter holds the blacklists from the show settings and the shows that are already owned
+type showFilter struct {
+	genres   map[string]bool
+	keywords []keywordRule
+	tvdbIDs  map[int]bool
+	owned    map[int]bool
+	excluded map[int]bool       // TVDB IDs on the import list exclusions of a Sonarr instance
+	watched  map[int]bool       // TVDB IDs the authorized Trakt account already watched
+	bounds   listBounds         // Year and runtime limits of a list source
+	ratings  ratingFilter       // Rating, vote and certification thresholds
+	library  mediaServerLibrary // Titles already in the media server library
+}
+
+func newShowFilter(settings db.ShowSettings, ownedTVDBIDs map[int]bool) showFilter {
+	filter := showFilter{
+		genres:  map[string]bool{},
+		tvdbIDs: map[int]bool{},
+		owned:   ownedTVDBIDs,
+	}
 
 	// Build blacklisted genres, keywords, and TVDB IDs from settings
-	blacklistedGenres := map[string]bool{}
 	for _, genre := range settings.BlacklistedGenres {
-		blacklistedGenres[strings.ToLower(genre.Genre)] = true
+		filter.genres[strings.ToLower(genre.Genre)] = true
 	}
 
-	blacklistedKeywords := map[string]bool{}
 	for _, keyword := range settings.BlacklistedTitleKeywords {
-		blacklistedKeywords[strings.ToLower(keyword.Keyword)] = true
+		if rule, ok := compileStoredKeywordRule(keyword.Keyword, keyword.MatchMode); ok {
+			filter.keywords = append(filter.keywords, rule)
+		}
 	}
 
-	blacklistedTVDBIDs := map[int]bool{}
 	for _, tvdbID := range settings.BlacklistedTVDBIDs {
-		blacklistedTVDBIDs[tvdbID.TVDBID] = true
+		filter.tvdbIDs[tvdbID.TVDBID] = true
 	}
 
-	// Loop through the shows and filter them
-	for _, show := range shows {
-		// Check if the show is blacklisted by TVDB ID
-		if blacklistedTVDBIDs[show.IDs.TVDB] {
-			log.Infof("Skipping show '%s' due to blacklisted TVDB ID: %d", show.Title, show.IDs.TVDB)
-			continue // Skip this show and move to the next
-		}
-
-		// Check if the show is blacklisted by genre
-		blacklisted := false
-		for _, genre := range show.Genres {
-			if blacklistedGenres[strings.ToLower(genre)] {
-				blacklisted = true
-				break
-			}
-		}
-		if blacklisted {
-			continue // Skip this show and move to the next
-		}
+	return filter
+}
 
-		// Check if the show is blacklisted by title keywords
-		for keyword := range blacklistedKeywords {
-			if strings.Contains(strings.ToLower(show.Title), keyword) {
-				log.Infof("Skipping show '%s' due to blacklisted keyword: %s", show.Title, keyword)
-				blacklisted = true
-				break
-			}
-		}
-		if blacklisted {
-			continue // Skip this show and move to the next
-		}
+// skipReason returns why the show should not be requested, or an empty string if it passes every filter
+func (f showFilter) skipReason(show trakt.Show) string {
+	if f.owned[show.IDs.TVDB] {
+		return "already in the Sonarr library"
+	}
 
-		// If the show passed all filters, add it to the filtered list
-		filteredShows = append(filteredShows, show)
+	if f.library.ownsShow(show.IDs) {
+		return mediaServerOwnedReason
 	}
 
-	return filteredShows
-}
+	if f.watched[show.IDs.TVDB] {
+		return "already watched on Trakt"
+	}
+
+	if f.tvdbIDs[show.IDs.TVDB] {
+		return fmt.Sprintf("blacklisted TVDB ID %d", show.IDs.TVDB)
+	}
 
-func getTopNShows(shows []trakt.Show, limit int) []trakt.Show {
-	if len(shows) <= limit {
-		return shows
+	if f.excluded[show.IDs.TVDB] {
+		return "on the Sonarr import list exclusions"
 	}
-	return shows[:limit]
+
+	for _, genre := range show.Genres {
+		if f.genres[strings.ToLower(genre)] {
+			return fmt.Sprintf("blacklisted genre '%s'", genre)
+		}
+	}
+
+	for _, keyword := range f.keywords {
+		if keyword.matches(show.Title) {
+			return keyword.skipReason()
+		}
+	}
+
+	if reason := f.ratings.skipReason(show.Rating, show.Votes, show.Certification); reason != "" {
+		return reason
+	}
+
+	return f.bounds.skipReason(show.Year, show.Runtime)
 }
 
 // Extract Shows from TrendingShows
@@ -396,6 +493,15 @@ func extractShowsFromAnticipated(anticipatedShows []trakt.AnticipatedShow) []tra
 	return shows
 }
 
+// Extract Shows from the most watched, played and collected lists
+func extractShowsFromMostWatched(mostWatchedShows []trakt.TraktMostWatchedShow) []trakt.Show {
+	shows := []trakt.Show{}
+	for _, mostWatched := range mostWatchedShows {
+		shows = append(shows, mostWatched.Show)
+	}
+	return shows
+}
+
 func fetchSonarrSettings(r sonarr.Service, sonarrSettings db.SonarrSettings) (int, string, error) {
 	// Get quality profiles from Sonarr
 	qualityProfiles, err := r.GetQualityProfiles(nil, nil)
@@ -437,14 +543,19 @@ func fetchSonarrSettings(r sonarr.Service, sonarrSettings db.SonarrSettings) (in
 	return qualityProfileID, rootFolderPath, nil
 }
 
-func requestShowsToOmbi(o ombi.Service, notifications *notifications.NotificationManager, shows []trakt.Show, ombiSettings db.OmbiSettings) {
+func requestShowsToOmbi(gctx global.Context, o ombi.Service, notifications *notifications.NotificationManager, shows []trakt.Show, ombiSettings db.OmbiSettings, monitor structures.SonarrMonitor, run *jobRun) {
 	for _, show := range shows {
 		body := ombi.RequestShowBody{
 			TheMovieDBID: show.IDs.TMDB,
-			RequestAll:   true,
 			LanguageCode: "en",
 		}
 
+		if !ombiSeasons(monitor, &body) {
+			log.Infof(`[ombi-job] Skipping "%s" as the monitor strategy is none...`, show.Title)
+			recordShowHistory(gctx, run, show, structures.RequestBackendOmbi, structures.RequestOutcomeSkipped, fmt.Errorf("monitor strategy 'none' has no Ombi request"))
+			continue
+		}
+
 		// Set the request on behalf of a specific user if configured
 		if ombiSettings.UserID.Valid && ombiSettings.UserID.String != "" {
 			body.RequestOnBehalf = ombiSettings.UserID.String
@@ -467,11 +578,14 @@ func requestShowsToOmbi(o ombi.Service, notifications *notifications.Notificatio
 		if err != nil {
 			if errors.Is(err, ombi.ErrShowAlreadyRequested) {
 				log.Warnf(`[ombi-job] Skipping "%s" as it was already requested...`, show.Title)
+				recordShowHistory(gctx, run, show, structures.RequestBackendOmbi, structures.RequestOutcomeExists, nil)
 			} else {
 				log.Errorf("[ombi-job] Failed to request show %s via Ombi: %v", show.Title, err)
+				recordShowHistory(gctx, run, show, structures.RequestBackendOmbi, structures.RequestOutcomeFailed, err)
 			}
 		} else {
 			log.Infof("[ombi-job] Show requested successfully via Ombi: %s", show.Title)
+			recordShowHistory(gctx, run, show, structures.RequestBackendOmbi, structures.RequestOutcomeAdded, nil)
 			showPayload, err := json.Marshal(show)
 			if err != nil {
 				log.Errorf("[ombi-job] Failed to marshal show payload: %v", err)
@@ -486,71 +600,166 @@ func requestShowsToOmbi(o ombi.Service, notifications *notifications.Notificatio
 	}
 }
 
-func requestShowsToSonarr(gctx global.Context, helpers helpers.Helpers, notifications *notifications.NotificationManager, shows []trakt.Show, sonarrSettings db.SonarrSettings) {
-	// Fetch quality profile and root folder from Sonarr
-	qualityProfileID, rootFolderPath, err := fetchSonarrSettings(helpers.Sonarr, sonarrSettings)
-	if err != nil {
-		return
+// sonarrTarget is a Sonarr instance along with the quality profile, root folder and tags shows are added with
+type sonarrTarget struct {
+	service          sonarr.Service
+	settings         db.SonarrSettings
+	qualityProfileID int
+	rootFolderPath   string
+	tagIDs           []int
+}
+
+// sonarrAddOptions are the options from the show settings that shows are added to Sonarr with
+type sonarrAddOptions struct {
+	seriesType   structures.SonarrSeriesType
+	seasonFolder bool
+	monitor      structures.SonarrMonitor
+	searchOnAdd  bool
+	tags         []string // Labels of the tags shows are added with
+}
+
+func newSonarrAddOptions(settings db.ShowSettings) sonarrAddOptions {
+	options := sonarrAddOptions{
+		seriesType:   structures.SonarrSeriesType(settings.SeriesType),
+		seasonFolder: settings.SeasonFolder,
+		monitor:      structures.SonarrMonitor(settings.Monitor),
+		searchOnAdd:  settings.SearchOnAdd,
 	}
 
-	for _, show := range shows {
-		// Prepare the request body for Sonarr
-		body := sonarr.RequestSeriesBody{
-			Title:            show.Title,
-			TVDbId:           show.IDs.TVDB,
-			Monitored:        true,
-			QualityProfileID: qualityProfileID,
-			RootFolderPath:   rootFolderPath,
-		}
+	// Without show settings, add shows the way Sonarr does by default
+	if settings.ID == 0 {
+		options.seasonFolder = true
+		options.searchOnAdd = true
+	}
+
+	if options.seriesType == "" {
+		options.seriesType = structures.SonarrSeriesTypeStandard
+	}
 
-		body.AddOptions.SearchForMissingEpisodes = true
+	if options.monitor == "" {
+		options.monitor = structures.SonarrMonitorAll
+	}
 
-		// Make the request to Sonarr
-		_, err := helpers.Sonarr.RequestSeries(context.Background(), nil, nil, body)
+	return options
+}
+
+// requestBody builds the request adding a show to a Sonarr instance
+func (o sonarrAddOptions) requestBody(show trakt.Show, target sonarrTarget) sonarr.RequestSeriesBody {
+	// A Sonarr instance with its own season folder setting overrides the show settings
+	seasonFolder := o.seasonFolder
+	if target.settings.SeasonFolder.Valid {
+		seasonFolder = target.settings.SeasonFolder.Bool
+	}
+
+	body := sonarr.RequestSeriesBody{
+		Title:            show.Title,
+		TVDbId:           show.IDs.TVDB,
+		Monitored:        o.monitor != structures.SonarrMonitorNone,
+		SeriesType:       o.seriesType.String(),
+		SeasonFolder:     seasonFolder,
+		QualityProfileID: target.qualityProfileID,
+		RootFolderPath:   target.rootFolderPath,
+		Tags:             target.tagIDs,
+	}
+
+	body.AddOptions.Monitor = o.monitor.String()
+	body.AddOptions.SearchForMissingEpisodes = o.searchOnAdd && body.Monitored
+
+	return body
+}
+
+func requestShowsToSonarr(gctx global.Context, helpers helpers.Helpers, notifications *notifications.NotificationManager, shows []trakt.Show, instances []db.SonarrSettings, addOptions sonarrAddOptions, run *jobRun) {
+	// Fetch quality profile and root folder from every Sonarr instance
+	targets := []sonarrTarget{}
+	unavailable := instanceResults{} // Instances whose settings couldn't be fetched, every show fails on them
+	for _, instance := range instances {
+		service := helpers.Sonarr.Instance(instance.ID)
+		qualityProfileID, rootFolderPath, err := fetchSonarrSettings(service, instance)
 		if err != nil {
-			if errors.Is(err, sonarr.ErrShowAlreadyExists) {
-				// Log a warning if the show already exists in Sonarr
-				log.Warnf(`[sonarr-job] Skipping "%s" as it already exists in Sonarr...`, show.Title)
-			} else {
-				// Log an error for any other issues
-				log.Errorf("[sonarr-job] Failed to request show %s: %v", show.Title, err)
-			}
-		} else {
-			// Log a success message if the show was added successfully
-			log.Infof("[sonarr-job] Show requested successfully: %s", show.Title)
+			log.Error("[sonarr-job] Failed to retrieve Sonarr settings", "instance", instance.Name, "error", err)
+			unavailable = append(unavailable, instanceResult{instanceID: instance.ID, name: instance.Name, outcome: structures.RequestOutcomeFailed, err: err})
+			continue
+		}
 
-			// Get show poster
-			media, err := helpers.OMDb.GetMedia(context.Background(), show.IDs.IMDB)
-			if err != nil {
-				log.Errorf("[sonarr-job] Failed to get show poster for %s: %v", show.Title, err)
-				continue
-			}
+		tagIDs, err := sonarrTagIDs(service, addOptions.tags)
+		if err != nil {
+			log.Warn("[sonarr-job] Failed to retrieve Sonarr tags, adding shows without tags", "instance", instance.Name, "error", err)
+		}
 
-			// Add show to recently added
-			err = gctx.Crate().SQL.Queries().AddToRecentlyAddedMedia(
-				context.Background(),
-				"SHOW",
-				media.Title,
-				show.Year,
-				media.Plot,
-				media.IMDBID,
-				media.Poster,
-			)
-			if err != nil {
-				log.Errorf("[sonarr-job] Failed to add show %s to recently added: %v", show.Title, err)
-				continue
-			}
+		targets = append(targets, sonarrTarget{
+			service:          service,
+			settings:         instance,
+			qualityProfileID: qualityProfileID,
+			rootFolderPath:   rootFolderPath,
+			tagIDs:           tagIDs,
+		})
+	}
 
-			showPayload, err := json.Marshal(show)
+	for _, show := range shows {
+		results := append(instanceResults{}, unavailable...)
+		for _, target := range targets {
+			// Prepare the request body for Sonarr
+			body := addOptions.requestBody(show, target)
+
+			// Make the request to Sonarr
+			result := instanceResult{instanceID: target.settings.ID, name: target.settings.Name, outcome: structures.RequestOutcomeAdded}
+			_, err := target.service.RequestSeries(context.Background(), nil, nil, body)
 			if err != nil {
-				log.Errorf("[sonarr-job] Failed to marshal show payload: %v", err)
-				continue
+				if errors.Is(err, sonarr.ErrShowAlreadyExists) {
+					// Log a warning if the show already exists in Sonarr
+					log.Warnf(`[sonarr-job] Skipping "%s" as it already exists in Sonarr instance "%s"...`, show.Title, target.settings.Name)
+					result.outcome = structures.RequestOutcomeExists
+				} else {
+					// Log an error for any other issues
+					log.Errorf("[sonarr-job] Failed to request show %s from Sonarr instance %s: %v", show.Title, target.settings.Name, err)
+					result.outcome = structures.RequestOutcomeFailed
+					result.err = err
+				}
+			} else {
+				// Log a success message if the show was added successfully
+				log.Infof("[sonarr-job] Show requested successfully from Sonarr instance %s: %s", target.settings.Name, show.Title)
 			}
+			results = append(results, result)
+		}
 
-			err = notifications.SendNotification(structures.SHOWADDEDALERT, showPayload)
-			if err != nil {
-				log.Errorf("[sonarr-job] Failed to send notification for show %s: %v", show.Title, err)
-			}
+		outcome := recordShowResults(gctx, run, show, results)
+
+		// Only announce the show once, no matter how many instances it was added to
+		if outcome != structures.RequestOutcomeAdded {
+			continue
+		}
+
+		// Get show poster
+		media, err := helpers.OMDb.GetMedia(context.Background(), show.IDs.IMDB)
+		if err != nil {
+			log.Errorf("[sonarr-job] Failed to get show poster for %s: %v", show.Title, err)
+			continue
+		}
+
+		// Add show to recently added
+		err = gctx.Crate().SQL.Queries().AddToRecentlyAddedMedia(
+			context.Background(),
+			"SHOW",
+			media.Title,
+			show.Year,
+			media.Plot,
+			media.IMDBID,
+			media.Poster,
+		)
+		if err != nil {
+			log.Errorf("[sonarr-job] Failed to add show %s to recently added: %v", show.Title, err)
+			continue
+		}
+
+		showPayload, err := json.Marshal(show)
+		if err != nil {
+			log.Errorf("[sonarr-job] Failed to marshal show payload: %v", err)
+			continue
+		}
+
+		err = notifications.SendNotification(structures.SHOWADDEDALERT, showPayload)
+		if err != nil {
+			log.Errorf("[sonarr-job] Failed to send notification for show %s: %v", show.Title, err)
 		}
 	}
 }
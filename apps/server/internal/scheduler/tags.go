@@ -0,0 +1,160 @@
+package scheduler
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/mahcks/blockbusterr/internal/helpers/radarr"
+	"github.com/mahcks/blockbusterr/internal/helpers/sonarr"
+	"github.com/mahcks/blockbusterr/pkg/structures"
+)
+
+var invalidTagChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// NormalizeTag turns a label into one Radarr and Sonarr accept, which is lowercase letters, digits and dashes
+func NormalizeTag(label string) string {
+	label = invalidTagChars.ReplaceAllString(strings.ToLower(strings.TrimSpace(label)), "-")
+	return strings.Trim(label, "-")
+}
+
+// GetJobTags returns the tags a list job adds titles with in place of the tag of its source
+func (s *Scheduler) GetJobTags(listType string) ([]string, error) {
+	if _, err := s.jobMediaType(listType); err != nil {
+		return nil, err
+	}
+
+	return s.gctx.Crate().SQL.Queries().GetJobTags(s.gctx, listType)
+}
+
+// SetJobTags sets the tags a list job adds titles with. No tags makes the job use the tag of its source again.
+func (s *Scheduler) SetJobTags(listType string, tags []string) error {
+	if _, err := s.jobMediaType(listType); err != nil {
+		return err
+	}
+
+	return s.gctx.Crate().SQL.Queries().SetJobTags(s.gctx, listType, tags)
+}
+
+// jobTagLabels returns the labels of the tags titles from a list job are added with:
+// the tags from the settings plus the tags of the job, or the tag of its source when it has none
+func (s Scheduler) jobTagLabels(listType string) []string {
+	queries := s.gctx.Crate().SQL.Queries()
+
+	enabled, err := queries.GetSettingByKey(s.gctx, structures.SettingTagsEnabled.String())
+	if err != nil {
+		log.Warn("[Scheduler] Error fetching tags setting, adding titles without tags.", "error", err)
+		return nil
+	}
+	if enabled.Value.String != "true" {
+		return nil
+	}
+
+	labels := []string{}
+	if setting, err := queries.GetSettingByKey(s.gctx, structures.SettingTags.String()); err != nil {
+		log.Warn("[Scheduler] Error fetching tags.", "error", err)
+	} else {
+		labels = append(labels, strings.Split(setting.Value.String, ",")...)
+	}
+
+	jobTags, err := queries.GetJobTags(s.gctx, listType)
+	if err != nil {
+		log.Warn("[Scheduler] Error fetching job tags.", "job", listType, "error", err)
+	}
+
+	if len(jobTags) > 0 {
+		labels = append(labels, jobTags...)
+	} else if setting, err := queries.GetSettingByKey(s.gctx, structures.SettingSourceTags.String()); err == nil && setting.Value.String == "true" {
+		labels = append(labels, s.sourceTag(listType))
+	}
+
+	return uniqueTags(labels)
+}
+
+// sourceTag returns the tag of the list a job fetches, e.g. "trakt-trending" or "trakt-my-watchlist"
+func (s Scheduler) sourceTag(listType string) string {
+	name := strings.TrimPrefix(strings.TrimPrefix(listType, "movie-"), "show-")
+	if source, err := s.getListSource(listType); err == nil {
+		name = source.Name
+	}
+
+	return NormalizeTag("trakt-" + name)
+}
+
+// uniqueTags normalizes labels and drops empty and repeated ones
+func uniqueTags(labels []string) []string {
+	seen := make(map[string]bool, len(labels))
+	tags := []string{}
+	for _, label := range labels {
+		tag := NormalizeTag(label)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+
+	return tags
+}
+
+// resolveTagIDs returns the IDs of the tags with the given labels, creating the ones that don't exist yet
+func resolveTagIDs(labels []string, existing map[string]int, create func(label string) (int, error)) ([]int, error) {
+	ids := make([]int, 0, len(labels))
+	for _, label := range labels {
+		id, ok := existing[label]
+		if !ok {
+			var err error
+			id, err = create(label)
+			if err != nil {
+				return nil, err
+			}
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// radarrTagIDs returns the IDs of the tags with the given labels in a Radarr instance
+func radarrTagIDs(service radarr.Service, labels []string) ([]int, error) {
+	if len(labels) == 0 {
+		return nil, nil
+	}
+
+	tags, err := service.GetTags(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]int, len(tags))
+	for _, tag := range tags {
+		existing[strings.ToLower(tag.Label)] = tag.ID
+	}
+
+	return resolveTagIDs(labels, existing, func(label string) (int, error) {
+		tag, err := service.CreateTag(nil, nil, label)
+		return tag.ID, err
+	})
+}
+
+// sonarrTagIDs returns the IDs of the tags with the given labels in a Sonarr instance
+func sonarrTagIDs(service sonarr.Service, labels []string) ([]int, error) {
+	if len(labels) == 0 {
+		return nil, nil
+	}
+
+	tags, err := service.GetTags(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]int, len(tags))
+	for _, tag := range tags {
+		existing[strings.ToLower(tag.Label)] = tag.ID
+	}
+
+	return resolveTagIDs(labels, existing, func(label string) (int, error) {
+		tag, err := service.CreateTag(nil, nil, label)
+		return tag.ID, err
+	})
+}
@@ -0,0 +1,89 @@
+package scheduler
+
+import (
+	"errors"
+
+	"github.com/charmbracelet/log"
+	"github.com/mahcks/blockbusterr/internal/helpers/trakt"
+	"github.com/mahcks/blockbusterr/pkg/structures"
+)
+
+// skipWatched reports whether titles the authorized Trakt account already watched should be skipped
+func (s Scheduler) skipWatched() bool {
+	setting, err := s.gctx.Crate().SQL.Queries().GetSettingByKey(s.gctx, structures.SettingTraktSkipWatched.String())
+	if err != nil {
+		return false
+	}
+
+	return setting.Value.String == "true"
+}
+
+// fetchWatchedMovieIDs returns the TMDb IDs of every movie the authorized Trakt account watched.
+// Nothing is fetched when skipping watched titles is disabled or no account is authorized.
+func (s Scheduler) fetchWatchedMovieIDs() (map[int]bool, error) {
+	if !s.skipWatched() {
+		return make(map[int]bool), nil
+	}
+
+	watched, err := s.traktWatchedMovieIDs()
+	if errors.Is(err, trakt.ErrTraktNotAuthorized) {
+		return watched, nil
+	}
+
+	return watched, err
+}
+
+// fetchWatchedShowIDs returns the TVDB IDs of every show the authorized Trakt account watched.
+// Nothing is fetched when skipping watched titles is disabled or no account is authorized.
+func (s Scheduler) fetchWatchedShowIDs() (map[int]bool, error) {
+	if !s.skipWatched() {
+		return make(map[int]bool), nil
+	}
+
+	watched, err := s.traktWatchedShowIDs()
+	if errors.Is(err, trakt.ErrTraktNotAuthorized) {
+		return watched, nil
+	}
+
+	return watched, err
+}
+
+// traktWatchedMovieIDs returns the TMDb IDs of every movie the authorized Trakt account watched
+func (s Scheduler) traktWatchedMovieIDs() (map[int]bool, error) {
+	watched := make(map[int]bool)
+
+	movies, err := s.helpers.Trakt.GetWatchedMovies(s.gctx)
+	if err != nil {
+		return watched, err
+	}
+
+	for _, movie := range movies {
+		watched[movie.Movie.IDs.TMDB] = true
+	}
+
+	return watched, nil
+}
+
+// traktWatchedShowIDs returns the TVDB IDs of every show the authorized Trakt account watched
+func (s Scheduler) traktWatchedShowIDs() (map[int]bool, error) {
+	watched := make(map[int]bool)
+
+	shows, err := s.helpers.Trakt.GetWatchedShows(s.gctx)
+	if err != nil {
+		return watched, err
+	}
+
+	for _, show := range shows {
+		watched[show.Show.IDs.TVDB] = true
+	}
+
+	return watched, nil
+}
+
+// refreshTraktToken refreshes the Trakt access token before it expires, so an account
+// that isn't used by any job for a while stays authorized
+func (s Scheduler) refreshTraktToken() {
+	if err := s.helpers.Trakt.RefreshAuth(s.gctx); err != nil && !errors.Is(err, trakt.ErrNoTraktSettings) {
+		log.Warn("[Scheduler] Failed to refresh the Trakt access token.", "error", err)
+	}
+}
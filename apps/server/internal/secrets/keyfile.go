@@ -0,0 +1,54 @@
+package secrets
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LoadOrCreateKey reads the secret key stored at path. If there's no file yet, a random key is
+// generated and written there, so secrets are encrypted even when no key is configured.
+// It reports whether the key was created.
+func LoadOrCreateKey(path string) (string, bool, error) {
+	contents, err := os.ReadFile(path)
+	if err == nil {
+		key := strings.TrimSpace(string(contents))
+		if key == "" {
+			return "", false, fmt.Errorf("secret key file %s is empty", path)
+		}
+
+		return key, false, nil
+	}
+
+	if !errors.Is(err, fs.ErrNotExist) {
+		return "", false, fmt.Errorf("error reading secret key file: %w", err)
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", false, fmt.Errorf("error generating secret key: %w", err)
+	}
+	key := base64.StdEncoding.EncodeToString(raw)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return "", false, fmt.Errorf("error creating secret key directory: %w", err)
+	}
+
+	// O_EXCL keeps a key written by another process from being replaced
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return "", false, fmt.Errorf("error creating secret key file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(key + "\n"); err != nil {
+		return "", false, fmt.Errorf("error writing secret key file: %w", err)
+	}
+
+	return key, true, nil
+}
@@ -0,0 +1,117 @@
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// Placeholder replaces secrets in API responses. Sending it back in an update keeps the stored secret.
+	Placeholder = "********"
+
+	// encryptedPrefix marks values that were encrypted, so plaintext from older installs can still be read
+	encryptedPrefix = "enc:v1:"
+)
+
+var ErrNoSecretKey = errors.New("secret is encrypted but no secret key is set")
+
+// Cipher encrypts secrets before they are stored and decrypts them when they are used.
+// A nil Cipher leaves values untouched, which is what happens when no key is configured.
+type Cipher struct {
+	aead cipher.AEAD
+}
+
+// NewCipher derives an AES-256 key from key. Any string works, but changing it makes
+// the secrets already stored unreadable.
+func NewCipher(key string) (*Cipher, error) {
+	if key == "" {
+		return nil, errors.New("secret key can't be empty")
+	}
+
+	sum := sha256.Sum256([]byte(key))
+	block, err := aes.NewCipher(sum[:])
+	if err != nil {
+		return nil, fmt.Errorf("error creating cipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("error creating GCM: %w", err)
+	}
+
+	return &Cipher{aead: aead}, nil
+}
+
+// Encrypt returns the encrypted form of value. Empty and already encrypted values are returned as-is.
+func (c *Cipher) Encrypt(value string) (string, error) {
+	if c == nil || value == "" || IsEncrypted(value) {
+		return value, nil
+	}
+
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("error generating nonce: %w", err)
+	}
+
+	sealed := c.aead.Seal(nonce, nonce, []byte(value), nil)
+	return encryptedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt returns the plaintext of value. Values that were never encrypted are returned as-is.
+func (c *Cipher) Decrypt(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+
+	if c == nil {
+		return "", ErrNoSecretKey
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, encryptedPrefix))
+	if err != nil {
+		return "", fmt.Errorf("error decoding secret: %w", err)
+	}
+
+	nonceSize := c.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return "", errors.New("encrypted secret is too short")
+	}
+
+	plaintext, err := c.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], nil)
+	if err != nil {
+		return "", fmt.Errorf("error decrypting secret, was the secret key changed? %w", err)
+	}
+
+	return string(plaintext), nil
+}
+
+// IsEncrypted reports whether value was produced by Encrypt
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, encryptedPrefix)
+}
+
+// Mask hides a stored secret for API responses, leaving empty values empty so clients can tell it isn't set
+func Mask(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	return Placeholder
+}
+
+// MaskNullString is Mask for nullable columns, returning nil if the secret isn't set
+func MaskNullString(value sql.NullString) *string {
+	if !value.Valid {
+		return nil
+	}
+
+	masked := Mask(value.String)
+	return &masked
+}
@@ -1,9 +1,11 @@
 package services
 
 import (
+	"github.com/mahcks/blockbusterr/internal/secrets"
 	"github.com/mahcks/blockbusterr/internal/services/sqlite"
 )
 
 type Crate struct {
-	SQL sqlite.Service
+	SQL     sqlite.Service
+	Secrets *secrets.Cipher // Decrypts the stored API keys and client secrets, nil if no secret key is set
 }
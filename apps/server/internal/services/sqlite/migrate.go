@@ -0,0 +1,356 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/mahcks/blockbusterr/internal/db"
+)
+
+type migration struct {
+	Version int    // Numeric prefix of the migration file (e.g. 1 for 0001_initial_schema.sql)
+	Name    string // File name of the migration
+	SQL     string // Contents of the migration file
+}
+
+// loadMigrations reads the embedded migration files and returns them sorted by version
+func loadMigrations() ([]migration, error) {
+	files, err := fs.Glob(db.Migrations, "migrations/*.sql")
+	if err != nil {
+		return nil, fmt.Errorf("error listing migrations: %w", err)
+	}
+
+	var migrations []migration
+	seen := make(map[int]string)
+	for _, file := range files {
+		name := path.Base(file)
+
+		prefix, _, found := strings.Cut(name, "_")
+		if !found {
+			return nil, fmt.Errorf("migration %s is missing a numeric version prefix", name)
+		}
+
+		version, err := strconv.Atoi(prefix)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %s has an invalid version prefix", name)
+		}
+
+		if other, exists := seen[version]; exists {
+			return nil, fmt.Errorf("migrations %s and %s share version %d", other, name, version)
+		}
+		seen[version] = name
+
+		contents, err := fs.ReadFile(db.Migrations, file)
+		if err != nil {
+			return nil, fmt.Errorf("error reading migration %s: %w", name, err)
+		}
+
+		migrations = append(migrations, migration{
+			Version: version,
+			Name:    name,
+			SQL:     string(contents),
+		})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// migrate applies every pending migration, each one inside its own transaction
+func migrate(ctx context.Context, conn *sql.DB) error {
+	_, err := conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			name TEXT NOT NULL,
+			applied_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		);
+	`)
+	if err != nil {
+		return fmt.Errorf("error creating schema_migrations table: %w", err)
+	}
+
+	migrations, err := loadMigrations()
+	if err != nil {
+		return err
+	}
+
+	applied, err := appliedMigrations(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	// Databases created by init-db.sh already contain the initial schema, but have no record of it
+	if len(applied) == 0 && len(migrations) > 0 {
+		legacy, err := isLegacyDatabase(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		if legacy {
+			// Older init-db.sh schemas may lack tables and columns the baseline migration creates
+			if err := reconcileBaseline(ctx, conn, migrations[0]); err != nil {
+				return err
+			}
+
+			_, err = conn.ExecContext(ctx, `INSERT INTO schema_migrations (version, name) VALUES (?, ?)`, migrations[0].Version, migrations[0].Name)
+			if err != nil {
+				return fmt.Errorf("error recording baseline migration: %w", err)
+			}
+
+			applied[migrations[0].Version] = true
+			log.Info("Existing database detected, marked initial migration as applied", "migration", migrations[0].Name)
+		}
+	}
+
+	for _, m := range migrations {
+		if applied[m.Version] {
+			continue
+		}
+
+		if err := applyMigration(ctx, conn, m); err != nil {
+			return err
+		}
+
+		log.Info("Applied database migration", "migration", m.Name)
+	}
+
+	return nil
+}
+
+// appliedMigrations returns the set of migration versions already recorded in schema_migrations
+func appliedMigrations(ctx context.Context, conn *sql.DB) (map[int]bool, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT version FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int]bool)
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			return nil, fmt.Errorf("error scanning applied migration: %w", err)
+		}
+		applied[version] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating applied migrations: %w", err)
+	}
+
+	return applied, nil
+}
+
+// isLegacyDatabase checks if the database was initialized before migrations were tracked
+func isLegacyDatabase(ctx context.Context, conn *sql.DB) (bool, error) {
+	var count int
+	err := conn.QueryRowContext(ctx, `SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'settings'`).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("error checking for existing schema: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// tableColumn is a column as reported by PRAGMA table_info
+type tableColumn struct {
+	Name         string
+	Type         string
+	NotNull      bool
+	DefaultValue sql.NullString
+}
+
+// reconcileBaseline brings a legacy database in line with the baseline migration. Missing tables are created
+// along with the rows the baseline seeds them with, and missing columns are added. Columns that can't be
+// added to an existing table fail the migration instead of leaving a schema that breaks at runtime.
+func reconcileBaseline(ctx context.Context, conn *sql.DB, baseline migration) error {
+	// Build the baseline schema in a scratch database to compare against
+	reference, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		return fmt.Errorf("error opening reference database: %w", err)
+	}
+	defer reference.Close()
+
+	// Every connection to :memory: is a separate database
+	reference.SetMaxOpenConns(1)
+
+	if _, err := reference.ExecContext(ctx, baseline.SQL); err != nil {
+		return fmt.Errorf("error building reference schema from %s: %w", baseline.Name, err)
+	}
+
+	tables, err := reference.QueryContext(ctx, `SELECT name, sql FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY rowid`)
+	if err != nil {
+		return fmt.Errorf("error listing reference tables: %w", err)
+	}
+
+	schema := make(map[string]string)
+	var names []string
+	for tables.Next() {
+		var name, createSQL string
+		if err := tables.Scan(&name, &createSQL); err != nil {
+			tables.Close()
+			return fmt.Errorf("error scanning reference table: %w", err)
+		}
+		schema[name] = createSQL
+		names = append(names, name)
+	}
+	tables.Close()
+
+	for _, table := range names {
+		existing, err := tableColumns(ctx, conn, table)
+		if err != nil {
+			return err
+		}
+
+		if len(existing) == 0 {
+			if err := createBaselineTable(ctx, conn, reference, table, schema[table]); err != nil {
+				return err
+			}
+			log.Warn("Created table missing from existing database", "table", table)
+			continue
+		}
+
+		expected, err := tableColumns(ctx, reference, table)
+		if err != nil {
+			return err
+		}
+
+		present := make(map[string]bool, len(existing))
+		for _, column := range existing {
+			present[strings.ToLower(column.Name)] = true
+		}
+
+		for _, column := range expected {
+			if present[strings.ToLower(column.Name)] {
+				continue
+			}
+
+			if err := addColumn(ctx, conn, table, column); err != nil {
+				return err
+			}
+			log.Warn("Added column missing from existing database", "table", table, "column", column.Name)
+		}
+	}
+
+	return nil
+}
+
+// tableColumns returns the columns of a table, or none if the table doesn't exist
+func tableColumns(ctx context.Context, conn *sql.DB, table string) ([]tableColumn, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT name, type, "notnull", dflt_value FROM pragma_table_info(?)`, table)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching columns of table %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	var columns []tableColumn
+	for rows.Next() {
+		var column tableColumn
+		if err := rows.Scan(&column.Name, &column.Type, &column.NotNull, &column.DefaultValue); err != nil {
+			return nil, fmt.Errorf("error scanning column of table %s: %w", table, err)
+		}
+		columns = append(columns, column)
+	}
+
+	return columns, rows.Err()
+}
+
+// addColumn adds a baseline column to an existing table
+func addColumn(ctx context.Context, conn *sql.DB, table string, column tableColumn) error {
+	if column.NotNull && !column.DefaultValue.Valid {
+		return fmt.Errorf("existing table %s is missing column %s, which is required and has no default; migrate the database by hand or start from a new one", table, column.Name)
+	}
+
+	definition := fmt.Sprintf("`%s` %s", column.Name, column.Type)
+	if column.NotNull {
+		definition += " NOT NULL"
+	}
+	if column.DefaultValue.Valid {
+		definition += " DEFAULT " + column.DefaultValue.String
+	}
+
+	if _, err := conn.ExecContext(ctx, fmt.Sprintf("ALTER TABLE `%s` ADD COLUMN %s", table, definition)); err != nil {
+		return fmt.Errorf("error adding column %s to existing table %s, migrate the database by hand or start from a new one: %w", column.Name, table, err)
+	}
+
+	return nil
+}
+
+// createBaselineTable creates a baseline table that is missing and copies the rows the baseline seeds it with
+func createBaselineTable(ctx context.Context, conn *sql.DB, reference *sql.DB, table, createSQL string) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction for table %s: %w", table, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, createSQL); err != nil {
+		return fmt.Errorf("error creating table %s: %w", table, err)
+	}
+
+	rows, err := reference.QueryContext(ctx, fmt.Sprintf("SELECT * FROM `%s`", table))
+	if err != nil {
+		return fmt.Errorf("error reading seed rows of table %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return fmt.Errorf("error reading seed columns of table %s: %w", table, err)
+	}
+
+	insert := fmt.Sprintf("INSERT INTO `%s` (`%s`) VALUES (%s)", table, strings.Join(columns, "`, `"), strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", "))
+	for rows.Next() {
+		values := make([]any, len(columns))
+		pointers := make([]any, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+
+		if err := rows.Scan(pointers...); err != nil {
+			return fmt.Errorf("error scanning seed row of table %s: %w", table, err)
+		}
+
+		if _, err := tx.ExecContext(ctx, insert, values...); err != nil {
+			return fmt.Errorf("error seeding table %s: %w", table, err)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error reading seed rows of table %s: %w", table, err)
+	}
+
+	return tx.Commit()
+}
+
+func applyMigration(ctx context.Context, conn *sql.DB, m migration) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction for migration %s: %w", m.Name, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, m.SQL); err != nil {
+		return fmt.Errorf("error applying migration %s: %w", m.Name, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version, name) VALUES (?, ?)`, m.Version, m.Name); err != nil {
+		return fmt.Errorf("error recording migration %s: %w", m.Name, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing migration %s: %w", m.Name, err)
+	}
+
+	return nil
+}
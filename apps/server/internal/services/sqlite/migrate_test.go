@@ -0,0 +1,202 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// openTestDatabase opens an empty database file that is removed after the test
+func openTestDatabase(t *testing.T) *sql.DB {
+	t.Helper()
+
+	conn, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+// mustLoadMigrations returns the embedded migrations or fails the test
+func mustLoadMigrations(t *testing.T) []migration {
+	t.Helper()
+
+	migrations, err := loadMigrations()
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("expected embedded migrations")
+	}
+
+	return migrations
+}
+
+// assertMigrationsRecorded checks that schema_migrations holds every migration exactly once
+func assertMigrationsRecorded(t *testing.T, conn *sql.DB, migrations []migration) {
+	t.Helper()
+
+	rows, err := conn.Query(`SELECT version, name FROM schema_migrations ORDER BY version`)
+	if err != nil {
+		t.Fatalf("failed to read schema_migrations: %v", err)
+	}
+	defer rows.Close()
+
+	var recorded []migration
+	for rows.Next() {
+		var m migration
+		if err := rows.Scan(&m.Version, &m.Name); err != nil {
+			t.Fatalf("failed to scan schema_migrations: %v", err)
+		}
+		recorded = append(recorded, m)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("failed to read schema_migrations: %v", err)
+	}
+
+	if len(recorded) != len(migrations) {
+		t.Fatalf("expected %d recorded migrations, got %d", len(migrations), len(recorded))
+	}
+	for i, m := range migrations {
+		if recorded[i].Version != m.Version || recorded[i].Name != m.Name {
+			t.Errorf("expected migration %d %s to be recorded, got %d %s", m.Version, m.Name, recorded[i].Version, recorded[i].Name)
+		}
+	}
+}
+
+// hasColumn reports whether table has the column
+func hasColumn(t *testing.T, conn *sql.DB, table, column string) bool {
+	t.Helper()
+
+	columns, err := tableColumns(context.Background(), conn, table)
+	if err != nil {
+		t.Fatalf("failed to read columns of %s: %v", table, err)
+	}
+
+	for _, c := range columns {
+		if c.Name == column {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestLoadMigrationsSortedByVersion(t *testing.T) {
+	migrations := mustLoadMigrations(t)
+
+	if migrations[0].Name != "0001_initial_schema.sql" {
+		t.Errorf("expected the baseline migration first, got %s", migrations[0].Name)
+	}
+
+	for i, m := range migrations {
+		if m.SQL == "" {
+			t.Errorf("migration %s is empty", m.Name)
+		}
+		if i > 0 && m.Version <= migrations[i-1].Version {
+			t.Errorf("migration %s is out of order after %s", m.Name, migrations[i-1].Name)
+		}
+	}
+}
+
+func TestIsLegacyDatabase(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDatabase(t)
+
+	legacy, err := isLegacyDatabase(ctx, conn)
+	if err != nil {
+		t.Fatalf("failed to check database: %v", err)
+	}
+	if legacy {
+		t.Error("expected an empty database not to be legacy")
+	}
+
+	if _, err := conn.Exec("CREATE TABLE settings (id INTEGER PRIMARY KEY)"); err != nil {
+		t.Fatalf("failed to create settings table: %v", err)
+	}
+
+	legacy, err = isLegacyDatabase(ctx, conn)
+	if err != nil {
+		t.Fatalf("failed to check database: %v", err)
+	}
+	if !legacy {
+		t.Error("expected a database with a settings table to be legacy")
+	}
+}
+
+func TestMigrateFreshDatabase(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDatabase(t)
+	migrations := mustLoadMigrations(t)
+
+	if err := migrate(ctx, conn); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	assertMigrationsRecorded(t, conn, migrations)
+
+	// Running again must not apply anything twice
+	if err := migrate(ctx, conn); err != nil {
+		t.Fatalf("failed to migrate again: %v", err)
+	}
+	assertMigrationsRecorded(t, conn, migrations)
+
+	var setupComplete string
+	if err := conn.QueryRow(`SELECT value FROM settings WHERE key = 'SETUP_COMPLETE'`).Scan(&setupComplete); err != nil {
+		t.Fatalf("expected the baseline settings to be seeded: %v", err)
+	}
+	if setupComplete != "false" {
+		t.Errorf("expected a fresh database to need the setup, got SETUP_COMPLETE %q", setupComplete)
+	}
+}
+
+func TestMigrateLegacyDatabase(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDatabase(t)
+	migrations := mustLoadMigrations(t)
+
+	// An init-db.sh database has the baseline schema without a schema_migrations table. Older
+	// ones lack some of its tables and columns.
+	if _, err := conn.Exec(migrations[0].SQL); err != nil {
+		t.Fatalf("failed to create baseline schema: %v", err)
+	}
+	if _, err := conn.Exec("DROP TABLE `omdb`"); err != nil {
+		t.Fatalf("failed to drop table: %v", err)
+	}
+	if _, err := conn.Exec("ALTER TABLE `settings` DROP COLUMN `type`"); err != nil {
+		t.Fatalf("failed to drop column: %v", err)
+	}
+	if _, err := conn.Exec(`UPDATE settings SET value = 'true' WHERE key = 'SETUP_COMPLETE'`); err != nil {
+		t.Fatalf("failed to complete setup: %v", err)
+	}
+
+	if err := migrate(ctx, conn); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	assertMigrationsRecorded(t, conn, migrations)
+
+	if err := migrate(ctx, conn); err != nil {
+		t.Fatalf("failed to migrate again: %v", err)
+	}
+	assertMigrationsRecorded(t, conn, migrations)
+
+	if !hasColumn(t, conn, "omdb", "api_key") {
+		t.Error("expected the missing omdb table to be created")
+	}
+	if !hasColumn(t, conn, "settings", "type") {
+		t.Error("expected the missing settings.type column to be added")
+	}
+
+	// The baseline isn't applied again, so existing data is kept
+	var setupComplete string
+	if err := conn.QueryRow(`SELECT value FROM settings WHERE key = 'SETUP_COMPLETE'`).Scan(&setupComplete); err != nil {
+		t.Fatalf("failed to read SETUP_COMPLETE: %v", err)
+	}
+	if setupComplete != "true" {
+		t.Errorf("expected the stored settings to be kept, got SETUP_COMPLETE %q", setupComplete)
+	}
+}
@@ -9,25 +9,17 @@ import (
 	"github.com/mahcks/blockbusterr/internal/db"
 )
 
-func Setup(ctx context.Context, Version string) (Service, error) {
+func Setup(ctx context.Context, path string) (Service, error) {
 	svc := &sqliteService{}
 	var err error
 
-	if Version == "dev" {
-		svc.db, err = sql.Open("sqlite3", "blockbusterr.db")
-		if err != nil {
-			log.Error("Error opening SQLite database", "error", err)
-			return nil, err
-		}
-	} else {
-		svc.db, err = sql.Open("sqlite3", "/app/data/settings.db")
-		if err != nil {
-			log.Error("Error opening SQLite database", "error", err)
-			return nil, err
-		}
+	svc.db, err = sql.Open("sqlite3", path)
+	if err != nil {
+		log.Error("Error opening SQLite database", "error", err)
+		return nil, err
 	}
 
-	log.Info("SQLite database opened")
+	log.Info("SQLite database opened", "path", path)
 
 	err = svc.db.Ping()
 	if err != nil {
@@ -37,6 +29,15 @@ func Setup(ctx context.Context, Version string) (Service, error) {
 
 	log.Info("SQLite database pinged")
 
+	// Apply any pending schema migrations
+	err = migrate(ctx, svc.db)
+	if err != nil {
+		log.Error("Error migrating SQLite database", "error", err)
+		return nil, err
+	}
+
+	log.Info("SQLite database migrated")
+
 	// Initialize the queries
 	svc.queries = db.NewQueries(svc.db)
 
@@ -22,7 +22,8 @@ type Hub struct {
 	crate *services.Crate
 
 	// Registered clients.
-	clients map[*Client]bool
+	clients   map[*Client]bool
+	clientsMu sync.RWMutex
 
 	// Register requests from the clients.
 	register chan *Client
@@ -57,22 +58,28 @@ func (h *Hub) Run() {
 		select {
 		// Register a new client
 		case client := <-h.register:
+			h.clientsMu.Lock()
 			h.clients[client] = true
+			h.clientsMu.Unlock()
 			log.Infof("client registered: %v", client.sessionID)
 
 		// Unregister a client
 		case client := <-h.unregister:
+			h.clientsMu.Lock()
 			if _, ok := h.clients[client]; ok {
 				delete(h.clients, client)
 				close(client.send)
 				log.Infof("client disconnected: %v", client.sessionID)
 			}
+			h.clientsMu.Unlock()
 
 		// Stop the hub
 		case <-h.stop:
+			h.clientsMu.Lock()
 			for client := range h.clients {
 				close(client.send)
 			}
+			h.clientsMu.Unlock()
 			h.wg.Done()
 			close(h.done)
 			return
@@ -119,6 +126,9 @@ func (h *Hub) ServeWs(conn *websocket.Conn) {
 }
 
 func (h *Hub) SendMessageToTopic(topic structures.Topic, msg structures.Message) {
+	h.clientsMu.RLock()
+	defer h.clientsMu.RUnlock()
+
 	if h.clients == nil || len(h.clients) == 0 {
 		log.Debug("SendMessageToTopic: no clients connected", "topic", topic)
 		return
@@ -133,3 +143,16 @@ func (h *Hub) SendMessageToTopic(topic structures.Topic, msg structures.Message)
 		}
 	}
 }
+
+// Dispatch sends the data as a dispatch message to every client subscribed to the topic.
+func (h *Hub) Dispatch(topic structures.Topic, data interface{}) {
+	h.SendMessageToTopic(topic, structures.NewMessage(structures.CodeDispatch, structures.DispatchPayload{
+		Topic: topic,
+		Data:  data,
+	}))
+}
+
+// PublishLog sends a newly created log entry to the clients subscribed to log.created.
+func (h *Hub) PublishLog(entry structures.Log) {
+	h.Dispatch(structures.TopicLogCreated, entry)
+}
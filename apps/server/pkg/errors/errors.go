@@ -32,6 +32,8 @@ var (
 	ErrMissingEnvironmentVariable apiErrorFunc = DefineError(10405, "Missing Required Environment Variable", fasthttp.StatusBadRequest)
 
 	// Other client errors
+	ErrConflict        apiErrorFunc = DefineError(10409, "Conflict", fasthttp.StatusConflict)
+	ErrTooManyRequests apiErrorFunc = DefineError(10429, "Too Many Requests", fasthttp.StatusTooManyRequests)
 
 	// Server errors
 	ErrInternalServerError apiErrorFunc = DefineError(10500, "Internal Server Error", fasthttp.StatusInternalServerError)
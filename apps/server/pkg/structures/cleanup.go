@@ -0,0 +1,68 @@
+package structures
+
+import "time"
+
+// CleanupAction is what the cleanup job does with a title it cleans up
+type CleanupAction string
+
+func (a CleanupAction) String() string {
+	return string(a)
+}
+
+const (
+	CleanupActionUnmonitor CleanupAction = "unmonitor" // The title stays in Radarr/Sonarr, but is no longer searched for
+	CleanupActionDelete    CleanupAction = "delete"    // The title is removed from Radarr/Sonarr
+)
+
+// IsValidCleanupAction checks if the action is one the cleanup job supports
+func IsValidCleanupAction(action string) bool {
+	switch CleanupAction(action) {
+	case CleanupActionUnmonitor, CleanupActionDelete:
+		return true
+	}
+
+	return false
+}
+
+// CleanupReason is why the cleanup job cleans up a title
+type CleanupReason string
+
+const (
+	CleanupReasonNoFile    CleanupReason = "no_file"   // The title was never downloaded
+	CleanupReasonUnwatched CleanupReason = "unwatched" // The authorized Trakt account never watched the title
+)
+
+type CleanupSettings struct {
+	Cron        *string       `json:"cron"`         // Cron expression the cleanup job runs on, null to not schedule it
+	MinAgeDays  int           `json:"min_age_days"` // Titles are only cleaned up once they were added at least this many days ago
+	Action      CleanupAction `json:"action"`       // Whether titles are unmonitored or deleted
+	DeleteFiles bool          `json:"delete_files"` // Whether deleting a title also deletes its files
+}
+
+type CleanupExclusion struct {
+	ID        int       `json:"id"`              // Primary key with auto-increment
+	MediaType string    `json:"media_type"`      // Type of media (MOVIE, SHOW)
+	MediaID   int       `json:"media_id"`        // TMDb ID of a movie or TVDB ID of a show
+	Title     *string   `json:"title,omitempty"` // Title of the excluded media
+	CreatedAt time.Time `json:"created_at"`      // Time the exclusion was added
+}
+
+// CleanupReport is what the cleanup job did, or would do in dry-run mode
+type CleanupReport struct {
+	DryRun bool          `json:"dry_run"` // Whether the titles were only reported
+	Action CleanupAction `json:"action"`  // What is done with the titles
+	Items  []CleanupItem `json:"items"`   // Every title that was, or would be, cleaned up
+}
+
+type CleanupItem struct {
+	MediaType string        `json:"media_type"`        // Type of media (MOVIE, SHOW)
+	Title     string        `json:"title"`             // Title of the media
+	Year      *int          `json:"year,omitempty"`    // Year the media was released
+	TMDBID    int           `json:"tmdb_id,omitempty"` // TMDb ID of a movie
+	TVDBID    int           `json:"tvdb_id,omitempty"` // TVDB ID of a show
+	Source    string        `json:"source"`            // List the media was first added from (e.g., movie-trending)
+	Instance  string        `json:"instance"`          // Radarr/Sonarr instance the media is in
+	AddedAt   time.Time     `json:"added_at"`          // Time the media was first added
+	Reason    CleanupReason `json:"reason"`            // Why the media is cleaned up
+	Error     *string       `json:"error,omitempty"`   // Why cleaning up the media failed
+}
@@ -0,0 +1,8 @@
+package structures
+
+// FilterEntry is an entry of one of the allowed or blacklisted lists of the movie or show settings
+type FilterEntry struct {
+	ID        int              `json:"id"`                   // Primary key with auto-increment, ignored when adding entries
+	Value     string           `json:"value"`                // Country or language code, genre, network, title keyword, TMDb or TVDB ID
+	MatchMode KeywordMatchMode `json:"match_mode,omitempty"` // How a title keyword is matched, only used by the keyword lists
+}
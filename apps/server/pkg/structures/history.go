@@ -0,0 +1,49 @@
+package structures
+
+import "time"
+
+type RequestOutcome string
+
+func (ro RequestOutcome) String() string {
+	return string(ro)
+}
+
+const (
+	RequestOutcomeAdded   RequestOutcome = "added"   // The candidate was added/requested successfully
+	RequestOutcomeExists  RequestOutcome = "exists"  // The candidate already exists or was already requested
+	RequestOutcomeFailed  RequestOutcome = "failed"  // The request for the candidate failed
+	RequestOutcomeDryRun  RequestOutcome = "dry_run" // The candidate would have been requested, but dry-run mode is enabled
+	RequestOutcomeSkipped RequestOutcome = "skipped" // The candidate was dropped by the filters, the reason says why
+
+	// RequestOutcomeOwned is used for candidates that were skipped because they are already in the media server library
+	RequestOutcomeOwned RequestOutcome = "owned"
+)
+
+type RequestBackend string
+
+func (rb RequestBackend) String() string {
+	return string(rb)
+}
+
+const (
+	RequestBackendRadarr RequestBackend = "radarr"
+	RequestBackendSonarr RequestBackend = "sonarr"
+	RequestBackendOmbi   RequestBackend = "ombi"
+)
+
+type RequestHistory struct {
+	ID        int            `json:"id"`                // Primary key with auto-increment
+	RunID     string         `json:"run_id"`            // ID of the job run that considered the candidate
+	MediaType string         `json:"media_type"`        // Type of media (MOVIE, SHOW)
+	Title     string         `json:"title"`             // Title of the candidate
+	Year      *int           `json:"year,omitempty"`    // Year the candidate was released
+	TMDBID    *int           `json:"tmdb_id,omitempty"` // TMDb ID of the candidate
+	TVDBID    *int           `json:"tvdb_id,omitempty"` // TVDB ID of the candidate
+	IMDBID    *string        `json:"imdb_id,omitempty"` // IMDb ID of the candidate
+	Source    string         `json:"source"`            // List the candidate came from (e.g., movie-trending)
+	Backend   RequestBackend `json:"backend"`           // Where the candidate was requested (radarr, sonarr, ombi)
+	Outcome   RequestOutcome `json:"outcome"`           // What happened to the candidate
+	Error     *string        `json:"error,omitempty"`   // Error text if the request failed
+	Reason    *string        `json:"reason,omitempty"`  // Why the candidate was skipped, or per-instance details of the outcome
+	CreatedAt time.Time      `json:"created_at"`        // Time the candidate was considered
+}
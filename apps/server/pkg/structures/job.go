@@ -0,0 +1,45 @@
+package structures
+
+import "time"
+
+// JobPreview is what a list job would request if it ran right now
+type JobPreview struct {
+	JobType    string           `json:"job_type"`   // The list the candidates came from (e.g., movie-trending)
+	Limit      int              `json:"limit"`      // Maximum number of items the job requests per run
+	Candidates []JobPreviewItem `json:"candidates"` // Every item returned by Trakt, in list order
+}
+
+type JobPreviewItem struct {
+	Title    string `json:"title"`             // Title of the candidate
+	Year     int    `json:"year,omitempty"`    // Year the candidate was released
+	TMDBID   int    `json:"tmdb_id,omitempty"` // TMDb ID of the candidate
+	TVDBID   int    `json:"tvdb_id,omitempty"` // TVDB ID of the candidate
+	IMDBID   string `json:"imdb_id,omitempty"` // IMDb ID of the candidate
+	Included bool   `json:"included"`          // Whether the job would request the candidate
+	Reason   string `json:"reason,omitempty"`  // Why the candidate was filtered out
+}
+
+type JobRunStatus string
+
+func (jrs JobRunStatus) String() string {
+	return string(jrs)
+}
+
+const (
+	JobRunStatusRunning   JobRunStatus = "running"   // The job is still running
+	JobRunStatusCompleted JobRunStatus = "completed" // The job finished and every candidate was processed
+	JobRunStatusFailed    JobRunStatus = "failed"    // The job stopped early because of an error
+)
+
+type JobRun struct {
+	ID         string       `json:"id"`                    // Unique ID of the run, also used by the request history
+	JobType    string       `json:"job_type"`              // List the job ran for (e.g., movie-trending)
+	Status     JobRunStatus `json:"status"`                // Current status of the run
+	Added      int          `json:"added"`                 // Number of items that were added/requested
+	Skipped    int          `json:"skipped"`               // Number of items that were filtered out, already existed or only reported in dry-run mode
+	Failed     int          `json:"failed"`                // Number of items whose request failed
+	Owned      int          `json:"owned"`                 // Number of items that were already in the media server library
+	Error      *string      `json:"error,omitempty"`       // Why the run failed
+	StartedAt  time.Time    `json:"started_at"`            // Time the run started
+	FinishedAt *time.Time   `json:"finished_at,omitempty"` // Time the run finished
+}
@@ -0,0 +1,44 @@
+package structures
+
+import "time"
+
+type ListSourceMediaType string
+
+func (lsmt ListSourceMediaType) String() string {
+	return string(lsmt)
+}
+
+const (
+	ListSourceMediaTypeMovie ListSourceMediaType = "movie" // Items are requested from Radarr
+	ListSourceMediaTypeShow  ListSourceMediaType = "show"  // Items are requested from Sonarr
+)
+
+type ListSourceType string
+
+func (lst ListSourceType) String() string {
+	return string(lst)
+}
+
+const (
+	ListSourceTypeList            ListSourceType = "list"            // A user's public list, by username and list slug
+	ListSourceTypeWatchlist       ListSourceType = "watchlist"       // A user's watchlist, by username
+	ListSourceTypeRecommendations ListSourceType = "recommendations" // The recommendations for the authorized Trakt account
+)
+
+type ListSource struct {
+	ID         int                 `json:"id"`                  // Unique ID of the source
+	JobType    string              `json:"job_type"`            // Job type the source runs as (e.g., list-3)
+	Name       string              `json:"name"`                // Name the source is shown with
+	MediaType  ListSourceMediaType `json:"media_type"`          // Either "movie" or "show"
+	SourceType ListSourceType      `json:"source_type"`         // Either "list", "watchlist" or "recommendations"
+	Username   *string             `json:"username,omitempty"`  // Trakt user the list or watchlist belongs to
+	ListSlug   *string             `json:"list_slug,omitempty"` // Slug or Trakt ID of the list
+	Cron       *string             `json:"cron"`                // Cron expression the source is requested on, only run manually when empty
+	Limit      int                 `json:"limit"`               // How many items are requested every run
+	MinYear    *int                `json:"min_year"`            // Skip items released before the specified year
+	MaxYear    *int                `json:"max_year"`            // Skip items released after the specified year
+	MinRuntime *int                `json:"min_runtime"`         // Skip items shorter than the specified time in minutes
+	MaxRuntime *int                `json:"max_runtime"`         // Skip items longer than the specified time in minutes
+	Enabled    bool                `json:"enabled"`             // Whether the source is scheduled
+	CreatedAt  time.Time           `json:"created_at"`          // Time the source was added
+}
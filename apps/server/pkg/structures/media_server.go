@@ -0,0 +1,14 @@
+package structures
+
+import "time"
+
+type MediaServerSettings struct {
+	Enabled    bool       `json:"enabled"`     // Whether titles already in the media server library are skipped
+	ServerType *string    `json:"server_type"` // Kind of media server (plex, jellyfin, emby)
+	URL        *string    `json:"url"`         // Base URL of the media server
+	APIKey     *string    `json:"api_key"`     // Plex token or Jellyfin/Emby API key
+	Cron       *string    `json:"cron"`        // Cron expression the library cache is refreshed on, null to not schedule it
+	SyncedAt   *time.Time `json:"synced_at"`   // Time the library cache was last refreshed
+	Movies     int        `json:"movies"`      // Number of movies in the library cache
+	Shows      int        `json:"shows"`       // Number of shows in the library cache
+}
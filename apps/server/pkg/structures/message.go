@@ -25,7 +25,10 @@ func (t Topic) String() string {
 }
 
 const (
-	TopicJobRan Topic = "job.ran" // Topic for when a job has ran
+	TopicJobStarted Topic = "job.started" // Topic for when a job has started
+	TopicJobItem    Topic = "job.item"    // Topic for every title a job added, skipped or failed to request
+	TopicJobRan     Topic = "job.ran"     // Topic for when a job has ran
+	TopicLogCreated Topic = "log.created" // Topic for every new row in the logs table
 )
 
 type Message struct {
@@ -63,3 +66,23 @@ type DispatchPayload struct {
 	Topic Topic       `json:"topic"`
 	Data  interface{} `json:"data"`
 }
+
+// Dispatched on job.started
+type JobStartedPayload struct {
+	RunID     string    `json:"run_id"`
+	JobType   string    `json:"job_type"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+// Dispatched on job.item
+type JobItemPayload struct {
+	RunID     string         `json:"run_id"`
+	JobType   string         `json:"job_type"`
+	MediaType string         `json:"media_type"`        // Type of media (MOVIE, SHOW)
+	Title     string         `json:"title"`             // Title of the item
+	Year      int            `json:"year,omitempty"`    // Year the item was released
+	TMDBID    int            `json:"tmdb_id,omitempty"` // TMDb ID of the item
+	TVDBID    int            `json:"tvdb_id,omitempty"` // TVDB ID of the item
+	Outcome   RequestOutcome `json:"outcome"`           // What happened to the item
+	Reason    string         `json:"reason,omitempty"`  // Why the item was skipped or failed
+}
@@ -10,11 +10,26 @@ type MovieSettings struct {
 	CronJobPopular           *string                   `json:"cron_job_popular,omitempty"`     // Cron expression for the popular list
 	Trending                 *int                      `json:"trending,omitempty"`             // How many movies after every interval will grab from the trending list
 	CronJobTrending          *string                   `json:"cron_job_trending,omitempty"`    // Cron expression for the trending list
+	Watched                  *int                      `json:"watched,omitempty"`              // How many movies after every interval will grab from the most watched list
+	WatchedPeriod            string                    `json:"watched_period"`                 // Period of the most watched list ("weekly", "monthly", "yearly" or "all")
+	CronJobWatched           *string                   `json:"cron_job_watched,omitempty"`     // Cron expression for the most watched list
+	Played                   *int                      `json:"played,omitempty"`               // How many movies after every interval will grab from the most played list
+	PlayedPeriod             string                    `json:"played_period"`                  // Period of the most played list
+	CronJobPlayed            *string                   `json:"cron_job_played,omitempty"`      // Cron expression for the most played list
+	Collected                *int                      `json:"collected,omitempty"`            // How many movies after every interval will grab from the most collected list
+	CollectedPeriod          string                    `json:"collected_period"`               // Period of the most collected list
+	CronJobCollected         *string                   `json:"cron_job_collected,omitempty"`   // Cron expression for the most collected list
 	MaxRuntime               *int                      `json:"max_runtime,omitempty"`          // Blacklist movies with runtime longer than the specified time (in minutes)
 	MinRuntime               *int                      `json:"min_runtime,omitempty"`          // Blacklist movies with runtime shorter than the specified time (in minutes)
 	MinYear                  *int                      `json:"min_year,omitempty"`             // Blacklist movies released before the specified year. If empty, ignore the year.
 	MaxYear                  *int                      `json:"max_year,omitempty"`             // Blacklist movies released after the specified year. If empty, use the current year.
-	RottenTomatoes           *string                   `json:"rotten_tomatoes,omitempty"`      // Rotten Tomatoes rating filter for movies
+	RottenTomatoes           *string                   `json:"rotten_tomatoes,omitempty"`      // Minimum Rotten Tomatoes score on OMDb (0-100)
+	MinRating                *float64                  `json:"min_rating,omitempty"`           // Minimum Trakt rating (0-10)
+	MinVotes                 *int                      `json:"min_votes,omitempty"`            // Minimum number of Trakt votes
+	MinIMDbRating            *float64                  `json:"min_imdb_rating,omitempty"`      // Minimum IMDb rating on OMDb (0-10)
+	MinMetacritic            *int                      `json:"min_metacritic,omitempty"`       // Minimum Metacritic score on OMDb (0-100)
+	AllowedCertifications    []string                  `json:"allowed_certifications"`         // Certifications a movie must have one of, empty allows every certification
+	BlockedCertifications    []string                  `json:"blocked_certifications"`         // Certifications to skip
 	AllowedCountries         []MovieAllowedCountry     `json:"allowed_countries"`              // List of allowed countries
 	AllowedLanguages         []MovieAllowedLanguage    `json:"allowed_languages"`              // List of allowed languages
 	BlacklistedGenres        []BlacklistedGenre        `json:"blacklisted_genres"`             // List of blacklisted genres
@@ -38,10 +53,24 @@ type BlacklistedGenre struct {
 }
 
 type BlacklistedTitleKeyword struct {
-	ID      int    `json:"id"`      // Primary key with auto-increment
-	Keyword string `json:"keyword"` // Keyword to blacklist from the title of a movie
+	ID        int              `json:"id"`         // Primary key with auto-increment
+	Keyword   string           `json:"keyword"`    // Keyword to blacklist from the title of a movie
+	MatchMode KeywordMatchMode `json:"match_mode"` // Either "substring", "word" or "regex"
 }
 
+// KeywordMatchMode is how a blacklisted title keyword is matched against a title, always ignoring case
+type KeywordMatchMode string
+
+func (m KeywordMatchMode) String() string {
+	return string(m)
+}
+
+const (
+	KeywordMatchSubstring KeywordMatchMode = "substring" // The keyword appears anywhere in the title
+	KeywordMatchWord      KeywordMatchMode = "word"      // The keyword appears as a whole word, so "ted" doesn't match "Wanted"
+	KeywordMatchRegex     KeywordMatchMode = "regex"     // The keyword is a regular expression in RE2 syntax
+)
+
 type BlacklistedTMDBID struct {
 	ID     int `json:"id"`      // Primary key with auto-increment
 	TMDBID int `json:"tmdb_id"` // TMDb ID to blacklist
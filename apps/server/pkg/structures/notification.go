@@ -1,8 +1,22 @@
 package structures
 
+import "time"
+
 type NotificationType string
 
 const (
 	MOVIEADDEDALERT NotificationType = "MOVIE_ADDED"
 	SHOWADDEDALERT  NotificationType = "SHOW_ADDED"
 )
+
+type NotificationWebhook struct {
+	ID           int               `json:"id"`
+	Name         string            `json:"name"`          // Name the webhook is shown with
+	Enabled      bool              `json:"enabled"`       // Whether notifications are sent to the webhook
+	URL          string            `json:"url"`           // URL the request is sent to
+	Method       string            `json:"method"`        // HTTP method of the request (GET, POST, PUT, PATCH)
+	Headers      map[string]string `json:"headers"`       // Extra request headers, values are masked
+	BodyTemplate *string           `json:"body_template"` // Go text/template the request body is rendered from, null for the default JSON body
+	MaxRetries   int               `json:"max_retries"`   // How many times a failed request is retried
+	CreatedAt    time.Time         `json:"created_at"`    // Time the webhook was added
+}
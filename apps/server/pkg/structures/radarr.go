@@ -2,6 +2,7 @@ package structures
 
 type RadarrSettings struct {
 	ID                  int     `json:"id"`
+	Name                string  `json:"name"`
 	APIKey              *string `json:"api_key"`
 	URL                 *string `json:"base_url"`
 	MinimumAvailability *string `json:"minimum_availability"`
@@ -12,11 +12,30 @@ const (
 	// SettingSetupComplete is a flag to indicate if the setup is complete
 	SettingSetupComplete Setting = "SETUP_COMPLETE"
 	SettingMode          Setting = "MODE"
+
+	// SettingDryRun makes scheduled jobs report their candidates instead of requesting them
+	SettingDryRun Setting = "DRY_RUN"
+
+	// SettingTraktSkipWatched skips titles the authorized Trakt account already watched
+	SettingTraktSkipWatched Setting = "TRAKT_SKIP_WATCHED"
+
+	// SettingTagsEnabled adds titles to Radarr and Sonarr with tags
+	SettingTagsEnabled Setting = "TAGS_ENABLED"
+	// SettingTags is a comma-separated list of tags every title is added with
+	SettingTags Setting = "TAGS"
+	// SettingSourceTags also tags titles with the list they came from, e.g. "trakt-trending"
+	SettingSourceTags Setting = "SOURCE_TAGS"
+
+	// SettingRespectExclusions skips titles on the Radarr exclusion list and Sonarr import list exclusions
+	SettingRespectExclusions Setting = "RESPECT_EXCLUSIONS"
+	// SettingPushExclusions adds the blacklisted TMDb and TVDB IDs to the Radarr and Sonarr exclusion lists
+	SettingPushExclusions Setting = "PUSH_EXCLUSIONS"
 )
 
 func IsValidSettingKey(key Setting) bool {
 	switch key {
-	case SettingSetupComplete, SettingMode:
+	case SettingSetupComplete, SettingMode, SettingDryRun, SettingTraktSkipWatched,
+		SettingTagsEnabled, SettingTags, SettingSourceTags, SettingRespectExclusions, SettingPushExclusions:
 		return true
 	default:
 		return false
@@ -8,10 +8,30 @@ type ShowSettings struct {
 	CronJobPopular           *string                       `json:"cron_job_popular,omitempty"`     // Cron expression for the popular list
 	Trending                 *int                          `json:"trending,omitempty"`             // How many shows after every interval will grab from the trending list
 	CronJobTrending          *string                       `json:"cron_job_trending,omitempty"`    // Cron expression for the trending list
+	Watched                  *int                          `json:"watched,omitempty"`              // How many shows after every interval will grab from the most watched list
+	WatchedPeriod            string                        `json:"watched_period"`                 // Period of the most watched list ("weekly", "monthly", "yearly" or "all")
+	CronJobWatched           *string                       `json:"cron_job_watched,omitempty"`     // Cron expression for the most watched list
+	Played                   *int                          `json:"played,omitempty"`               // How many shows after every interval will grab from the most played list
+	PlayedPeriod             string                        `json:"played_period"`                  // Period of the most played list
+	CronJobPlayed            *string                       `json:"cron_job_played,omitempty"`      // Cron expression for the most played list
+	Collected                *int                          `json:"collected,omitempty"`            // How many shows after every interval will grab from the most collected list
+	CollectedPeriod          string                        `json:"collected_period"`               // Period of the most collected list
+	CronJobCollected         *string                       `json:"cron_job_collected,omitempty"`   // Cron expression for the most collected list
 	MaxRuntime               *int                          `json:"max_runtime,omitempty"`          // Blacklist shows with runtime longer than the specified time (in minutes)
 	MinRuntime               *int                          `json:"min_runtime,omitempty"`          // Blacklist shows with runtime shorter than the specified time (in minutes)
 	MinYear                  *int                          `json:"min_year,omitempty"`             // Blacklist shows released before the specified year. If empty, ignore the year.
 	MaxYear                  *int                          `json:"max_year,omitempty"`             // Blacklist shows released after the specified year. If empty, use the current year.
+	MinRating                *float64                      `json:"min_rating,omitempty"`           // Minimum Trakt rating (0-10)
+	MinVotes                 *int                          `json:"min_votes,omitempty"`            // Minimum number of Trakt votes
+	MinIMDbRating            *float64                      `json:"min_imdb_rating,omitempty"`      // Minimum IMDb rating on OMDb (0-10)
+	RottenTomatoes           *string                       `json:"rotten_tomatoes,omitempty"`      // Minimum Rotten Tomatoes score on OMDb (0-100)
+	MinMetacritic            *int                          `json:"min_metacritic,omitempty"`       // Minimum Metacritic score on OMDb (0-100)
+	AllowedCertifications    []string                      `json:"allowed_certifications"`         // Certifications a show must have one of, empty allows every certification
+	BlockedCertifications    []string                      `json:"blocked_certifications"`         // Certifications to skip
+	SeriesType               SonarrSeriesType              `json:"series_type"`                    // Series type shows are added to Sonarr with
+	SeasonFolder             bool                          `json:"season_folder"`                  // Whether Sonarr sorts the episodes into season folders
+	Monitor                  SonarrMonitor                 `json:"monitor"`                        // Episodes Sonarr monitors when a show is added
+	SearchOnAdd              bool                          `json:"search_on_add"`                  // Whether Sonarr searches for the monitored episodes when a show is added
 	AllowedCountries         []ShowAllowedCountry          `json:"allowed_countries"`              // List of allowed countries
 	AllowedLanguages         []ShowAllowedLanguage         `json:"allowed_languages"`              // List of allowed languages
 	BlacklistedGenres        []BlacklistedShowGenre        `json:"blacklisted_genres"`             // List of blacklisted genres
@@ -41,8 +61,9 @@ type BlacklistedNetwork struct {
 }
 
 type BlacklistedShowTitleKeyword struct {
-	ID      int    `json:"id"`      // Primary key with auto-increment
-	Keyword string `json:"keyword"` // Keyword to blacklist from the title of a show
+	ID        int              `json:"id"`         // Primary key with auto-increment
+	Keyword   string           `json:"keyword"`    // Keyword to blacklist from the title of a show
+	MatchMode KeywordMatchMode `json:"match_mode"` // Either "substring", "word" or "regex"
 }
 
 type BlacklistedTVDBID struct {
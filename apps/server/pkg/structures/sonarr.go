@@ -2,6 +2,7 @@ package structures
 
 type SonarrSettings struct {
 	ID           int     `json:"id"`
+	Name         string  `json:"name"`
 	APIKey       *string `json:"api_key"`
 	URL          *string `json:"url"`
 	Language     *string `json:"language"`
@@ -10,6 +11,55 @@ type SonarrSettings struct {
 	SeasonFolder *bool   `json:"season_folder"`
 }
 
+// SonarrSeriesType is how Sonarr names and searches the episodes of a series
+type SonarrSeriesType string
+
+func (t SonarrSeriesType) String() string {
+	return string(t)
+}
+
+const (
+	SonarrSeriesTypeStandard SonarrSeriesType = "standard" // Episodes are numbered by season, e.g. S01E05
+	SonarrSeriesTypeAnime    SonarrSeriesType = "anime"    // Episodes are numbered absolutely
+	SonarrSeriesTypeDaily    SonarrSeriesType = "daily"    // Episodes are identified by their air date, like talk shows
+)
+
+// IsValidSonarrSeriesType checks if the series type is one Sonarr supports
+func IsValidSonarrSeriesType(seriesType string) bool {
+	switch SonarrSeriesType(seriesType) {
+	case SonarrSeriesTypeStandard, SonarrSeriesTypeAnime, SonarrSeriesTypeDaily:
+		return true
+	}
+
+	return false
+}
+
+// SonarrMonitor is which episodes Sonarr monitors when a series is added, matching Sonarr's addOptions.monitor
+type SonarrMonitor string
+
+func (m SonarrMonitor) String() string {
+	return string(m)
+}
+
+const (
+	SonarrMonitorAll          SonarrMonitor = "all"          // Every episode except specials
+	SonarrMonitorFuture       SonarrMonitor = "future"       // Only episodes that haven't aired yet
+	SonarrMonitorFirstSeason  SonarrMonitor = "firstSeason"  // Only the episodes of the first season
+	SonarrMonitorLatestSeason SonarrMonitor = "latestSeason" // Only the episodes of the latest season
+	SonarrMonitorPilot        SonarrMonitor = "pilot"        // Only the first episode of the first season
+	SonarrMonitorNone         SonarrMonitor = "none"         // The series is added unmonitored
+)
+
+// IsValidSonarrMonitor checks if the monitor option is one that can be picked
+func IsValidSonarrMonitor(monitor string) bool {
+	switch SonarrMonitor(monitor) {
+	case SonarrMonitorAll, SonarrMonitorFuture, SonarrMonitorFirstSeason, SonarrMonitorLatestSeason, SonarrMonitorPilot, SonarrMonitorNone:
+		return true
+	}
+
+	return false
+}
+
 type SonarrQualityProfile struct {
 	Name              string              `json:"name"`
 	UpgradeAllowed    bool                `json:"upgradeAllowed"`
@@ -1,7 +1,59 @@
 package structures
 
+import "time"
+
 type TraktSettings struct {
 	ID           int    `json:"id"`
 	ClientID     string `json:"client_id"`
 	ClientSecret string `json:"client_secret"`
 }
+
+// TraktAuthStatus describes the Trakt account authorized through the device-code flow
+type TraktAuthStatus struct {
+	Authorized bool       `json:"authorized"`           // Whether an account is authorized
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"` // When the access token expires, it's refreshed automatically before then
+}
+
+type TraktAuthPollPayload struct {
+	DeviceCode string `json:"device_code"` // Device code returned when the authorization was started
+}
+
+type TraktAuthPollStatus string
+
+func (s TraktAuthPollStatus) String() string {
+	return string(s)
+}
+
+const (
+	TraktAuthAuthorized TraktAuthPollStatus = "authorized" // The user approved the code and the tokens are stored
+	TraktAuthPending    TraktAuthPollStatus = "pending"    // The user hasn't approved the code yet
+	TraktAuthSlowDown   TraktAuthPollStatus = "slow_down"  // Polled faster than the interval, wait longer before polling again
+)
+
+type TraktAuthPollResponse struct {
+	Status TraktAuthPollStatus `json:"status"` // Either "authorized", "pending" or "slow_down"
+}
+
+// TraktPeriod is the time period the most watched, played and collected lists cover
+type TraktPeriod string
+
+func (p TraktPeriod) String() string {
+	return string(p)
+}
+
+const (
+	TraktPeriodWeekly  TraktPeriod = "weekly"
+	TraktPeriodMonthly TraktPeriod = "monthly"
+	TraktPeriodYearly  TraktPeriod = "yearly"
+	TraktPeriodAll     TraktPeriod = "all"
+)
+
+// IsValidTraktPeriod checks if the period is one the lists support
+func IsValidTraktPeriod(period string) bool {
+	switch TraktPeriod(period) {
+	case TraktPeriodWeekly, TraktPeriodMonthly, TraktPeriodYearly, TraktPeriodAll:
+		return true
+	}
+
+	return false
+}
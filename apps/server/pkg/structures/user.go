@@ -0,0 +1,17 @@
+package structures
+
+import "time"
+
+type User struct {
+	ID        int       `json:"id"`          // Unique ID of the user
+	Username  string    `json:"username"`    // Name the user signs in with
+	HasAPIKey bool      `json:"has_api_key"` // Whether the user has generated an API key
+	CreatedAt time.Time `json:"created_at"`  // Time the user was created
+}
+
+// AuthStatus tells the UI which of the setup wizard, registration or sign in to show
+type AuthStatus struct {
+	SetupComplete          bool `json:"setup_complete"`           // Whether the setup wizard has been finished
+	HasUsers               bool `json:"has_users"`                // Whether the admin user exists
+	BootstrapTokenRequired bool `json:"bootstrap_token_required"` // Whether registering needs the bootstrap token
+}
@@ -93,6 +93,14 @@ func NullBoolToPointer(nb sql.NullBool) *bool {
 	return nil
 }
 
+// Utility function to convert sql.NullFloat64 to *float64 for JSON serialization
+func NullFloatToPointer(nf sql.NullFloat64) *float64 {
+	if nf.Valid {
+		return &nf.Float64
+	}
+	return nil
+}
+
 // Utility function to convert *int to sql.NullInt32
 func PointerToNullInt32(ptr *int) sql.NullInt32 {
 	if ptr != nil {
@@ -160,3 +168,45 @@ func PointerToNullBool(ptr *bool) sql.NullBool {
 		Valid: false,
 	}
 }
+
+// Utility function to convert *float64 to sql.NullFloat64
+func PointerToNullFloat64(ptr *float64) sql.NullFloat64 {
+	if ptr != nil {
+		return sql.NullFloat64{
+			Float64: *ptr,
+			Valid:   true,
+		}
+	}
+	return sql.NullFloat64{
+		Float64: 0,
+		Valid:   false,
+	}
+}
+
+// Patch overwrites dst with the value ptr points to, a nil pointer leaves dst as it is
+func Patch[T any](dst *T, ptr *T) {
+	if ptr != nil {
+		*dst = *ptr
+	}
+}
+
+// PatchNullInt32 overwrites dst with the value ptr points to, a nil pointer leaves dst as it is
+func PatchNullInt32(dst *sql.NullInt32, ptr *int) {
+	if ptr != nil {
+		*dst = PointerToNullInt32(ptr)
+	}
+}
+
+// PatchNullString overwrites dst with the value ptr points to, a nil pointer leaves dst as it is
+func PatchNullString(dst *sql.NullString, ptr *string) {
+	if ptr != nil {
+		*dst = PointerToNullString(ptr)
+	}
+}
+
+// PatchNullFloat64 overwrites dst with the value ptr points to, a nil pointer leaves dst as it is
+func PatchNullFloat64(dst *sql.NullFloat64, ptr *float64) {
+	if ptr != nil {
+		*dst = PointerToNullFloat64(ptr)
+	}
+}
@@ -0,0 +1,25 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/robfig/cron/v3"
+)
+
+// ValidateCronExpression returns an error if the expression can't be used to schedule a job
+func ValidateCronExpression(expr string) error {
+	_, err := cron.ParseStandard(expr)
+	return err
+}
+
+// ParseRottenTomatoes parses a Rotten Tomatoes threshold like "70" or "70%"
+func ParseRottenTomatoes(value string) (int, error) {
+	score, err := strconv.Atoi(strings.TrimSuffix(strings.TrimSpace(value), "%"))
+	if err != nil || score < 0 || score > 100 {
+		return 0, fmt.Errorf("expected a percentage between 0 and 100")
+	}
+
+	return score, nil
+}
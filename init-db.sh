@@ -1,17 +1,11 @@
 #!/bin/sh
 
-DB_PATH="/app/data/settings.db"
-MIGRATIONS_PATH="/migrations/schema.sql"
-LOG_FILE="/app/data/init.log"
+DATA_PATH="/app/data"
+LOG_FILE="$DATA_PATH/init.log"
 
-# Check if the log file exists to avoid repeated initialization
-if [ ! -f "$DB_PATH" ]; then
-    echo "$(date): SQLite database not found. Initializing..." | tee -a "$LOG_FILE"
-    sqlite3 "$DB_PATH" < "$MIGRATIONS_PATH"
-    echo "$(date): Database initialization complete." | tee -a "$LOG_FILE"
-else
-    echo "$(date): SQLite database already exists. Skipping initialization." | tee -a "$LOG_FILE"
-fi
+# The server creates and migrates the SQLite database on startup, only make sure the data directory exists
+mkdir -p "$DATA_PATH"
+echo "$(date): Data directory ready. Database migrations are applied by the server on startup." | tee -a "$LOG_FILE"
 
 # Continue with the main process
 exec "$@"